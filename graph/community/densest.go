@@ -0,0 +1,131 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package community
+
+import (
+	"container/heap"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// DensestSubgraph returns a set of nodes of g inducing a subgraph of high density, and
+// the density of the induced subgraph, the ratio of its number of edges to its number of
+// nodes. The returned nodes are sorted by ID. Edges are treated as undirected; for
+// directed graphs a pair of nodes joined in either or both directions is counted as a
+// single edge. Self loops are ignored. If g has no nodes, DensestSubgraph returns nil
+// and zero.
+//
+// DensestSubgraph uses Charikar's greedy peeling algorithm, repeatedly removing the
+// node of minimum degree and retaining the densest of the subgraphs seen. The density
+// of the returned subgraph is at least half the maximum density of any subgraph of g.
+//
+// See Charikar, M. "Greedy approximation algorithms for finding dense components in
+// a graph." APPROX 2000. doi:10.1007/3-540-44436-X_10.
+func DensestSubgraph(g graph.Graph) ([]graph.Node, float64) {
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) == 0 {
+		return nil, 0
+	}
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	adj := make([]map[int]struct{}, len(nodes))
+	for i := range adj {
+		adj[i] = make(map[int]struct{})
+	}
+	var edges int
+	for i, u := range nodes {
+		to := g.From(u.ID())
+		for to.Next() {
+			j := indexOf[to.Node().ID()]
+			if i == j {
+				continue
+			}
+			if _, ok := adj[i][j]; ok {
+				continue
+			}
+			adj[i][j] = struct{}{}
+			adj[j][i] = struct{}{}
+			edges++
+		}
+	}
+
+	degree := make([]int, len(nodes))
+	queue := make(degreeQueue, len(nodes))
+	for i := range nodes {
+		degree[i] = len(adj[i])
+		queue[i] = degreeItem{index: i, degree: degree[i]}
+	}
+	heap.Init(&queue)
+
+	// removed holds the nodes in order of removal. The
+	// densest subgraph is the set of nodes remaining
+	// after the first best of them have been removed.
+	removed := make([]int, 0, len(nodes))
+	gone := make([]bool, len(nodes))
+	best := 0
+	bestDensity := float64(edges) / float64(len(nodes))
+	for queue.Len() != 0 {
+		v := heap.Pop(&queue).(degreeItem)
+		if gone[v.index] || v.degree != degree[v.index] {
+			continue
+		}
+		gone[v.index] = true
+		removed = append(removed, v.index)
+		edges -= degree[v.index]
+		for u := range adj[v.index] {
+			if gone[u] {
+				continue
+			}
+			degree[u]--
+			heap.Push(&queue, degreeItem{index: u, degree: degree[u]})
+		}
+		remaining := len(nodes) - len(removed)
+		if remaining == 0 {
+			break
+		}
+		if d := float64(edges) / float64(remaining); d > bestDensity {
+			best = len(removed)
+			bestDensity = d
+		}
+	}
+
+	for i := range gone {
+		gone[i] = false
+	}
+	for _, i := range removed[:best] {
+		gone[i] = true
+	}
+	dense := make([]graph.Node, 0, len(nodes)-best)
+	for i, n := range nodes {
+		if !gone[i] {
+			dense = append(dense, n)
+		}
+	}
+	sort.Sort(ordered.ByID(dense))
+	return dense, bestDensity
+}
+
+type degreeItem struct {
+	index  int
+	degree int
+}
+
+// degreeQueue implements a no-dec priority queue of degreeItems.
+type degreeQueue []degreeItem
+
+func (q degreeQueue) Len() int            { return len(q) }
+func (q degreeQueue) Less(i, j int) bool  { return q[i].degree < q[j].degree }
+func (q degreeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *degreeQueue) Push(n interface{}) { *q = append(*q, n.(degreeItem)) }
+func (q *degreeQueue) Pop() interface{} {
+	t := *q
+	var n interface{}
+	n, *q = t[len(t)-1], t[:len(t)-1]
+	return n
+}
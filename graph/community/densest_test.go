@@ -0,0 +1,72 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package community
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestDensestSubgraph(t *testing.T) {
+	// A 10-clique on nodes 0-9 embedded in a sparse random
+	// background of 90 nodes with average degree about 3.
+	const (
+		clique = 10
+		n      = 100
+	)
+	rnd := rand.New(rand.NewSource(1))
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < n; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	for i := 0; i < clique; i++ {
+		for j := i + 1; j < clique; j++ {
+			g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(j)})
+		}
+	}
+	for added := 0; added < 150; {
+		u, v := rnd.Intn(n), rnd.Intn(n)
+		if u == v || (u < clique && v < clique) || g.HasEdgeBetween(int64(u), int64(v)) {
+			continue
+		}
+		g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		added++
+	}
+
+	nodes, density := DensestSubgraph(g)
+	var got []int64
+	for _, n := range nodes {
+		got = append(got, n.ID())
+	}
+	want := []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected densest subgraph: got:%v want:%v", got, want)
+	}
+	if want := float64(clique-1) / 2; density != want {
+		t.Errorf("unexpected density: got:%v want:%v", density, want)
+	}
+}
+
+func TestDensestSubgraphTrivial(t *testing.T) {
+	if nodes, density := DensestSubgraph(simple.NewUndirectedGraph()); nodes != nil || density != 0 {
+		t.Errorf("unexpected result for empty graph: got:%v,%v want:nil,0", nodes, density)
+	}
+
+	// Edges in both directions between a pair of
+	// directed nodes are counted once.
+	d := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 0}, {1, 2}, {2, 0}} {
+		d.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	d.AddNode(simple.Node(3))
+	nodes, density := DensestSubgraph(d)
+	if len(nodes) != 3 || density != 1 {
+		t.Errorf("unexpected result for directed triangle: got:%d nodes density:%v want:3 nodes density:1", len(nodes), density)
+	}
+}
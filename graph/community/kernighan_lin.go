@@ -0,0 +1,180 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package community
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/path"
+)
+
+// KernighanLin refines the bisection of the undirected graph g given by partition,
+// which maps each node ID of g to one of two part labels, using the Kernighan-Lin
+// heuristic. In each pass, pairs of nodes from opposite parts are tentatively swapped
+// in order of decreasing gain in cut weight, with each node swapped at most once, and
+// the prefix of swaps with the largest total gain is kept. Refinement stops after
+// passes passes or when a pass does not reduce the cut. Since nodes are exchanged in
+// pairs, the sizes of the parts are unchanged and the cut weight never increases.
+// The refined partition is returned as a new map using the labels of partition.
+// KernighanLin is suitable for refining the result of network.SpectralBisection.
+//
+// If weight is nil, the Weight method of g is used if g implements graph.Weighted,
+// falling back to path.UniformCost otherwise. Self edges are ignored. Each pass takes
+// O(n³) time for a graph with n nodes. KernighanLin will panic if a node of g is not
+// in partition or if partition holds more than two labels.
+func KernighanLin(g graph.Undirected, partition map[int64]int, weight path.Weighting, passes int) map[int64]int {
+	if weight == nil {
+		if wg, ok := g.(graph.Weighted); ok {
+			weight = wg.Weight
+		} else {
+			weight = path.UniformCost(g)
+		}
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+
+	refined := make(map[int64]int, len(partition))
+	for id, p := range partition {
+		refined[id] = p
+	}
+	labels := make([]int, 0, 2)
+	part := make([]int, len(nodes))
+	for i, n := range nodes {
+		p, ok := partition[n.ID()]
+		if !ok {
+			panic("community: node missing from partition")
+		}
+		switch {
+		case len(labels) == 0:
+			labels = append(labels, p)
+		case p == labels[0]:
+		case len(labels) == 1:
+			labels = append(labels, p)
+		case p != labels[1]:
+			panic("community: partition is not a bisection")
+		}
+		if p != labels[0] {
+			part[i] = 1
+		}
+	}
+	if len(labels) < 2 {
+		return refined
+	}
+
+	newPartLevel(g, nodes, weight).kernighanLin(part, 0, 1, passes)
+
+	for i, n := range nodes {
+		refined[n.ID()] = labels[part[i]]
+	}
+	return refined
+}
+
+// kernighanLin refines the bisection of the nodes of l in parts p and q of
+// part in place using the Kernighan-Lin heuristic, making at most passes
+// passes.
+func (l *partLevel) kernighanLin(part []int, p, q, passes int) {
+	var members []int
+	for v, pv := range part {
+		if pv == p || pv == q {
+			members = append(members, v)
+		}
+	}
+	indexOf := make(map[int]int, len(members))
+	for i, v := range members {
+		indexOf[v] = i
+	}
+	side := make([]bool, len(members))
+	c := make([][]float64, len(members))
+	for i, v := range members {
+		side[i] = part[v] == q
+		c[i] = make([]float64, len(members))
+		for _, e := range l.adj[v] {
+			if j, ok := indexOf[e.to]; ok {
+				c[i][j] = e.weight
+			}
+		}
+	}
+
+	d := make([]float64, len(members))
+	locked := make([]bool, len(members))
+	for pass := 0; pass < passes; pass++ {
+		// d holds the reduction in cut weight obtained by
+		// moving each node to the other part alone.
+		for i := range members {
+			d[i] = 0
+			for j, w := range c[i] {
+				if side[i] != side[j] {
+					d[i] += w
+				} else {
+					d[i] -= w
+				}
+			}
+			locked[i] = false
+		}
+
+		var (
+			swaps          [][2]int
+			gain, bestGain float64
+			best           int
+		)
+		for {
+			a, b := -1, -1
+			step := math.Inf(-1)
+			for i := range members {
+				if locked[i] || side[i] {
+					continue
+				}
+				for j := range members {
+					if locked[j] || !side[j] {
+						continue
+					}
+					if s := d[i] + d[j] - 2*c[i][j]; s > step {
+						a, b, step = i, j, s
+					}
+				}
+			}
+			if a < 0 {
+				break
+			}
+			locked[a] = true
+			locked[b] = true
+			for k := range members {
+				if locked[k] {
+					continue
+				}
+				if side[k] {
+					d[k] += 2*c[k][b] - 2*c[k][a]
+				} else {
+					d[k] += 2*c[k][a] - 2*c[k][b]
+				}
+			}
+			swaps = append(swaps, [2]int{a, b})
+			gain += step
+			if gain > bestGain {
+				bestGain = gain
+				best = len(swaps)
+			}
+		}
+		if best == 0 {
+			break
+		}
+		for _, s := range swaps[:best] {
+			side[s[0]] = true
+			side[s[1]] = false
+		}
+	}
+
+	for i, v := range members {
+		if side[i] {
+			part[v] = q
+		} else {
+			part[v] = p
+		}
+	}
+}
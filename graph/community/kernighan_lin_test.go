@@ -0,0 +1,106 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package community
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestKernighanLin(t *testing.T) {
+	// Two K4 joined by an edge, with nodes 3 and 4 of
+	// the partition on the wrong side of the bridge.
+	g := simple.NewUndirectedGraph()
+	for _, c := range [][]int64{{0, 1, 2, 3}, {4, 5, 6, 7}} {
+		for i, u := range c {
+			for _, v := range c[i+1:] {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+	}
+	g.SetEdge(simple.Edge{F: simple.Node(3), T: simple.Node(4)})
+	partition := map[int64]int{0: 1, 1: 1, 2: 1, 3: 2, 4: 1, 5: 2, 6: 2, 7: 2}
+
+	got := KernighanLin(g, partition, nil, 10)
+	want := map[int64]int{0: 1, 1: 1, 2: 1, 3: 1, 4: 2, 5: 2, 6: 2, 7: 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected partition: got:%v want:%v", got, want)
+	}
+	if c := cutWeight(g, got); c != 1 {
+		t.Errorf("unexpected cut weight: got:%v want:1", c)
+	}
+	if partition[3] != 2 {
+		t.Error("input partition modified")
+	}
+
+	got = KernighanLin(g, partition, nil, 0)
+	if !reflect.DeepEqual(got, partition) {
+		t.Errorf("unexpected partition with no passes: got:%v want:%v", got, partition)
+	}
+}
+
+func TestKernighanLinRandom(t *testing.T) {
+	const n = 30
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for k := 0; k < 3*n; k++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u != v {
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(1 + rnd.Intn(5))})
+			}
+		}
+		partition := make(map[int64]int, n)
+		for i, p := range rnd.Perm(n) {
+			partition[int64(i)] = p % 2
+		}
+
+		before := cutWeight(g, partition)
+		got := KernighanLin(g, partition, nil, 5)
+		after := cutWeight(g, got)
+		if after > before {
+			t.Errorf("cut weight increased for trial %d: before:%v after:%v", trial, before, after)
+		}
+		if after == before {
+			t.Errorf("cut weight not reduced for random partition in trial %d: %v", trial, before)
+		}
+		var size [2]int
+		for _, p := range got {
+			size[p]++
+		}
+		if size[0] != n/2 || size[1] != n/2 {
+			t.Errorf("unexpected part sizes for trial %d: got:%v want:[%d %d]", trial, size, n/2, n/2)
+		}
+	}
+}
+
+// cutWeight returns the total weight of edges of g between the parts
+// of partition.
+func cutWeight(g graph.Undirected, partition map[int64]int) float64 {
+	var cut float64
+	nodes := graph.NodesOf(g.Nodes())
+	for _, u := range nodes {
+		uid := u.ID()
+		for _, v := range graph.NodesOf(g.From(uid)) {
+			vid := v.ID()
+			if uid < vid && partition[uid] != partition[vid] {
+				w := 1.0
+				if wg, ok := g.(graph.Weighted); ok {
+					w, _ = wg.Weight(uid, vid)
+				}
+				cut += w
+			}
+		}
+	}
+	return cut
+}
@@ -0,0 +1,104 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package community
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// Modularity returns the modularity Q score of the graph g partitioned by
+// the given community assignment at resolution 1. The partition maps node
+// IDs to community labels; nodes of g that are not in partition are each
+// placed in their own community. Edge weights are given by weight.
+//
+// If weight is nil, the Weight method of g is used if g implements graph.Weighted,
+// falling back to path.UniformCost otherwise.
+//
+// Modularity allows the quality of community structures found by different
+// methods to be compared. It is calculated by Q and will panic under the
+// same conditions.
+func Modularity(g graph.Graph, partition map[int64]int, weight path.Weighting) float64 {
+	if weight != nil {
+		switch u := g.(type) {
+		case graph.Undirected:
+			g = undirectedWeighting{Undirected: u, weight: weight}
+		case graph.Directed:
+			g = directedWeighting{Directed: u, weight: weight}
+		}
+	}
+	return Q(g, communitiesOf(g, partition), 1)
+}
+
+// undirectedWeighting is an undirected graph with
+// edge weights given by a path.Weighting.
+type undirectedWeighting struct {
+	graph.Undirected
+	weight path.Weighting
+}
+
+func (g undirectedWeighting) Weight(xid, yid int64) (w float64, ok bool) {
+	return g.weight(xid, yid)
+}
+
+func (g undirectedWeighting) WeightedEdge(uid, vid int64) graph.WeightedEdge {
+	e := g.Edge(uid, vid)
+	if e == nil {
+		return nil
+	}
+	w, _ := g.weight(uid, vid)
+	return simple.WeightedEdge{F: e.From(), T: e.To(), W: w}
+}
+
+// directedWeighting is a directed graph with
+// edge weights given by a path.Weighting.
+type directedWeighting struct {
+	graph.Directed
+	weight path.Weighting
+}
+
+func (g directedWeighting) Weight(xid, yid int64) (w float64, ok bool) {
+	return g.weight(xid, yid)
+}
+
+func (g directedWeighting) WeightedEdge(uid, vid int64) graph.WeightedEdge {
+	e := g.Edge(uid, vid)
+	if e == nil {
+		return nil
+	}
+	w, _ := g.weight(uid, vid)
+	return simple.WeightedEdge{F: e.From(), T: e.To(), W: w}
+}
+
+// communitiesOf returns the communities of g described by a partition.
+// Communities are ordered by label and nodes missing from partition
+// are placed in singleton communities after the labelled communities.
+func communitiesOf(g graph.Graph, partition map[int64]int) [][]graph.Node {
+	members := make(map[int][]graph.Node)
+	var singletons [][]graph.Node
+	nodes := g.Nodes()
+	for nodes.Next() {
+		n := nodes.Node()
+		c, ok := partition[n.ID()]
+		if !ok {
+			singletons = append(singletons, []graph.Node{n})
+			continue
+		}
+		members[c] = append(members[c], n)
+	}
+	labels := make([]int, 0, len(members))
+	for c := range members {
+		labels = append(labels, c)
+	}
+	sort.Ints(labels)
+	communities := make([][]graph.Node, 0, len(labels)+len(singletons))
+	for _, c := range labels {
+		communities = append(communities, members[c])
+	}
+	return append(communities, singletons...)
+}
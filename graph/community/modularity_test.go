@@ -0,0 +1,98 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package community
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestModularity(t *testing.T) {
+	// Two triangles joined by a single edge.
+	g := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{
+		{0, 1}, {0, 2}, {1, 2},
+		{3, 4}, {3, 5}, {4, 5},
+		{2, 3},
+	} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+
+	clustered := map[int64]int{0: 0, 1: 0, 2: 0, 3: 1, 4: 1, 5: 1}
+	mixed := map[int64]int{0: 0, 1: 1, 2: 0, 3: 1, 4: 0, 5: 1}
+
+	qClustered := Modularity(g, clustered, nil)
+	qMixed := Modularity(g, mixed, nil)
+	if qClustered <= qMixed {
+		t.Errorf("expected clustered partition to score higher: clustered=%v mixed=%v", qClustered, qMixed)
+	}
+
+	// Q = 1/2m \sum_c (2·l_c - d_c^2/2m) with l_c = 3, d_c = 7 and m = 7.
+	want := 2 * (3.0/7 - math.Pow(7.0/14, 2))
+	if !floats.EqualWithinAbsOrRel(qClustered, want, 1e-12, 1e-12) {
+		t.Errorf("unexpected modularity: got:%v want:%v", qClustered, want)
+	}
+	if q := Q(g, [][]graph.Node{
+		{simple.Node(0), simple.Node(1), simple.Node(2)},
+		{simple.Node(3), simple.Node(4), simple.Node(5)},
+	}, 1); !floats.EqualWithinAbsOrRel(qClustered, q, 1e-12, 1e-12) {
+		t.Errorf("modularity does not match Q: got:%v want:%v", qClustered, q)
+	}
+
+	// Unassigned nodes are singletons.
+	partial := Modularity(g, map[int64]int{0: 0, 1: 0, 2: 0}, nil)
+	if q := Q(g, [][]graph.Node{
+		{simple.Node(0), simple.Node(1), simple.Node(2)},
+		{simple.Node(3)}, {simple.Node(4)}, {simple.Node(5)},
+	}, 1); !floats.EqualWithinAbsOrRel(partial, q, 1e-12, 1e-12) {
+		t.Errorf("unexpected modularity for partial partition: got:%v want:%v", partial, q)
+	}
+}
+
+func TestModularityWeighted(t *testing.T) {
+	// A four cycle with two heavy edges.
+	g := simple.NewWeightedUndirectedGraph(0, 0)
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 5},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 5},
+		{F: simple.Node(3), T: simple.Node(0), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	heavy := map[int64]int{0: 0, 1: 0, 2: 1, 3: 1}
+	light := map[int64]int{0: 0, 3: 0, 1: 1, 2: 1}
+
+	if qHeavy, qLight := Modularity(g, heavy, nil), Modularity(g, light, nil); qHeavy <= qLight {
+		t.Errorf("expected partition along heavy edges to score higher: heavy=%v light=%v", qHeavy, qLight)
+	}
+	// Each community holds weight 5 of the total 12
+	// and half of the total degree.
+	want := 2 * (5.0/12 - math.Pow(12.0/24, 2))
+	if got := Modularity(g, heavy, nil); !floats.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+		t.Errorf("unexpected weighted modularity: got:%v want:%v", got, want)
+	}
+
+	// A weighting overrides the weights of g, here
+	// making the light edges heavy.
+	swapped := func(xid, yid int64) (float64, bool) {
+		w, ok := g.Weight(xid, yid)
+		if !ok || xid == yid {
+			return w, ok
+		}
+		return 6 - w, true
+	}
+	if got := Modularity(g, light, swapped); !floats.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+		t.Errorf("unexpected modularity with weighting: got:%v want:%v", got, want)
+	}
+	if got, unit := Modularity(g, heavy, path.UniformCost(g)), 2*(1.0/4-math.Pow(4.0/8, 2)); !floats.EqualWithinAbsOrRel(got, unit, 1e-12, 1e-12) {
+		t.Errorf("unexpected modularity with uniform cost: got:%v want:%v", got, unit)
+	}
+}
@@ -0,0 +1,369 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package community
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/path"
+)
+
+const (
+	// partitionImbalance is the fractional excess
+	// over the mean part size allowed for a part.
+	partitionImbalance = 0.03
+
+	// partitionPasses is the maximum number of
+	// refinement passes made at each level.
+	partitionPasses = 10
+)
+
+// BalancedPartition returns a partition of the nodes of g into k parts of
+// roughly equal size chosen to minimize the total weight of edges cut by the
+// partition. The returned map holds a part label in [0, k) for each node ID
+// in g. Edges are treated as undirected; for directed graphs the weights of
+// edges in both directions between a pair of nodes are summed.
+//
+// BalancedPartition uses a multilevel scheme. The graph is repeatedly coarsened
+// by contracting a heavy edge matching, the coarsest graph is partitioned by
+// greedy region growing, and the partition is projected back through each level
+// with a Kernighan-Lin style refinement that moves boundary nodes between parts
+// to reduce the cut while keeping parts within 3% of the mean part size. The
+// result is a heuristic; it is not guaranteed to be the minimum balanced cut.
+// Nodes are considered in order of ID, so the partition found for a given graph
+// does not depend on the order in which g returns its nodes.
+//
+// If weight is nil, the Weight method of g is used if g implements graph.Weighted,
+// falling back to path.UniformCost otherwise. BalancedPartition will panic if k is
+// less than one.
+func BalancedPartition(g graph.Graph, k int, weight path.Weighting) map[int64]int {
+	if k < 1 {
+		panic("community: invalid number of parts")
+	}
+	if weight == nil {
+		if wg, ok := g.(graph.Weighted); ok {
+			weight = wg.Weight
+		} else {
+			weight = path.UniformCost(g)
+		}
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	partition := make(map[int64]int, len(nodes))
+	if len(nodes) == 0 {
+		return partition
+	}
+	if k == 1 {
+		for _, n := range nodes {
+			partition[n.ID()] = 0
+		}
+		return partition
+	}
+
+	levels := []*partLevel{newPartLevel(g, nodes, weight)}
+	var matches [][]int
+	coarsest := 10 * k
+	for {
+		l := levels[len(levels)-1]
+		if len(l.weight) <= coarsest {
+			break
+		}
+		c, match := l.coarsenByMatching(k)
+		if 10*len(c.weight) > 9*len(l.weight) {
+			// Coarsening is no longer effective.
+			break
+		}
+		levels = append(levels, c)
+		matches = append(matches, match)
+	}
+
+	maxWeight := int(math.Ceil(float64(len(nodes)) / float64(k) * (1 + partitionImbalance)))
+	part := levels[len(levels)-1].grow(k)
+	for i := len(levels) - 1; i >= 0; i-- {
+		if i < len(levels)-1 {
+			fine := make([]int, len(levels[i].weight))
+			for j, c := range matches[i] {
+				fine[j] = part[c]
+			}
+			part = fine
+		}
+		levels[i].refine(part, k, maxWeight)
+	}
+
+	for i, n := range nodes {
+		partition[n.ID()] = part[i]
+	}
+	return partition
+}
+
+// partLevel is a level in a multilevel partitioning. Each node of a level
+// represents a set of nodes of the original graph.
+type partLevel struct {
+	// weight holds the number of original
+	// nodes represented by each node.
+	weight []int
+	// adj holds the edges of each node,
+	// sorted by the index of the neighbor.
+	adj [][]partEdge
+}
+
+type partEdge struct {
+	to     int
+	weight float64
+}
+
+// newPartLevel returns the finest level for a partitioning of g.
+func newPartLevel(g graph.Graph, nodes []graph.Node, weight path.Weighting) *partLevel {
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	_, undirected := g.(graph.Undirected)
+	adj := make([]map[int]float64, len(nodes))
+	for i := range adj {
+		adj[i] = make(map[int]float64)
+	}
+	for i, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			j := indexOf[vid]
+			if i == j || (undirected && j < i) {
+				continue
+			}
+			w, ok := weight(uid, vid)
+			if !ok {
+				panic("community: unexpected invalid weight")
+			}
+			adj[i][j] += w
+			adj[j][i] += w
+		}
+	}
+	l := &partLevel{weight: make([]int, len(nodes)), adj: make([][]partEdge, len(nodes))}
+	for i := range nodes {
+		l.weight[i] = 1
+		l.adj[i] = partEdges(adj[i])
+	}
+	return l
+}
+
+// partEdges returns the edges described by the neighbor weights in adj.
+func partEdges(adj map[int]float64) []partEdge {
+	edges := make([]partEdge, 0, len(adj))
+	for v, w := range adj {
+		edges = append(edges, partEdge{to: v, weight: w})
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i].to < edges[j].to })
+	return edges
+}
+
+// coarsenByMatching returns the level obtained by contracting a heavy edge
+// matching of l and the index of the coarse node containing each node of l.
+// Nodes are visited in order of increasing degree and matched with the
+// unmatched neighbor joined by the heaviest edge. Nodes are not merged if
+// the result would be too heavy to allow a balanced k-way partition.
+func (l *partLevel) coarsenByMatching(k int) (*partLevel, []int) {
+	var total int
+	for _, w := range l.weight {
+		total += w
+	}
+	limit := total / (4 * k)
+	if limit < 2 {
+		limit = 2
+	}
+
+	order := make([]int, len(l.weight))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return len(l.adj[order[i]]) < len(l.adj[order[j]]) })
+
+	match := make([]int, len(l.weight))
+	for i := range match {
+		match[i] = -1
+	}
+	var weights []int
+	for _, u := range order {
+		if match[u] >= 0 {
+			continue
+		}
+		best := -1
+		var bestWeight float64
+		for _, e := range l.adj[u] {
+			if match[e.to] >= 0 || l.weight[u]+l.weight[e.to] > limit {
+				continue
+			}
+			if best < 0 || e.weight > bestWeight {
+				best = e.to
+				bestWeight = e.weight
+			}
+		}
+		match[u] = len(weights)
+		w := l.weight[u]
+		if best >= 0 {
+			match[best] = match[u]
+			w += l.weight[best]
+		}
+		weights = append(weights, w)
+	}
+
+	adj := make([]map[int]float64, len(weights))
+	for i := range adj {
+		adj[i] = make(map[int]float64)
+	}
+	for u, edges := range l.adj {
+		cu := match[u]
+		for _, e := range edges {
+			if cv := match[e.to]; cv != cu {
+				adj[cu][cv] += e.weight
+			}
+		}
+	}
+	c := &partLevel{weight: weights, adj: make([][]partEdge, len(weights))}
+	for i := range adj {
+		c.adj[i] = partEdges(adj[i])
+	}
+	return c, match
+}
+
+// grow returns an initial k-way partition of l found by greedily growing
+// each part from a seed node, adding the node most strongly connected to
+// the part until it holds its share of the total node weight.
+func (l *partLevel) grow(k int) []int {
+	var total int
+	for _, w := range l.weight {
+		total += w
+	}
+	part := make([]int, len(l.weight))
+	for i := range part {
+		part[i] = -1
+	}
+	conn := make([]float64, len(l.weight))
+	var assigned int
+	for p := 0; p < k-1; p++ {
+		target := float64(total-assigned) / float64(k-p)
+		for i := range conn {
+			conn[i] = 0
+		}
+		var size int
+		for float64(size) < target {
+			best := -1
+			for v, c := range conn {
+				if part[v] < 0 && (best < 0 || c > conn[best]) {
+					best = v
+				}
+			}
+			if best < 0 {
+				break
+			}
+			part[best] = p
+			size += l.weight[best]
+			for _, e := range l.adj[best] {
+				conn[e.to] += e.weight
+			}
+		}
+		assigned += size
+	}
+	for v, p := range part {
+		if p < 0 {
+			part[v] = k - 1
+		}
+	}
+	return part
+}
+
+// refine improves the partition of l in place by moving nodes to the part
+// they are most strongly connected to when this reduces the cut without
+// making the destination part heavier than maxWeight, and then by moving
+// nodes out of parts that are heavier than maxWeight.
+func (l *partLevel) refine(part []int, k, maxWeight int) {
+	size := make([]int, k)
+	for v, p := range part {
+		size[p] += l.weight[v]
+	}
+	conn := make([]float64, k)
+	connections := func(v int) {
+		for i := range conn {
+			conn[i] = 0
+		}
+		for _, e := range l.adj[v] {
+			conn[part[e.to]] += e.weight
+		}
+	}
+	move := func(v, p int) {
+		size[part[v]] -= l.weight[v]
+		size[p] += l.weight[v]
+		part[v] = p
+	}
+
+	for pass := 0; pass < partitionPasses; pass++ {
+		var moved bool
+		for v := range part {
+			from := part[v]
+			connections(v)
+			best := from
+			var bestGain float64
+			for p := 0; p < k; p++ {
+				if p == from || size[p]+l.weight[v] > maxWeight {
+					continue
+				}
+				gain := conn[p] - conn[from]
+				if gain > bestGain || (gain == bestGain && best != from && size[p] < size[best]) {
+					best = p
+					bestGain = gain
+				}
+			}
+			if best != from && bestGain > 0 {
+				move(v, best)
+				moved = true
+			}
+		}
+		if !moved {
+			break
+		}
+	}
+
+	// Rebalance overweight parts, moving the
+	// node that least increases the cut.
+	for {
+		heavy := 0
+		for p := range size {
+			if size[p] > size[heavy] {
+				heavy = p
+			}
+		}
+		if size[heavy] <= maxWeight {
+			return
+		}
+		bestNode, bestPart := -1, -1
+		bestGain := math.Inf(-1)
+		for v, p := range part {
+			if p != heavy {
+				continue
+			}
+			connections(v)
+			for q := 0; q < k; q++ {
+				if q == heavy || size[q]+l.weight[v] > maxWeight {
+					continue
+				}
+				if gain := conn[q] - conn[heavy]; gain > bestGain {
+					bestNode, bestPart = v, q
+					bestGain = gain
+				}
+			}
+		}
+		if bestNode < 0 {
+			// No move is possible at this
+			// level; leave it to a finer one.
+			return
+		}
+		move(bestNode, bestPart)
+	}
+}
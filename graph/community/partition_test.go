@@ -0,0 +1,164 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package community
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// plantedPartition returns an undirected graph of clusters of the given size
+// with intra-cluster edge probability p and the given number of random edges
+// between clusters.
+func plantedPartition(clusters, size int, p float64, between int, src rand.Source) graph.Undirected {
+	rnd := rand.New(src)
+	g := simple.NewUndirectedGraph()
+	n := clusters * size
+	for i := 0; i < n; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	for c := 0; c < clusters; c++ {
+		for i := c * size; i < (c+1)*size; i++ {
+			// Link each node to the next to
+			// keep clusters connected.
+			if i+1 < (c+1)*size {
+				g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(i + 1)})
+			}
+			for j := i + 2; j < (c+1)*size; j++ {
+				if rnd.Float64() < p {
+					g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(j)})
+				}
+			}
+		}
+	}
+	for added := 0; added < between; {
+		u, v := rnd.Intn(n), rnd.Intn(n)
+		if u/size == v/size || g.HasEdgeBetween(int64(u), int64(v)) {
+			continue
+		}
+		g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		added++
+	}
+	return g
+}
+
+func cutSize(g graph.Undirected, partition map[int64]int) int {
+	var cut int
+	nodes := g.Nodes()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if uid < vid && partition[uid] != partition[vid] {
+				cut++
+			}
+		}
+	}
+	return cut
+}
+
+func TestBalancedPartition(t *testing.T) {
+	for _, test := range []struct {
+		clusters, size int
+		between        int
+		k              int
+	}{
+		{clusters: 2, size: 20, between: 4, k: 2},
+		{clusters: 4, size: 25, between: 8, k: 4},
+		{clusters: 8, size: 30, between: 20, k: 8},
+		{clusters: 4, size: 30, between: 8, k: 2},
+	} {
+		for seed := uint64(1); seed <= 5; seed++ {
+			g := plantedPartition(test.clusters, test.size, 0.4, test.between, rand.NewSource(seed))
+			partition := BalancedPartition(g, test.k, nil)
+
+			n := g.Nodes().Len()
+			if len(partition) != n {
+				t.Errorf("unexpected number of labelled nodes for k=%d seed=%d: got:%d want:%d",
+					test.k, seed, len(partition), n)
+			}
+			sizes := make([]int, test.k)
+			for id, p := range partition {
+				if p < 0 || p >= test.k {
+					t.Fatalf("invalid part label for node %d: %d", id, p)
+				}
+				sizes[p]++
+			}
+			maxSize := int(math.Ceil(float64(n) / float64(test.k) * (1 + partitionImbalance)))
+			for p, s := range sizes {
+				if s > maxSize {
+					t.Errorf("part %d too large for k=%d seed=%d: got:%d max:%d", p, test.k, seed, s, maxSize)
+				}
+			}
+			if cut := cutSize(g, partition); cut > test.between {
+				t.Errorf("unexpectedly large cut for k=%d seed=%d: got:%d want:<=%d", test.k, seed, cut, test.between)
+			}
+		}
+	}
+}
+
+func TestBalancedPartitionCut(t *testing.T) {
+	// The cuts found for sparser planted partitions with
+	// more edges between clusters, where refinement has
+	// more to do. A change to the refinement must not
+	// make any of these cuts larger.
+	for _, test := range []struct {
+		clusters, size int
+		p              float64
+		between        int
+		k              int
+		want           []int
+	}{
+		{clusters: 8, size: 30, p: 0.15, between: 20, k: 8, want: []int{20, 20, 20, 20, 20}},
+		{clusters: 4, size: 30, p: 0.4, between: 8, k: 2, want: []int{6, 5, 3, 3, 4}},
+		{clusters: 4, size: 40, p: 0.15, between: 60, k: 4, want: []int{60, 60, 60, 62, 60}},
+		{clusters: 8, size: 25, p: 0.15, between: 80, k: 8, want: []int{80, 80, 80, 80, 132}},
+		{clusters: 6, size: 30, p: 0.4, between: 60, k: 3, want: []int{38, 48, 47, 45, 46}},
+	} {
+		for i, want := range test.want {
+			seed := uint64(i + 1)
+			g := plantedPartition(test.clusters, test.size, test.p, test.between, rand.NewSource(seed))
+			partition := BalancedPartition(g, test.k, nil)
+			if cut := cutSize(g, partition); cut > want {
+				t.Errorf("cut regressed for clusters=%d k=%d seed=%d: got:%d want:<=%d",
+					test.clusters, test.k, seed, cut, want)
+			}
+			again := BalancedPartition(g, test.k, nil)
+			if !reflect.DeepEqual(again, partition) {
+				t.Errorf("partition not deterministic for clusters=%d k=%d seed=%d", test.clusters, test.k, seed)
+			}
+		}
+	}
+}
+
+func TestBalancedPartitionTrivial(t *testing.T) {
+	if got := BalancedPartition(simple.NewUndirectedGraph(), 3, nil); len(got) != 0 {
+		t.Errorf("unexpected partition of empty graph: %v", got)
+	}
+
+	g := plantedPartition(2, 5, 0.5, 1, rand.NewSource(1))
+	for id, p := range BalancedPartition(g, 1, nil) {
+		if p != 0 {
+			t.Errorf("unexpected part for node %d with k=1: got:%d want:0", id, p)
+		}
+	}
+
+	// Directed edges in both directions between a pair of nodes are combined.
+	d := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 0}, {2, 3}, {3, 2}, {1, 2}} {
+		d.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	partition := BalancedPartition(d, 2, nil)
+	if partition[0] != partition[1] || partition[2] != partition[3] || partition[0] == partition[2] {
+		t.Errorf("unexpected partition of directed graph: %v", partition)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package graphml implements reading and writing of weighted graphs in the
+// GraphML format.
+//
+// See http://graphml.graphdrawing.org/ for details of the format.
+package graphml // import "gonum.org/v1/gonum/graph/encoding/graphml"
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+const (
+	namespace = "http://graphml.graphdrawing.org/xmlns"
+	weightKey = "weight"
+)
+
+// errSelfLoop is returned when writing or reading a graph with a self loop.
+var errSelfLoop = errors.New("graphml: self loops not supported")
+
+// WriteGraphML writes the nodes and edges of g to w as a GraphML document. Node
+// IDs are written as decimal integers and the weight of each edge, as returned by
+// weight, is written as a double-valued edge attribute named "weight". If g is
+// undirected, the graph is written with undirected edges, each edge being written
+// once. Self loops are not supported, as they are not by ReadGraphML, and are
+// reported as errors.
+//
+// If weight is nil, the Weight method of g is used if g implements graph.Weighted,
+// otherwise no edge weights are written.
+func WriteGraphML(w io.Writer, g graph.Graph, weight func(xid, yid int64) (w float64, ok bool)) error {
+	if weight == nil {
+		if wg, ok := g.(graph.Weighted); ok {
+			weight = wg.Weight
+		}
+	}
+
+	doc := document{Xmlns: namespace, Graph: graphElement{ID: "G", EdgeDefault: "directed"}}
+	if weight != nil {
+		doc.Keys = []key{{ID: weightKey, For: "edge", Name: "weight", Type: "double"}}
+	}
+	_, undirected := g.(graph.Undirected)
+	if undirected {
+		doc.Graph.EdgeDefault = "undirected"
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	for _, u := range nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node{ID: strconv.FormatInt(u.ID(), 10)})
+	}
+	for _, u := range nodes {
+		uid := u.ID()
+		to := graph.NodesOf(g.From(uid))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			vid := v.ID()
+			if vid == uid {
+				return errSelfLoop
+			}
+			if undirected && vid < uid {
+				continue
+			}
+			e := edge{Source: strconv.FormatInt(uid, 10), Target: strconv.FormatInt(vid, 10)}
+			if weight != nil {
+				w, ok := weight(uid, vid)
+				if !ok {
+					return fmt.Errorf("graphml: no weight for edge from %d to %d", uid, vid)
+				}
+				e.Data = []data{{Key: weightKey, Value: strconv.FormatFloat(w, 'g', -1, 64)}}
+			}
+			doc.Graph.Edges = append(doc.Graph.Edges, e)
+		}
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+	err = enc.Encode(doc)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// ReadGraphML reads a GraphML document from r and returns the graph it describes.
+// The first graph in the document is read. Node IDs that are decimal integers are
+// used as the IDs of the returned graph's nodes, other nodes are given unused IDs.
+// Edge weights are read from the edge attribute named "weight", using the default
+// value of the attribute for edges without a weight, or 1 if there is no default.
+// Undirected edges are added to the returned graph in both directions. Self loops
+// and parallel edges are not supported and are reported as errors.
+func ReadGraphML(r io.Reader) (graph.Directed, error) {
+	var doc document
+	err := xml.NewDecoder(r).Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	weightID := ""
+	defaultWeight := 1.0
+	for _, k := range doc.Keys {
+		if k.Name != "weight" || (k.For != "edge" && k.For != "all") {
+			continue
+		}
+		weightID = k.ID
+		if k.Default != nil {
+			defaultWeight, err = strconv.ParseFloat(*k.Default, 64)
+			if err != nil {
+				return nil, fmt.Errorf("graphml: invalid default weight: %v", err)
+			}
+		}
+		break
+	}
+
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	nodes := make(map[string]graph.Node, len(doc.Graph.Nodes))
+	// Add nodes with integer IDs before allocating
+	// IDs for the remaining nodes, so that allocated
+	// IDs do not collide with encoded IDs.
+	for _, n := range doc.Graph.Nodes {
+		id, err := strconv.ParseInt(n.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		if _, exists := nodes[n.ID]; exists || g.Node(id) != nil {
+			return nil, fmt.Errorf("graphml: duplicate node %q", n.ID)
+		}
+		nodes[n.ID] = simple.Node(id)
+		g.AddNode(simple.Node(id))
+	}
+	for _, n := range doc.Graph.Nodes {
+		if _, exists := nodes[n.ID]; exists {
+			if _, err := strconv.ParseInt(n.ID, 10, 64); err != nil {
+				return nil, fmt.Errorf("graphml: duplicate node %q", n.ID)
+			}
+			continue
+		}
+		u := g.NewNode()
+		nodes[n.ID] = u
+		g.AddNode(u)
+	}
+
+	for _, e := range doc.Graph.Edges {
+		u, ok := nodes[e.Source]
+		if !ok {
+			return nil, fmt.Errorf("graphml: unknown source node %q", e.Source)
+		}
+		v, ok := nodes[e.Target]
+		if !ok {
+			return nil, fmt.Errorf("graphml: unknown target node %q", e.Target)
+		}
+		if u.ID() == v.ID() {
+			return nil, errSelfLoop
+		}
+		if g.HasEdgeFromTo(u.ID(), v.ID()) {
+			return nil, fmt.Errorf("graphml: parallel edge from %q to %q", e.Source, e.Target)
+		}
+		w := defaultWeight
+		for _, d := range e.Data {
+			if d.Key != weightID || weightID == "" {
+				continue
+			}
+			w, err = strconv.ParseFloat(d.Value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("graphml: invalid weight for edge from %q to %q: %v", e.Source, e.Target, err)
+			}
+		}
+		directed := doc.Graph.EdgeDefault != "undirected"
+		if e.Directed != "" {
+			directed, err = strconv.ParseBool(e.Directed)
+			if err != nil {
+				return nil, fmt.Errorf("graphml: invalid directed attribute: %v", err)
+			}
+		}
+		g.SetWeightedEdge(simple.WeightedEdge{F: u, T: v, W: w})
+		if !directed {
+			g.SetWeightedEdge(simple.WeightedEdge{F: v, T: u, W: w})
+		}
+	}
+	return g, nil
+}
+
+// document is the root element of a GraphML document.
+type document struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr,omitempty"`
+	Keys    []key        `xml:"key"`
+	Graph   graphElement `xml:"graph"`
+}
+
+type key struct {
+	ID      string  `xml:"id,attr"`
+	For     string  `xml:"for,attr"`
+	Name    string  `xml:"attr.name,attr"`
+	Type    string  `xml:"attr.type,attr"`
+	Default *string `xml:"default"`
+}
+
+type graphElement struct {
+	ID          string `xml:"id,attr,omitempty"`
+	EdgeDefault string `xml:"edgedefault,attr"`
+	Nodes       []node `xml:"node"`
+	Edges       []edge `xml:"edge"`
+}
+
+type node struct {
+	ID string `xml:"id,attr"`
+}
+
+type edge struct {
+	Source   string `xml:"source,attr"`
+	Target   string `xml:"target,attr"`
+	Directed string `xml:"directed,attr,omitempty"`
+	Data     []data `xml:"data"`
+}
+
+type data struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
@@ -0,0 +1,187 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graphml
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/multi"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestRoundTrip(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1.5},
+		{F: simple.Node(1), T: simple.Node(2), W: 0.1},
+		{F: simple.Node(2), T: simple.Node(0), W: 3},
+		{F: simple.Node(1), T: simple.Node(0), W: -2},
+		{F: simple.Node(5), T: simple.Node(2), W: 1e-9},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	g.AddNode(simple.Node(7))
+
+	var buf bytes.Buffer
+	err := WriteGraphML(&buf, g, nil)
+	if err != nil {
+		t.Fatalf("unexpected error writing GraphML: %v", err)
+	}
+	got, err := ReadGraphML(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error reading GraphML: %v\n%s", err, buf.Bytes())
+	}
+	checkSameGraph(t, got, g)
+}
+
+func TestRoundTripUndirected(t *testing.T) {
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 2},
+		{F: simple.Node(1), T: simple.Node(2), W: 4},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	var buf bytes.Buffer
+	err := WriteGraphML(&buf, g, nil)
+	if err != nil {
+		t.Fatalf("unexpected error writing GraphML: %v", err)
+	}
+	if n := strings.Count(buf.String(), "<edge "); n != 2 {
+		t.Errorf("unexpected number of edge elements: got:%d want:2", n)
+	}
+	got, err := ReadGraphML(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error reading GraphML: %v", err)
+	}
+	want := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range graph.WeightedEdgesOf(g.WeightedEdges()) {
+		want.SetWeightedEdge(e)
+		want.SetWeightedEdge(simple.WeightedEdge{F: e.To(), T: e.From(), W: e.Weight()})
+	}
+	checkSameGraph(t, got, want)
+}
+
+func TestRoundTripSelfLoop(t *testing.T) {
+	g := multi.NewDirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 1}} {
+		g.SetLine(g.NewLine(multi.Node(e[0]), multi.Node(e[1])))
+	}
+
+	var buf bytes.Buffer
+	err := WriteGraphML(&buf, g, nil)
+	if err != errSelfLoop {
+		t.Errorf("unexpected error writing graph with self loop: got:%v want:%v", err, errSelfLoop)
+	}
+
+	// The self loop written by hand is rejected
+	// in the same way when read.
+	const doc = `<graphml><graph><node id="0"/><node id="1"/><edge source="0" target="1"/><edge source="1" target="1"/></graph></graphml>`
+	_, err = ReadGraphML(strings.NewReader(doc))
+	if err != errSelfLoop {
+		t.Errorf("unexpected error reading graph with self loop: got:%v want:%v", err, errSelfLoop)
+	}
+}
+
+func TestReadGraphML(t *testing.T) {
+	const doc = `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d0" for="edge" attr.name="weight" attr.type="double">
+    <default>2.5</default>
+  </key>
+  <graph id="G" edgedefault="directed">
+    <node id="a"/>
+    <node id="1"/>
+    <node id="b"/>
+    <edge source="a" target="1"><data key="d0">4</data></edge>
+    <edge source="1" target="b"/>
+    <edge source="b" target="a" directed="false"/>
+  </graph>
+</graphml>
+`
+	g, err := ReadGraphML(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("unexpected error reading GraphML: %v", err)
+	}
+	if n := g.Nodes().Len(); n != 3 {
+		t.Fatalf("unexpected number of nodes: got:%d want:3", n)
+	}
+	if g.Node(1) == nil {
+		t.Fatal("missing node with integer ID")
+	}
+	wg := g.(graph.Weighted)
+	var a, b int64 = -1, -1
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		switch {
+		case n.ID() == 1:
+		case g.HasEdgeFromTo(n.ID(), 1):
+			a = n.ID()
+		default:
+			b = n.ID()
+		}
+	}
+	for _, test := range []struct {
+		uid, vid int64
+		want     float64
+	}{
+		{uid: a, vid: 1, want: 4},
+		{uid: 1, vid: b, want: 2.5},
+		{uid: b, vid: a, want: 2.5},
+		{uid: a, vid: b, want: 2.5},
+	} {
+		w, ok := wg.Weight(test.uid, test.vid)
+		if !ok || w != test.want {
+			t.Errorf("unexpected weight for edge %d->%d: got:%v,%t want:%v", test.uid, test.vid, w, ok, test.want)
+		}
+	}
+
+	for _, bad := range []string{
+		`<graphml><graph><node id="0"/><edge source="0" target="1"/></graph></graphml>`,
+		`<graphml><graph><node id="0"/><node id="0"/></graph></graphml>`,
+		`<graphml><graph><node id="0"/><edge source="0" target="0"/></graph></graphml>`,
+	} {
+		_, err := ReadGraphML(strings.NewReader(bad))
+		if err == nil {
+			t.Errorf("expected error reading %q", bad)
+		}
+	}
+}
+
+func checkSameGraph(t *testing.T, got graph.Directed, want *simple.WeightedDirectedGraph) {
+	t.Helper()
+	if got.Nodes().Len() != want.Nodes().Len() {
+		t.Errorf("unexpected number of nodes: got:%d want:%d", got.Nodes().Len(), want.Nodes().Len())
+	}
+	for _, n := range graph.NodesOf(want.Nodes()) {
+		if got.Node(n.ID()) == nil {
+			t.Errorf("missing node %d", n.ID())
+		}
+	}
+	wg, ok := got.(graph.Weighted)
+	if !ok {
+		t.Fatal("read graph is not weighted")
+	}
+	var edges int
+	for _, e := range graph.WeightedEdgesOf(want.WeightedEdges()) {
+		edges++
+		w, ok := wg.Weight(e.From().ID(), e.To().ID())
+		if !ok || w != e.Weight() {
+			t.Errorf("unexpected weight for edge %d->%d: got:%v,%t want:%v",
+				e.From().ID(), e.To().ID(), w, ok, e.Weight())
+		}
+	}
+	var n int
+	for _, u := range graph.NodesOf(got.Nodes()) {
+		n += got.From(u.ID()).Len()
+	}
+	if n != edges {
+		t.Errorf("unexpected number of edges: got:%d want:%d", n, edges)
+	}
+}
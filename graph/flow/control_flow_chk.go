@@ -0,0 +1,90 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import "gonum.org/v1/gonum/graph"
+
+// DominatorsCHK returns a dominator tree for all nodes in the flow graph
+// g starting from the given root node using the iterative algorithm of
+// Cooper, Harvey and Kennedy. The CHK algorithm is simple and performs
+// well on the sparse, shallow graphs typical of program control flow.
+func DominatorsCHK(root graph.Node, g graph.Directed) DominatorTree {
+	// The algorithm used here is described in
+	// "A Simple, Fast Dominance Algorithm" by
+	// Cooper, Harvey and Kennedy.
+	// https://www.cs.rice.edu/~keith/EMBED/dom.pdf
+
+	// Number the reachable nodes in postorder.
+	var post []graph.Node
+	postorder := make(map[int64]int)
+	seen := make(map[int64]bool)
+	var dfs func(v graph.Node)
+	dfs = func(v graph.Node) {
+		seen[v.ID()] = true
+		for _, w := range graph.NodesOf(g.From(v.ID())) {
+			if !seen[w.ID()] {
+				dfs(w)
+			}
+		}
+		postorder[v.ID()] = len(post)
+		post = append(post, v)
+	}
+	dfs(root)
+
+	// idom holds the postorder number of the immediate
+	// dominator of each node, or -1 if not yet known.
+	n := len(post)
+	idom := make([]int, n)
+	for i := range idom {
+		idom[i] = -1
+	}
+	idom[n-1] = n - 1
+
+	intersect := func(b1, b2 int) int {
+		for b1 != b2 {
+			for b1 < b2 {
+				b1 = idom[b1]
+			}
+			for b2 < b1 {
+				b2 = idom[b2]
+			}
+		}
+		return b1
+	}
+
+	for changed := true; changed; {
+		changed = false
+		// Visit nodes in reverse postorder, skipping the root.
+		for b := n - 2; b >= 0; b-- {
+			newIdom := -1
+			for _, p := range graph.NodesOf(g.To(post[b].ID())) {
+				pi, ok := postorder[p.ID()]
+				if !ok || idom[pi] < 0 {
+					continue
+				}
+				if newIdom < 0 {
+					newIdom = pi
+				} else {
+					newIdom = intersect(pi, newIdom)
+				}
+			}
+			if idom[b] != newIdom {
+				idom[b] = newIdom
+				changed = true
+			}
+		}
+	}
+
+	// Construct the public-facing dominator tree structure.
+	dominatorOf := make(map[int64]graph.Node)
+	dominatedBy := make(map[int64][]graph.Node)
+	for b, w := range post[:n-1] {
+		dom := post[idom[b]]
+		dominatorOf[w.ID()] = dom
+		did := dom.ID()
+		dominatedBy[did] = append(dominatedBy[did], w)
+	}
+	return DominatorTree{root: root, dominatorOf: dominatorOf, dominatedBy: dominatedBy}
+}
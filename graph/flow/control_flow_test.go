@@ -124,6 +124,35 @@ var dominatorsTests = []struct {
 			},
 		},
 	},
+	{ // A loop with a branch and an exit from its latch, with a node unreachable from the entry.
+		n: simple.Node(1),
+		edges: []simple.Edge{
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(3)},
+			{F: simple.Node(2), T: simple.Node(4)},
+			{F: simple.Node(3), T: simple.Node(5)},
+			{F: simple.Node(4), T: simple.Node(5)},
+			{F: simple.Node(5), T: simple.Node(2)},
+			{F: simple.Node(5), T: simple.Node(6)},
+			{F: simple.Node(7), T: simple.Node(4)},
+		},
+
+		want: DominatorTree{
+			root: simple.Node(1),
+			dominatorOf: map[int64]graph.Node{
+				2: simple.Node(1),
+				3: simple.Node(2),
+				4: simple.Node(2),
+				5: simple.Node(2),
+				6: simple.Node(5),
+			},
+			dominatedBy: map[int64][]graph.Node{
+				1: {simple.Node(2)},
+				2: {simple.Node(3), simple.Node(4), simple.Node(5)},
+				5: {simple.Node(6)},
+			},
+		},
+	},
 }
 
 type char int64
@@ -144,6 +173,7 @@ func TestDominators(t *testing.T) {
 		}{
 			{"Dominators", Dominators},
 			{"DominatorsSLT", DominatorsSLT},
+			{"DominatorsCHK", DominatorsCHK},
 		} {
 			got := alg.fn(test.n, g)
 
@@ -0,0 +1,74 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gen
+
+import (
+	"math"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// Anonymize returns a copy of g with its nodes relabelled by a random permutation
+// of the IDs in [0, n) where n is the number of nodes in g, and the mapping
+// from the original node IDs to the new node IDs. The returned graph has the same
+// structure as g. If g is a graph.Weighted, the returned graph is a
+// *simple.WeightedDirectedGraph holding the weights of g's edges, otherwise it is
+// a *simple.DirectedGraph.
+//
+// If src is nil, the global random number generator from golang.org/x/exp/rand
+// is used.
+func Anonymize(g graph.Directed, src rand.Source) (graph.Directed, map[int64]int64) {
+	var perm func(int) []int
+	if src == nil {
+		perm = rand.Perm
+	} else {
+		perm = rand.New(src).Perm
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	mapping := make(map[int64]int64, len(nodes))
+	for i, id := range perm(len(nodes)) {
+		mapping[nodes[i].ID()] = int64(id)
+	}
+
+	wg, weighted := g.(graph.Weighted)
+	var dst graph.Directed
+	var setEdge func(u, v graph.Node, e graph.Edge)
+	if weighted {
+		d := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		setEdge = func(u, v graph.Node, e graph.Edge) {
+			d.SetWeightedEdge(d.NewWeightedEdge(u, v, wg.WeightedEdge(e.From().ID(), e.To().ID()).Weight()))
+		}
+		for _, n := range nodes {
+			d.AddNode(simple.Node(mapping[n.ID()]))
+		}
+		dst = d
+	} else {
+		d := simple.NewDirectedGraph()
+		setEdge = func(u, v graph.Node, _ graph.Edge) {
+			d.SetEdge(d.NewEdge(u, v))
+		}
+		for _, n := range nodes {
+			d.AddNode(simple.Node(mapping[n.ID()]))
+		}
+		dst = d
+	}
+
+	for _, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			setEdge(simple.Node(mapping[uid]), simple.Node(mapping[vid]), g.Edge(uid, vid))
+		}
+	}
+	return dst, mapping
+}
@@ -0,0 +1,106 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gen
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestAnonymize(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	// Use sparse original IDs so that the
+	// new IDs must differ from the old.
+	for i := 0; i < 50; i++ {
+		g.AddNode(simple.Node(1000 + 3*i))
+	}
+	for i := 0; i < 200; i++ {
+		u, v := 1000+3*rnd.Intn(50), 1000+3*rnd.Intn(50)
+		if u == v {
+			continue
+		}
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(10))})
+	}
+
+	a, mapping := Anonymize(g, rand.NewSource(1))
+	if _, ok := a.(graph.Weighted); !ok {
+		t.Fatalf("anonymized copy of weighted graph is not weighted: %T", a)
+	}
+	checkAnonymized(t, g, a, mapping)
+
+	u := simple.NewDirectedGraph()
+	for i := 0; i < 20; i++ {
+		u.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node((i*7 + 3) % 20)})
+	}
+	a, mapping = Anonymize(u, rand.NewSource(2))
+	if _, ok := a.(graph.Weighted); ok {
+		t.Errorf("anonymized copy of unweighted graph is weighted: %T", a)
+	}
+	checkAnonymized(t, u, a, mapping)
+	var moved int
+	for old, id := range mapping {
+		if old != id {
+			moved++
+		}
+	}
+	if moved == 0 {
+		t.Error("no node IDs were changed")
+	}
+}
+
+func checkAnonymized(t *testing.T, g, a graph.Directed, mapping map[int64]int64) {
+	t.Helper()
+	n := g.Nodes().Len()
+	if a.Nodes().Len() != n || len(mapping) != n {
+		t.Fatalf("unexpected number of nodes: got:%d mapping:%d want:%d", a.Nodes().Len(), len(mapping), n)
+	}
+	used := make(map[int64]bool)
+	for old, id := range mapping {
+		if g.Node(old) == nil || a.Node(id) == nil {
+			t.Errorf("invalid mapping %d->%d", old, id)
+		}
+		if used[id] {
+			t.Errorf("new ID %d used more than once", id)
+		}
+		used[id] = true
+	}
+
+	wg, weighted := g.(graph.Weighted)
+	var edges int
+	nodes := g.Nodes()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			edges++
+			if !a.HasEdgeFromTo(mapping[uid], mapping[vid]) {
+				t.Errorf("missing edge %d->%d mapped to %d->%d", uid, vid, mapping[uid], mapping[vid])
+				continue
+			}
+			if weighted {
+				want, _ := wg.Weight(uid, vid)
+				got, _ := a.(graph.Weighted).Weight(mapping[uid], mapping[vid])
+				if got != want {
+					t.Errorf("unexpected weight for edge %d->%d: got:%v want:%v", uid, vid, got, want)
+				}
+			}
+		}
+	}
+	var got int
+	nodes = a.Nodes()
+	for nodes.Next() {
+		got += a.From(nodes.Node().ID()).Len()
+	}
+	if got != edges {
+		t.Errorf("unexpected number of edges: got:%d want:%d", got, edges)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gen
+
+import (
+	"math"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// SampleSubgraph returns the subgraph of g induced by a random sample of its nodes.
+// Each node of g is kept independently with probability nodeFraction, and all the
+// edges of g between kept nodes are retained. The nodes of the returned graph have
+// the same IDs as in g. Undirected edges are retained in both directions and self
+// edges are not retained. If g is a graph.Weighted, the returned graph is a
+// *simple.WeightedDirectedGraph holding the weights of g's edges, otherwise it is
+// a *simple.DirectedGraph.
+//
+// If src is nil, the global random number generator from golang.org/x/exp/rand
+// is used. SampleSubgraph will panic if nodeFraction is not in [0, 1].
+func SampleSubgraph(g graph.Graph, nodeFraction float64, src rand.Source) graph.Directed {
+	if nodeFraction < 0 || 1 < nodeFraction {
+		panic("gen: invalid node fraction")
+	}
+	var rnd func() float64
+	if src == nil {
+		rnd = rand.Float64
+	} else {
+		rnd = rand.New(src).Float64
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	var kept []graph.Node
+	for _, n := range nodes {
+		if rnd() < nodeFraction {
+			kept = append(kept, n)
+		}
+	}
+
+	wg, weighted := g.(graph.Weighted)
+	var (
+		dst     graph.Directed
+		addNode func(graph.Node)
+		setEdge func(u, v graph.Node)
+	)
+	if weighted {
+		d := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		addNode = d.AddNode
+		setEdge = func(u, v graph.Node) {
+			d.SetWeightedEdge(d.NewWeightedEdge(u, v, wg.WeightedEdge(u.ID(), v.ID()).Weight()))
+		}
+		dst = d
+	} else {
+		d := simple.NewDirectedGraph()
+		addNode = d.AddNode
+		setEdge = func(u, v graph.Node) {
+			d.SetEdge(d.NewEdge(u, v))
+		}
+		dst = d
+	}
+
+	for _, n := range kept {
+		addNode(n)
+	}
+	for _, u := range kept {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			if v.ID() == uid || dst.Node(v.ID()) == nil {
+				continue
+			}
+			setEdge(u, v)
+		}
+	}
+	return dst
+}
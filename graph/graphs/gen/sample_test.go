@@ -0,0 +1,88 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gen
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestSampleSubgraph(t *testing.T) {
+	const n = 2000
+	rnd := rand.New(rand.NewSource(1))
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for i := 0; i < n; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	for i := 0; i < 5*n; i++ {
+		u, v := rnd.Intn(n), rnd.Intn(n)
+		if u == v {
+			continue
+		}
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(10))})
+	}
+
+	for _, frac := range []float64{0, 0.1, 0.5, 1} {
+		s := SampleSubgraph(g, frac, rand.NewSource(2))
+		got := s.Nodes().Len()
+		// Allow four standard deviations of the
+		// binomial distribution of node counts.
+		want := frac * n
+		tol := 4 * math.Sqrt(n*frac*(1-frac))
+		if math.Abs(float64(got)-want) > tol {
+			t.Errorf("unexpected number of sampled nodes for fraction %v: got:%d want:%v±%v", frac, got, want, tol)
+		}
+
+		ws, ok := s.(graph.Weighted)
+		if !ok {
+			t.Fatalf("sample of weighted graph is not weighted: %T", s)
+		}
+		for _, u := range graph.NodesOf(s.Nodes()) {
+			if g.Node(u.ID()) == nil {
+				t.Fatalf("sampled node %d not in original graph", u.ID())
+			}
+			for _, v := range graph.NodesOf(g.From(u.ID())) {
+				kept := s.Node(v.ID()) != nil
+				if retained := s.HasEdgeFromTo(u.ID(), v.ID()); retained != kept {
+					t.Errorf("edge %d-%d retained:%t but node %d kept:%t", u.ID(), v.ID(), retained, v.ID(), kept)
+				}
+				if !kept {
+					continue
+				}
+				got, _ := ws.Weight(u.ID(), v.ID())
+				want, _ := g.Weight(u.ID(), v.ID())
+				if got != want {
+					t.Errorf("unexpected weight for edge %d-%d: got:%v want:%v", u.ID(), v.ID(), got, want)
+				}
+			}
+		}
+	}
+
+	a := SampleSubgraph(g, 0.3, rand.NewSource(3))
+	b := SampleSubgraph(g, 0.3, rand.NewSource(3))
+	if !reflect.DeepEqual(nodeIDs(a), nodeIDs(b)) {
+		t.Error("sampling not reproducible for a fixed source")
+	}
+
+	u := simple.NewDirectedGraph()
+	u.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	if _, ok := SampleSubgraph(u, 1, nil).(*simple.DirectedGraph); !ok {
+		t.Error("sample of unweighted graph is not a *simple.DirectedGraph")
+	}
+}
+
+func nodeIDs(g graph.Graph) map[int64]bool {
+	ids := make(map[int64]bool)
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		ids[n.ID()] = true
+	}
+	return ids
+}
@@ -0,0 +1,48 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/mat"
+)
+
+// AdjacencyMatrix returns the weighted adjacency matrix of g and a mapping from
+// the node IDs of g to row and column indices. The element at row i and column j
+// holds the weight of the edge from the node with index i to the node with index
+// j, or zero if there is no such edge. If weight is nil, each edge is given a weight
+// of one. If g is a graph.Undirected, the returned matrix is symmetric, with the
+// weight of each edge taken from the lower ID node to the higher.
+func AdjacencyMatrix(g graph.Graph, weight path.Weighting) (m *mat.Dense, index map[int64]int) {
+	nodes := graph.NodesOf(g.Nodes())
+	index = make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		index[n.ID()] = i
+	}
+	_, undirected := g.(graph.Undirected)
+
+	m = mat.NewDense(len(nodes), len(nodes), nil)
+	for i, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if undirected && vid < uid {
+				continue
+			}
+			w := 1.0
+			if weight != nil {
+				w, _ = weight(uid, vid)
+			}
+			j := index[vid]
+			m.Set(i, j, w)
+			if undirected {
+				m.Set(j, i, w)
+			}
+		}
+	}
+	return m, index
+}
@@ -0,0 +1,102 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestAdjacencyMatrix(t *testing.T) {
+	edges := []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 2},
+		{F: simple.Node(1), T: simple.Node(2), W: 3},
+		{F: simple.Node(3), T: simple.Node(0), W: 0.5},
+	}
+
+	for _, test := range []struct {
+		name     string
+		directed bool
+		weighted bool
+		want     [][]float64
+	}{
+		{
+			name:     "undirected weighted",
+			weighted: true,
+			want: [][]float64{
+				{0, 2, 0, 0.5},
+				{2, 0, 3, 0},
+				{0, 3, 0, 0},
+				{0.5, 0, 0, 0},
+			},
+		},
+		{
+			name: "undirected unweighted",
+			want: [][]float64{
+				{0, 1, 0, 1},
+				{1, 0, 1, 0},
+				{0, 1, 0, 0},
+				{1, 0, 0, 0},
+			},
+		},
+		{
+			name:     "directed weighted",
+			directed: true,
+			weighted: true,
+			want: [][]float64{
+				{0, 2, 0, 0},
+				{0, 0, 3, 0},
+				{0, 0, 0, 0},
+				{0.5, 0, 0, 0},
+			},
+		},
+	} {
+		var g interface {
+			graph.Weighted
+			SetWeightedEdge(graph.WeightedEdge)
+		}
+		if test.directed {
+			g = simple.NewWeightedDirectedGraph(0, 0)
+		} else {
+			g = simple.NewWeightedUndirectedGraph(0, 0)
+		}
+		for _, e := range edges {
+			g.SetWeightedEdge(e)
+		}
+		var weight func(uid, vid int64) (float64, bool)
+		if test.weighted {
+			weight = g.Weight
+		}
+
+		m, index := AdjacencyMatrix(g, weight)
+		if r, c := m.Dims(); r != len(test.want) || c != len(test.want) {
+			t.Fatalf("%s: unexpected matrix dimensions: got:%d×%d want:%d×%d", test.name, r, c, len(test.want), len(test.want))
+		}
+		for uid, row := range test.want {
+			for vid, w := range row {
+				if got := m.At(index[int64(uid)], index[int64(vid)]); got != w {
+					t.Errorf("%s: unexpected entry for %d→%d: got:%v want:%v", test.name, uid, vid, got, w)
+				}
+			}
+		}
+		if !test.directed && !mat.Equal(m, m.T()) {
+			t.Errorf("%s: adjacency matrix of undirected graph is not symmetric:\n%v", test.name, mat.Formatted(m))
+		}
+	}
+
+	// A weight of +Inf for absent edges in the
+	// input graph does not leak into the matrix.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 4})
+	g.AddNode(simple.Node(2))
+	m, index := AdjacencyMatrix(g, g.Weight)
+	if got := m.At(index[0], index[2]); got != 0 {
+		t.Errorf("unexpected entry for absent edge: got:%v want:0", got)
+	}
+}
@@ -6,10 +6,12 @@ package network
 
 import (
 	"math"
+	"sort"
 
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/internal/linear"
 	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
 )
 
 // Betweenness returns the non-zero betweenness centrality for nodes in the unweighted graph g.
@@ -254,3 +256,83 @@ func EdgeBetweennessWeighted(g graph.Weighted, p path.AllShortest) map[[2]int64]
 
 	return cb
 }
+
+// TopBetweennessEdges returns the k edges of g with the highest edge betweenness
+// centrality, sorted in descending order of centrality. The weight of each returned
+// edge is its centrality, and its nodes are the nodes of g. Ties are broken by the
+// IDs of the edge end points. If g has fewer than k edges with non-zero centrality,
+// all of them are returned. The returned edges may be removed from g, for example
+// in a Girvan-Newman style community detection.
+//
+// If weight is nil and g does not implement graph.Weighted, the centrality is
+// calculated by EdgeBetweenness. Otherwise it is calculated by
+// EdgeBetweennessWeighted using the shortest paths of g under weight, or the
+// Weight method of g if weight is nil. If g is undirected, the returned edges
+// are oriented such that the From node has the lower ID.
+func TopBetweennessEdges(g graph.Graph, weight path.Weighting, k int) []simple.WeightedEdge {
+	if k <= 0 {
+		return nil
+	}
+	var cb map[[2]int64]float64
+	wg, isWeighted := g.(graph.Weighted)
+	switch {
+	case weight == nil && isWeighted:
+		cb = EdgeBetweennessWeighted(wg, path.DijkstraAllPaths(wg))
+	case weight == nil:
+		cb = EdgeBetweenness(g)
+	default:
+		var view graph.Weighted = weightedView{Graph: g, weight: weight}
+		if _, ok := g.(graph.Undirected); ok {
+			view = undirectedWeightedView{weightedView: weightedView{Graph: g, weight: weight}}
+		}
+		cb = EdgeBetweennessWeighted(view, path.DijkstraAllPaths(view))
+	}
+
+	edges := make([]simple.WeightedEdge, 0, len(cb))
+	for e, c := range cb {
+		if c == 0 {
+			continue
+		}
+		edges = append(edges, simple.WeightedEdge{F: g.Node(e[0]), T: g.Node(e[1]), W: c})
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.W != b.W {
+			return a.W > b.W
+		}
+		if a.F.ID() != b.F.ID() {
+			return a.F.ID() < b.F.ID()
+		}
+		return a.T.ID() < b.T.ID()
+	})
+	if len(edges) > k {
+		edges = edges[:k]
+	}
+	return edges
+}
+
+// weightedView is a graph.Weighted with edge weights given by a path.Weighting.
+type weightedView struct {
+	graph.Graph
+	weight path.Weighting
+}
+
+func (g weightedView) Weight(xid, yid int64) (w float64, ok bool) { return g.weight(xid, yid) }
+
+func (g weightedView) WeightedEdge(uid, vid int64) graph.WeightedEdge {
+	e := g.Edge(uid, vid)
+	if e == nil {
+		return nil
+	}
+	w, _ := g.weight(uid, vid)
+	return simple.WeightedEdge{F: e.From(), T: e.To(), W: w}
+}
+
+// undirectedWeightedView is an undirected weightedView.
+type undirectedWeightedView struct {
+	weightedView
+}
+
+func (g undirectedWeightedView) EdgeBetween(xid, yid int64) graph.Edge {
+	return g.Graph.(graph.Undirected).EdgeBetween(xid, yid)
+}
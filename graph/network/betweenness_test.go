@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/path"
 	"gonum.org/v1/gonum/graph/simple"
 )
@@ -336,3 +337,56 @@ func (o orderedPairFloatsMap) Less(i, j int) bool {
 	return o[i].key[0] < o[j].key[0] || (o[i].key[0] == o[j].key[0] && o[i].key[1] < o[j].key[1])
 }
 func (o orderedPairFloatsMap) Swap(i, j int) { o[i], o[j] = o[j], o[i] }
+
+func TestTopBetweennessEdges(t *testing.T) {
+	// A barbell of two 4-cliques joined by the bridge 3-4.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, c := range [][]int64{{0, 1, 2, 3}, {4, 5, 6, 7}} {
+		for i, u := range c {
+			for _, v := range c[i+1:] {
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: 1})
+			}
+		}
+	}
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(3), T: simple.Node(4), W: 1})
+	unweighted := simple.NewUndirectedGraph()
+	edges := g.Edges()
+	for edges.Next() {
+		unweighted.SetEdge(edges.Edge())
+	}
+
+	for _, test := range []struct {
+		name   string
+		g      graph.Graph
+		weight path.Weighting
+	}{
+		{name: "unweighted", g: unweighted},
+		{name: "weighted", g: g},
+		{name: "weighting", g: unweighted, weight: path.UniformCost(unweighted)},
+	} {
+		top := TopBetweennessEdges(test.g, test.weight, 3)
+		if len(top) != 3 {
+			t.Fatalf("%s: unexpected number of edges: got:%d want:3", test.name, len(top))
+		}
+		if f, to := top[0].From().ID(), top[0].To().ID(); f != 3 || to != 4 {
+			t.Errorf("%s: unexpected top edge: got:%d-%d want:3-4", test.name, f, to)
+		}
+		// Every shortest path between the 16 unordered pairs of
+		// nodes in opposite cliques crosses the bridge, and each
+		// pair is counted in both directions.
+		if top[0].W != 32 {
+			t.Errorf("%s: unexpected bridge betweenness: got:%v want:32", test.name, top[0].W)
+		}
+		for i := 1; i < len(top); i++ {
+			if top[i].W > top[i-1].W {
+				t.Errorf("%s: edges not sorted by betweenness: %v", test.name, top)
+			}
+		}
+	}
+	if all := TopBetweennessEdges(g, nil, 100); len(all) != 13 {
+		t.Errorf("unexpected number of edges for large k: got:%d want:13", len(all))
+	}
+	if none := TopBetweennessEdges(g, nil, 0); none != nil {
+		t.Errorf("unexpected edges for k=0: got:%v", none)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestCurrentFlowBetweenness(t *testing.T) {
+	// Two K4 joined through a single node, 8, which
+	// carries all current between the cliques.
+	g := simple.NewUndirectedGraph()
+	for _, c := range [][]int64{{0, 1, 2, 3}, {4, 5, 6, 7}} {
+		for i, u := range c {
+			for _, v := range c[i+1:] {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+	}
+	g.SetEdge(simple.Edge{F: simple.Node(3), T: simple.Node(8)})
+	g.SetEdge(simple.Edge{F: simple.Node(8), T: simple.Node(4)})
+	// An isolated edge in a separate component.
+	g.SetEdge(simple.Edge{F: simple.Node(9), T: simple.Node(10)})
+
+	cb := CurrentFlowBetweenness(g)
+	var best int64 = -1
+	for id, c := range cb {
+		if best < 0 || c > cb[best] {
+			best = id
+		}
+	}
+	if best != 8 {
+		t.Errorf("unexpected most central node: got:%d want:8 (%v)", best, cb)
+	}
+	if !floats.EqualWithinAbsOrRel(cb[3], cb[4], 1e-9, 1e-9) {
+		t.Errorf("expected symmetric centrality for bridge ends: %v != %v", cb[3], cb[4])
+	}
+	if cb[3] <= cb[0] {
+		t.Errorf("expected bridge end to be more central than clique member: %v <= %v", cb[3], cb[0])
+	}
+	for _, id := range []int64{9, 10} {
+		if c, ok := cb[id]; ok {
+			t.Errorf("unexpected centrality for node %d in isolated edge: %v", id, c)
+		}
+	}
+}
+
+func TestCurrentFlowBetweennessTree(t *testing.T) {
+	// On trees there is a single path between each pair of
+	// nodes, so current-flow and shortest-path betweenness agree.
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 5; trial++ {
+		g := simple.NewUndirectedGraph()
+		for i := 1; i < 20; i++ {
+			g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(rnd.Intn(i))})
+		}
+		got := CurrentFlowBetweenness(g)
+		want := Betweenness(g)
+		for id := int64(0); id < 20; id++ {
+			if !floats.EqualWithinAbsOrRel(got[id], want[id], 1e-8, 1e-8) {
+				t.Errorf("trial %d: unexpected centrality for node %d: got:%v want:%v", trial, id, got[id], want[id])
+			}
+		}
+	}
+}
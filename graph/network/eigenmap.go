@@ -0,0 +1,86 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/topo"
+	"gonum.org/v1/gonum/mat"
+)
+
+// LaplacianEigenmap returns an embedding of the nodes of the simple undirected graph
+// g into dims dimensions using Laplacian eigenmaps. The coordinates of each node are
+// given by the eigenvectors of the symmetric normalized Laplacian with the dims
+// smallest non-trivial eigenvalues, scaled by D^(-1/2) where D is the degree matrix,
+// so that strongly connected nodes are placed close together. The returned map holds
+// the coordinates of each node keyed by node ID.
+//
+// Each connected component of g is embedded separately, so coordinates are only
+// comparable between nodes in the same component. Components with dims or fewer
+// nodes have the unavailable coordinates set to zero, and isolated nodes are placed
+// at the origin. The sign of each eigenvector is chosen so that the first node of
+// each component returned by topo.ConnectedComponents has a non-negative
+// coordinate.
+//
+// The eigendecompositions are computed with mat.EigenSym, so LaplacianEigenmap takes
+// O(n³) time for a component with n nodes. LaplacianEigenmap will panic if dims is
+// less than one or if g contains self edges.
+//
+// See Belkin and Niyogi doi:10.1162/089976603321780317 for details.
+func LaplacianEigenmap(g graph.Undirected, dims int) map[int64][]float64 {
+	if dims < 1 {
+		panic("network: invalid number of dimensions")
+	}
+	l := NewSymNormLaplacian(g)
+	embedding := make(map[int64][]float64, len(l.Nodes))
+	for _, cc := range topo.ConnectedComponents(g) {
+		coords := make([][]float64, len(cc))
+		for i := range coords {
+			coords[i] = make([]float64, dims)
+			embedding[cc[i].ID()] = coords[i]
+		}
+		if len(cc) < 2 {
+			continue
+		}
+
+		idx := make([]int, len(cc))
+		for i, n := range cc {
+			idx[i] = l.Index[n.ID()]
+		}
+		sub := mat.NewSymDense(len(cc), nil)
+		for i := range idx {
+			for j := i; j < len(idx); j++ {
+				sub.SetSym(i, j, l.At(idx[i], idx[j]))
+			}
+		}
+		var eig mat.EigenSym
+		ok := eig.Factorize(sub, true)
+		if !ok {
+			panic("network: eigendecomposition failed")
+		}
+		vecs := eig.VectorsTo(nil)
+
+		scale := make([]float64, len(cc))
+		for i, n := range cc {
+			scale[i] = 1 / math.Sqrt(float64(g.From(n.ID()).Len()))
+		}
+		// Eigenvalues are returned in ascending order and the
+		// first belongs to the trivial eigenvector of the
+		// connected component.
+		for d := 0; d < dims && d+1 < len(cc); d++ {
+			col := vecs.ColView(d + 1)
+			sign := 1.0
+			if col.AtVec(0) < 0 {
+				sign = -1
+			}
+			for i := range cc {
+				coords[i][d] = sign * scale[i] * col.AtVec(i)
+			}
+		}
+	}
+	return embedding
+}
@@ -0,0 +1,80 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestLaplacianEigenmap(t *testing.T) {
+	// Two K6 joined by a single edge, and a separate
+	// component of one edge and an isolated node.
+	g := simple.NewUndirectedGraph()
+	clusters := [][]int64{{0, 1, 2, 3, 4, 5}, {6, 7, 8, 9, 10, 11}}
+	for _, c := range clusters {
+		for i, u := range c {
+			for _, v := range c[i+1:] {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+	}
+	g.SetEdge(simple.Edge{F: simple.Node(5), T: simple.Node(6)})
+	g.SetEdge(simple.Edge{F: simple.Node(12), T: simple.Node(13)})
+	g.AddNode(simple.Node(14))
+
+	for _, dims := range []int{1, 2, 3} {
+		embedding := LaplacianEigenmap(g, dims)
+		if len(embedding) != g.Nodes().Len() {
+			t.Fatalf("unexpected number of embedded nodes: got:%d want:%d", len(embedding), g.Nodes().Len())
+		}
+		for id, c := range embedding {
+			if len(c) != dims {
+				t.Fatalf("unexpected number of dimensions for node %d: got:%d want:%d", id, len(c), dims)
+			}
+		}
+
+		// The first coordinate separates the clusters.
+		side := embedding[0][0] > 0
+		for i := int64(0); i < 12; i++ {
+			if (embedding[i][0] > 0) != (side == (i < 6)) {
+				t.Errorf("dims=%d: node %d on wrong side of cluster boundary: %v", dims, i, embedding[i])
+			}
+		}
+		if dims == 1 {
+			var within, between float64
+			for i := int64(0); i < 12; i++ {
+				for j := i + 1; j < 12; j++ {
+					d := math.Abs(embedding[i][0] - embedding[j][0])
+					if (i < 6) == (j < 6) {
+						within = math.Max(within, d)
+					} else if between == 0 || d < between {
+						between = d
+					}
+				}
+			}
+			if between <= within {
+				t.Errorf("clusters not well separated: max within=%v min between=%v", within, between)
+			}
+		}
+
+		if embedding[12][0] == embedding[13][0] {
+			t.Errorf("dims=%d: nodes of separate edge component not separated: %v %v", dims, embedding[12], embedding[13])
+		}
+		for d := 1; d < dims; d++ {
+			if embedding[12][d] != 0 || embedding[13][d] != 0 {
+				t.Errorf("dims=%d: unexpected coordinates for two node component: %v %v", dims, embedding[12], embedding[13])
+			}
+		}
+		for _, x := range embedding[14] {
+			if x != 0 {
+				t.Errorf("dims=%d: isolated node not at origin: %v", dims, embedding[14])
+				break
+			}
+		}
+	}
+}
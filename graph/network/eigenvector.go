@@ -0,0 +1,81 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// EigenvectorCentrality returns the eigenvector centrality of the nodes of g,
+// the principal eigenvector of the adjacency matrix of g scaled so that the
+// highest score is one. The centrality of a node is proportional to the sum of
+// the centralities of its neighbors, so nodes connected to other central nodes
+// score highly. If g is directed, the out-adjacency is used, so the centrality
+// of a node is proportional to the sum of the centralities of the nodes it links
+// to; the centrality based on in-links may be found using a reversed view of g.
+// The returned map is keyed on the graph node IDs.
+//
+// The eigenvector is found by power iteration of A+I, where A is the adjacency
+// matrix, which has the same principal eigenvector as A but also converges for
+// bipartite graphs. EigenvectorCentrality terminates when the 2-norm of the vector
+// difference between iterations is below tol, or after maxIter iterations if
+// maxIter is positive, in which case the returned scores may not have converged.
+// EigenvectorCentrality will panic if neither tol nor maxIter is positive, since
+// the iteration would then not terminate.
+func EigenvectorCentrality(g graph.Graph, tol float64, maxIter int) map[int64]float64 {
+	if tol <= 0 && maxIter <= 0 {
+		panic("network: no termination condition")
+	}
+	nodes := graph.NodesOf(g.Nodes())
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	adj := make([][]int, len(nodes))
+	for i, n := range nodes {
+		id := n.ID()
+		for _, v := range graph.NodesOf(g.From(id)) {
+			if v.ID() == id {
+				continue
+			}
+			adj[i] = append(adj[i], indexOf[v.ID()])
+		}
+	}
+
+	x := make([]float64, len(nodes))
+	next := make([]float64, len(nodes))
+	for i := range x {
+		x[i] = 1
+	}
+	for iter := 0; maxIter <= 0 || iter < maxIter; iter++ {
+		var hi float64
+		for i := range nodes {
+			s := x[i]
+			for _, j := range adj[i] {
+				s += x[j]
+			}
+			next[i] = s
+			hi = math.Max(hi, s)
+		}
+		var norm float64
+		for i := range next {
+			next[i] /= hi
+			d := next[i] - x[i]
+			norm += d * d
+		}
+		x, next = next, x
+		if math.Sqrt(norm) < tol {
+			break
+		}
+	}
+
+	c := make(map[int64]float64, len(nodes))
+	for i, n := range nodes {
+		c[n.ID()] = x[i]
+	}
+	return c
+}
@@ -0,0 +1,167 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/mat"
+)
+
+var eigenvectorCentralityTests = []struct {
+	g        []set
+	directed bool
+
+	want map[int64]float64
+}{
+	{
+		// Star graph; the principal eigenvector
+		// of K_{1,4} is (2, 1, 1, 1, 1).
+		g: []set{
+			A: linksTo(B, C, D, E),
+			B: nil,
+			C: nil,
+			D: nil,
+			E: nil,
+		},
+		want: map[int64]float64{A: 1, B: 0.5, C: 0.5, D: 0.5, E: 0.5},
+	},
+	{
+		// Path graph; the principal eigenvector
+		// of P_3 is (1, √2, 1).
+		g: []set{
+			A: linksTo(B),
+			B: linksTo(C),
+			C: nil,
+		},
+		want: map[int64]float64{A: 1 / math.Sqrt2, B: 1, C: 1 / math.Sqrt2},
+	},
+	{
+		g: []set{
+			A: linksTo(B, C, D),
+			B: linksTo(C, D),
+			C: linksTo(D),
+			D: nil,
+		},
+		want: map[int64]float64{A: 1, B: 1, C: 1, D: 1},
+	},
+	{
+		// Node C does not link to any node,
+		// so has no out-adjacency centrality.
+		g: []set{
+			A: linksTo(B),
+			B: linksTo(A, C),
+			C: nil,
+		},
+		directed: true,
+		want:     map[int64]float64{A: 1, B: 1, C: 0},
+	},
+}
+
+func TestEigenvectorCentrality(t *testing.T) {
+	const tol = 1e-10
+	for i, test := range eigenvectorCentralityTests {
+		var g interface {
+			graph.Graph
+			graph.Builder
+		} = simple.NewUndirectedGraph()
+		if test.directed {
+			g = simple.NewDirectedGraph()
+		}
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		got := EigenvectorCentrality(g, tol, 0)
+		for n := range test.g {
+			if !floats.EqualWithinAbsOrRel(got[int64(n)], test.want[int64(n)], 1e-8, 1e-8) {
+				t.Errorf("unexpected eigenvector centrality result for test %d:\ngot: %v\nwant:%v",
+					i, orderedFloats(got, 8), orderedFloats(test.want, 8))
+				break
+			}
+		}
+	}
+}
+
+func TestEigenvectorCentralityEigenSym(t *testing.T) {
+	const n = 20
+	rnd := rand.New(rand.NewSource(1))
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < n; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	// A ring with random chords is connected and
+	// non-bipartite, so has a unique principal
+	// eigenvector.
+	for i := 0; i < n; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node((i + 1) % n)})
+	}
+	for k := 0; k < 2*n; k++ {
+		u, v := rnd.Intn(n), rnd.Intn(n)
+		if u != v {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+
+	a, index := AdjacencyMatrix(g, nil)
+	var eig mat.EigenSym
+	if ok := eig.Factorize(mat.NewSymDense(n, a.RawMatrix().Data), true); !ok {
+		t.Fatal("eigendecomposition failed")
+	}
+	vecs := eig.VectorsTo(nil)
+	want := mat.Col(nil, n-1, vecs)
+	if floats.Sum(want) < 0 {
+		floats.Scale(-1, want)
+	}
+	floats.Scale(1/floats.Max(want), want)
+
+	got := EigenvectorCentrality(g, 1e-12, 0)
+	for id, i := range index {
+		if !floats.EqualWithinAbsOrRel(got[id], want[i], 1e-8, 1e-8) {
+			t.Errorf("unexpected centrality for node %d: got:%v want:%v", id, got[id], want[i])
+		}
+	}
+
+	// A single iteration from the uniform vector
+	// gives scores proportional to degree plus one.
+	got = EigenvectorCentrality(g, 0, 1)
+	var hi float64
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		hi = math.Max(hi, float64(g.From(n.ID()).Len()+1))
+	}
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		want := float64(g.From(n.ID()).Len()+1) / hi
+		if got[n.ID()] != want {
+			t.Errorf("unexpected single iteration centrality for node %d: got:%v want:%v", n.ID(), got[n.ID()], want)
+		}
+	}
+}
+
+func TestEigenvectorCentralityNoTermination(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	panicked := func() (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		EigenvectorCentrality(g, 0, 0)
+		return false
+	}()
+	if !panicked {
+		t.Error("expected panic with neither tol nor maxIter positive")
+	}
+	if got := EigenvectorCentrality(g, 0, 10); got[0] != 1 || got[1] != 1 {
+		t.Errorf("unexpected eigenvector centrality with iteration limit: got:%v", got)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/mat"
+)
+
+// HeatKernel returns the heat kernel exp(-t·L) of the simple undirected graph g at
+// time t, where L is the graph Laplacian returned by NewLaplacian. The value held
+// in k[u][v] is the amount of heat diffused from the node with ID u to the node with
+// ID v after time t starting from a unit of heat at u, and is a measure of the
+// similarity of u and v. The kernel is symmetric and its rows sum to one.
+//
+// For small t the kernel is close to the identity and reflects local structure,
+// and as t increases heat spreads further through g. As t tends to infinity, the
+// kernel tends to the uniform distribution over the connected component of each
+// node.
+//
+// The kernel is computed from the eigendecomposition of L by mat.EigenSym, so
+// HeatKernel takes O(n³) time for a graph with n nodes. HeatKernel will panic if t
+// is negative or if g contains self edges.
+func HeatKernel(g graph.Undirected, t float64) (k map[int64]map[int64]float64) {
+	if t < 0 {
+		panic("network: negative diffusion time")
+	}
+	l := NewLaplacian(g)
+	n := len(l.Nodes)
+	k = make(map[int64]map[int64]float64, n)
+	if n == 0 {
+		return k
+	}
+
+	var eig mat.EigenSym
+	ok := eig.Factorize(l.Matrix.(mat.Symmetric), true)
+	if !ok {
+		panic("network: eigendecomposition failed")
+	}
+	vals := eig.Values(nil)
+	vecs := eig.VectorsTo(nil)
+
+	// Scale the eigenvectors by exp(-t·λ) to form
+	// V exp(-t·Λ) and multiply by Vᵀ.
+	var scaled mat.Dense
+	scaled.CloneFrom(vecs)
+	for j, λ := range vals {
+		f := math.Exp(-t * λ)
+		for i := 0; i < n; i++ {
+			scaled.Set(i, j, f*scaled.At(i, j))
+		}
+	}
+	var kernel mat.Dense
+	kernel.Mul(&scaled, vecs.T())
+
+	for i, u := range l.Nodes {
+		row := make(map[int64]float64, n)
+		for j, v := range l.Nodes {
+			row[v.ID()] = kernel.At(i, j)
+		}
+		k[u.ID()] = row
+	}
+	return k
+}
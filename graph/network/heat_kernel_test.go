@@ -0,0 +1,83 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestHeatKernel(t *testing.T) {
+	const tol = 1e-10
+
+	// A path 0-1-2-3 and an isolated edge 4-5.
+	g := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 3}, {4, 5}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+
+	for _, tm := range []float64{0, 1e-8} {
+		k := HeatKernel(g, tm)
+		for u, row := range k {
+			for v, got := range row {
+				want := 0.0
+				if u == v {
+					want = 1
+				}
+				if !floats.EqualWithinAbs(got, want, 1e-6) {
+					t.Errorf("unexpected kernel value at t=%v for %d,%d: got:%v want:%v", tm, u, v, got, want)
+				}
+			}
+		}
+	}
+
+	prev := HeatKernel(g, 0)
+	for _, tm := range []float64{0.01, 0.1, 0.5, 1, 2, 5} {
+		k := HeatKernel(g, tm)
+		for u, row := range k {
+			var sum float64
+			for v, got := range row {
+				sum += got
+				if !floats.EqualWithinAbs(got, k[v][u], tol) {
+					t.Errorf("kernel not symmetric at t=%v for %d,%d: %v != %v", tm, u, v, got, k[v][u])
+				}
+				if (u < 4) != (v < 4) && !floats.EqualWithinAbs(got, 0, tol) {
+					t.Errorf("unexpected diffusion between components at t=%v for %d,%d: %v", tm, u, v, got)
+				}
+			}
+			if !floats.EqualWithinAbs(sum, 1, tol) {
+				t.Errorf("unexpected row sum at t=%v for %d: got:%v want:1", tm, u, sum)
+			}
+			// The heat that has left each node grows with time.
+			if out, prevOut := 1-row[u], 1-prev[u][u]; out <= prevOut {
+				t.Errorf("off-diagonal heat did not grow at t=%v for %d: got:%v previous:%v", tm, u, out, prevOut)
+			}
+		}
+		// Heat reaches the far end of the path.
+		if k[0][3] <= prev[0][3] {
+			t.Errorf("diffusion from 0 to 3 did not grow at t=%v: got:%v previous:%v", tm, k[0][3], prev[0][3])
+		}
+		prev = k
+	}
+
+	// At large times the kernel is uniform over components.
+	k := HeatKernel(g, 100)
+	for u, row := range k {
+		for v, got := range row {
+			want := 0.0
+			switch {
+			case u < 4 && v < 4:
+				want = 0.25
+			case u >= 4 && v >= 4:
+				want = 0.5
+			}
+			if !floats.EqualWithinAbs(got, want, 1e-6) {
+				t.Errorf("unexpected kernel value at large t for %d,%d: got:%v want:%v", u, v, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// ForceDirectedLayout returns two-dimensional coordinates for the nodes of g found
+// by a Fruchterman-Reingold force-directed simulation, keyed by node ID. All pairs
+// of nodes repel each other and nodes joined by an edge attract each other, with
+// the ideal distance between nodes set to one. Starting from a random placement,
+// the nodes are moved by the net force on them for the given number of iterations,
+// with the maximum displacement in each iteration decreasing linearly to zero.
+// Edge directions and self loops are ignored.
+//
+// Unlike the original algorithm, nodes are not confined to a frame, so distinct
+// connected components are pushed apart by their mutual repulsion. Each iteration
+// takes O(|V|^2+|E|) time. If src is nil, rand.Float64 is used as the random
+// generator for the initial placement; the layout is otherwise deterministic.
+//
+// See Fruchterman and Reingold doi:10.1002/spe.4380211102 for details.
+func ForceDirectedLayout(g graph.Graph, iterations int, src rand.Source) map[int64][2]float64 {
+	rnd := rand.Float64
+	if src != nil {
+		rnd = rand.New(src).Float64
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	n := len(nodes)
+	indexOf := make(map[int64]int, n)
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+	seen := make(map[[2]int]bool)
+	var edges [][2]int
+	for i, u := range nodes {
+		to := g.From(u.ID())
+		for to.Next() {
+			j := indexOf[to.Node().ID()]
+			if i == j {
+				continue
+			}
+			e := [2]int{i, j}
+			if j < i {
+				e = [2]int{j, i}
+			}
+			if !seen[e] {
+				seen[e] = true
+				edges = append(edges, e)
+			}
+		}
+	}
+	// Sort edges so that the order of force
+	// accumulation does not depend on iteration
+	// order of g.
+	sort.Slice(edges, func(i, j int) bool {
+		return edges[i][0] < edges[j][0] || (edges[i][0] == edges[j][0] && edges[i][1] < edges[j][1])
+	})
+
+	// Place the nodes in a square with area n,
+	// so the ideal node distance k is one.
+	const k = 1
+	side := math.Sqrt(float64(n))
+	pos := make([][2]float64, n)
+	for i := range pos {
+		pos[i] = [2]float64{rnd() * side, rnd() * side}
+	}
+
+	disp := make([][2]float64, n)
+	temp0 := side / 10
+	for it := 0; it < iterations; it++ {
+		for i := range disp {
+			disp[i] = [2]float64{}
+		}
+		for i := range pos {
+			for j := i + 1; j < n; j++ {
+				dx, dy, d := separation(pos[i], pos[j])
+				f := k * k / d
+				disp[i][0] += dx / d * f
+				disp[i][1] += dy / d * f
+				disp[j][0] -= dx / d * f
+				disp[j][1] -= dy / d * f
+			}
+		}
+		for _, e := range edges {
+			i, j := e[0], e[1]
+			dx, dy, d := separation(pos[i], pos[j])
+			f := d * d / k
+			disp[i][0] -= dx / d * f
+			disp[i][1] -= dy / d * f
+			disp[j][0] += dx / d * f
+			disp[j][1] += dy / d * f
+		}
+
+		temp := temp0 * (1 - float64(it)/float64(iterations))
+		for i := range pos {
+			d := math.Hypot(disp[i][0], disp[i][1])
+			if d == 0 {
+				continue
+			}
+			step := math.Min(d, temp)
+			pos[i][0] += disp[i][0] / d * step
+			pos[i][1] += disp[i][1] / d * step
+		}
+	}
+
+	layout := make(map[int64][2]float64, n)
+	for i, u := range nodes {
+		layout[u.ID()] = pos[i]
+	}
+	return layout
+}
+
+// separation returns the displacement of a from b and its length. Coincident
+// points are treated as separated by a small distance along the x axis.
+func separation(a, b [2]float64) (dx, dy, d float64) {
+	const minSeparation = 1e-9
+	dx, dy = a[0]-b[0], a[1]-b[1]
+	d = math.Hypot(dx, dy)
+	if d < minSeparation {
+		return minSeparation, 0, minSeparation
+	}
+	return dx, dy, d
+}
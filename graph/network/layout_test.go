@@ -0,0 +1,72 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestForceDirectedLayout(t *testing.T) {
+	// Two disconnected 5-cliques.
+	g := simple.NewUndirectedGraph()
+	for c := 0; c < 2; c++ {
+		for i := 0; i < 5; i++ {
+			for j := i + 1; j < 5; j++ {
+				g.SetEdge(simple.Edge{F: simple.Node(5*c + i), T: simple.Node(5*c + j)})
+			}
+		}
+	}
+
+	layout := ForceDirectedLayout(g, 200, rand.NewSource(1))
+	if len(layout) != g.Nodes().Len() {
+		t.Fatalf("unexpected number of positioned nodes: got:%d want:%d", len(layout), g.Nodes().Len())
+	}
+	if again := ForceDirectedLayout(g, 200, rand.NewSource(1)); !reflect.DeepEqual(layout, again) {
+		t.Error("layout not deterministic for a fixed seed")
+	}
+
+	within, between := 0.0, math.Inf(1)
+	for u := int64(0); u < 10; u++ {
+		for v := u + 1; v < 10; v++ {
+			p, q := layout[u], layout[v]
+			d := math.Hypot(p[0]-q[0], p[1]-q[1])
+			if u/5 == v/5 {
+				within = math.Max(within, d)
+			} else {
+				between = math.Min(between, d)
+			}
+		}
+	}
+	if between <= within {
+		t.Errorf("components not separated: minimum distance between:%v maximum distance within:%v", between, within)
+	}
+}
+
+func TestForceDirectedLayoutEdgeLengths(t *testing.T) {
+	// Nodes joined by an edge are placed closer
+	// than nodes at the ends of a long path.
+	g := simple.NewUndirectedGraph()
+	const n = 10
+	for i := 0; i < n-1; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(i + 1)})
+	}
+	layout := ForceDirectedLayout(g, 300, rand.NewSource(1))
+	dist := func(u, v int64) float64 {
+		p, q := layout[u], layout[v]
+		return math.Hypot(p[0]-q[0], p[1]-q[1])
+	}
+	end := dist(0, n-1)
+	for i := int64(0); i < n-1; i++ {
+		if d := dist(i, i+1); d >= end {
+			t.Errorf("edge %d-%d is longer than the path span: %v >= %v", i, i+1, d, end)
+		}
+	}
+}
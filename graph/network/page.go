@@ -19,11 +19,10 @@ import (
 // vector difference between iterations is below tol. The returned map is
 // keyed on the graph node IDs.
 // If g is a graph.WeightedDirected, an edge-weighted PageRank is calculated.
+// Self-loop edges returned by g are included in the link structure; see
+// PageRankWithOptions.
 func PageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
-	if g, ok := g.(graph.WeightedDirected); ok {
-		return edgeWeightedPageRank(g, damp, tol)
-	}
-	return pageRank(g, damp, tol)
+	return PageRankWithOptions(g, damp, tol, PageRankOptions{IncludeSelfLoops: true})
 }
 
 // PageRankSparse returns the PageRank weights for nodes of the sparse directed
@@ -31,18 +30,56 @@ func PageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
 // vector difference between iterations is below tol. The returned map is
 // keyed on the graph node IDs.
 // If g is a graph.WeightedDirected, an edge-weighted PageRank is calculated.
+// Self-loop edges returned by g are included in the link structure; see
+// PageRankWithOptions.
 func PageRankSparse(g graph.Directed, damp, tol float64) map[int64]float64 {
+	return PageRankWithOptions(g, damp, tol, PageRankOptions{IncludeSelfLoops: true, Sparse: true})
+}
+
+// PageRankOptions holds options for a PageRankWithOptions calculation.
+type PageRankOptions struct {
+	// IncludeSelfLoops specifies whether self-loop
+	// edges are included in the link structure.
+	IncludeSelfLoops bool
+
+	// Sparse specifies whether the calculation uses
+	// a sparse representation of the link matrix.
+	Sparse bool
+}
+
+// PageRankWithOptions returns the PageRank weights for nodes of the directed graph g
+// using the given damping factor and terminating when the 2-norm of the vector
+// difference between iterations is below tol, with the calculation configured by
+// opts. The returned map is keyed on the graph node IDs.
+// If g is a graph.WeightedDirected, an edge-weighted PageRank is calculated.
+//
+// If opts.IncludeSelfLoops is true, a self-loop edge of a node is treated as a
+// link from the node to itself, so the node keeps the share of its own rank
+// given by the edge: for an unweighted graph the self-loop counts as one of
+// the node's out links, and for a weighted graph its share is its weight over
+// the total weight of the node's out edges. The weight of a self-loop is taken
+// from the edge returned by g.WeightedEdge since the value returned by g.Weight
+// for a node and itself is implementation dependent. If opts.IncludeSelfLoops
+// is false, self-loop edges are ignored and a node whose only out edge is a
+// self-loop is treated as dangling.
+func PageRankWithOptions(g graph.Directed, damp, tol float64, opts PageRankOptions) map[int64]float64 {
 	if g, ok := g.(graph.WeightedDirected); ok {
-		return edgeWeightedPageRankSparse(g, damp, tol)
+		if opts.Sparse {
+			return edgeWeightedPageRankSparse(g, damp, tol, opts.IncludeSelfLoops)
+		}
+		return edgeWeightedPageRank(g, damp, tol, opts.IncludeSelfLoops)
+	}
+	if opts.Sparse {
+		return pageRankSparse(g, damp, tol, opts.IncludeSelfLoops)
 	}
-	return pageRankSparse(g, damp, tol)
+	return pageRank(g, damp, tol, opts.IncludeSelfLoops)
 }
 
 // edgeWeightedPageRank returns the PageRank weights for nodes of the weighted directed graph g
 // using the given damping factor and terminating when the 2-norm of the
 // vector difference between iterations is below tol. The returned map is
 // keyed on the graph node IDs.
-func edgeWeightedPageRank(g graph.WeightedDirected, damp, tol float64) map[int64]float64 {
+func edgeWeightedPageRank(g graph.WeightedDirected, damp, tol float64, selfLoops bool) map[int64]float64 {
 	// edgeWeightedPageRank is implemented according to "How Google Finds Your Needle
 	// in the Web's Haystack" with the modification that
 	// the columns of hyperlink matrix H are calculated with edge weights.
@@ -60,16 +97,16 @@ func edgeWeightedPageRank(g graph.WeightedDirected, damp, tol float64) map[int64
 	m := mat.NewDense(len(nodes), len(nodes), nil)
 	dangling := damp / float64(len(nodes))
 	for j, u := range nodes {
-		to := graph.NodesOf(g.From(u.ID()))
+		to := outNodes(g, u, selfLoops)
 		var z float64
 		for _, v := range to {
-			if w, ok := g.Weight(u.ID(), v.ID()); ok {
+			if w, ok := edgeWeight(g, u.ID(), v.ID()); ok {
 				z += w
 			}
 		}
 		if z != 0 {
 			for _, v := range to {
-				if w, ok := g.Weight(u.ID(), v.ID()); ok {
+				if w, ok := edgeWeight(g, u.ID(), v.ID()); ok {
 					m.Set(indexOf[v.ID()], j, (w*damp)/z)
 				}
 			}
@@ -125,7 +162,7 @@ func edgeWeightedPageRank(g graph.WeightedDirected, damp, tol float64) map[int64
 // graph g using the given damping factor and terminating when the 2-norm of the
 // vector difference between iterations is below tol. The returned map is
 // keyed on the graph node IDs.
-func edgeWeightedPageRankSparse(g graph.WeightedDirected, damp, tol float64) map[int64]float64 {
+func edgeWeightedPageRankSparse(g graph.WeightedDirected, damp, tol float64, selfLoops bool) map[int64]float64 {
 	// edgeWeightedPageRankSparse is implemented according to "How Google Finds Your Needle
 	// in the Web's Haystack" with the modification that
 	// the columns of hyperlink matrix H are calculated with edge weights.
@@ -144,16 +181,16 @@ func edgeWeightedPageRankSparse(g graph.WeightedDirected, damp, tol float64) map
 	var dangling compressedRow
 	df := damp / float64(len(nodes))
 	for j, u := range nodes {
-		to := graph.NodesOf(g.From(u.ID()))
+		to := outNodes(g, u, selfLoops)
 		var z float64
 		for _, v := range to {
-			if w, ok := g.Weight(u.ID(), v.ID()); ok {
+			if w, ok := edgeWeight(g, u.ID(), v.ID()); ok {
 				z += w
 			}
 		}
 		if z != 0 {
 			for _, v := range to {
-				if w, ok := g.Weight(u.ID(), v.ID()); ok {
+				if w, ok := edgeWeight(g, u.ID(), v.ID()); ok {
 					m.addTo(indexOf[v.ID()], j, (w*damp)/z)
 				}
 			}
@@ -207,7 +244,7 @@ func edgeWeightedPageRankSparse(g graph.WeightedDirected, damp, tol float64) map
 // using the given damping factor and terminating when the 2-norm of the
 // vector difference between iterations is below tol. The returned map is
 // keyed on the graph node IDs.
-func pageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
+func pageRank(g graph.Directed, damp, tol float64, selfLoops bool) map[int64]float64 {
 	// pageRank is implemented according to "How Google Finds Your Needle
 	// in the Web's Haystack".
 	//
@@ -224,7 +261,7 @@ func pageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
 	m := mat.NewDense(len(nodes), len(nodes), nil)
 	dangling := damp / float64(len(nodes))
 	for j, u := range nodes {
-		to := graph.NodesOf(g.From(u.ID()))
+		to := outNodes(g, u, selfLoops)
 		f := damp / float64(len(to))
 		for _, v := range to {
 			m.Set(indexOf[v.ID()], j, f)
@@ -280,7 +317,7 @@ func pageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
 // graph g using the given damping factor and terminating when the 2-norm of the
 // vector difference between iterations is below tol. The returned map is
 // keyed on the graph node IDs.
-func pageRankSparse(g graph.Directed, damp, tol float64) map[int64]float64 {
+func pageRankSparse(g graph.Directed, damp, tol float64, selfLoops bool) map[int64]float64 {
 	// pageRankSparse is implemented according to "How Google Finds Your Needle
 	// in the Web's Haystack".
 	//
@@ -298,7 +335,7 @@ func pageRankSparse(g graph.Directed, damp, tol float64) map[int64]float64 {
 	var dangling compressedRow
 	df := damp / float64(len(nodes))
 	for j, u := range nodes {
-		to := graph.NodesOf(g.From(u.ID()))
+		to := outNodes(g, u, selfLoops)
 		f := damp / float64(len(to))
 		for _, v := range to {
 			m.addTo(indexOf[v.ID()], j, f)
@@ -349,6 +386,35 @@ func pageRankSparse(g graph.Directed, damp, tol float64) map[int64]float64 {
 	return ranks
 }
 
+// outNodes returns the nodes reachable directly from u in g, excluding
+// u itself unless selfLoops is true.
+func outNodes(g graph.Directed, u graph.Node, selfLoops bool) []graph.Node {
+	to := graph.NodesOf(g.From(u.ID()))
+	if selfLoops {
+		return to
+	}
+	for i, v := range to {
+		if v.ID() == u.ID() {
+			return append(to[:i:i], to[i+1:]...)
+		}
+	}
+	return to
+}
+
+// edgeWeight returns the weight of the edge from u to v in g. Self-loop
+// weights are obtained from the edge since the value of g.Weight for a
+// node and itself is implementation dependent.
+func edgeWeight(g graph.WeightedDirected, uid, vid int64) (w float64, ok bool) {
+	if uid != vid {
+		return g.Weight(uid, vid)
+	}
+	e := g.WeightedEdge(uid, vid)
+	if e == nil {
+		return 0, false
+	}
+	return e.Weight(), true
+}
+
 // rowCompressedMatrix implements row-compressed
 // matrix/vector multiplication.
 type rowCompressedMatrix []compressedRow
@@ -11,6 +11,8 @@ import (
 	"testing"
 
 	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/multi"
 	"gonum.org/v1/gonum/graph/simple"
 )
 
@@ -91,7 +93,7 @@ func TestPageRank(t *testing.T) {
 				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
 			}
 		}
-		got := pageRank(g, test.damp, test.tol)
+		got := pageRank(g, test.damp, test.tol, true)
 		prec := 1 - int(math.Log10(test.wantTol))
 		for n := range test.g {
 			if !floats.EqualWithinAbsOrRel(got[int64(n)], test.want[int64(n)], test.wantTol, test.wantTol) {
@@ -115,7 +117,7 @@ func TestPageRankSparse(t *testing.T) {
 				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
 			}
 		}
-		got := pageRankSparse(g, test.damp, test.tol)
+		got := pageRankSparse(g, test.damp, test.tol, true)
 		prec := 1 - int(math.Log10(test.wantTol))
 		for n := range test.g {
 			if !floats.EqualWithinAbsOrRel(got[int64(n)], test.want[int64(n)], test.wantTol, test.wantTol) {
@@ -201,7 +203,7 @@ func TestEdgeWeightedPageRank(t *testing.T) {
 				}
 			}
 		}
-		got := edgeWeightedPageRank(g, test.damp, test.tol)
+		got := edgeWeightedPageRank(g, test.damp, test.tol, true)
 		prec := 1 - int(math.Log10(test.wantTol))
 		for n := range test.g {
 			if !floats.EqualWithinAbsOrRel(got[int64(n)], test.want[int64(n)], test.wantTol, test.wantTol) {
@@ -232,7 +234,7 @@ func TestEdgeWeightedPageRankSparse(t *testing.T) {
 				}
 			}
 		}
-		got := edgeWeightedPageRankSparse(g, test.damp, test.tol)
+		got := edgeWeightedPageRankSparse(g, test.damp, test.tol, true)
 		prec := 1 - int(math.Log10(test.wantTol))
 		for n := range test.g {
 			if !floats.EqualWithinAbsOrRel(got[int64(n)], test.want[int64(n)], test.wantTol, test.wantTol) {
@@ -244,6 +246,62 @@ func TestEdgeWeightedPageRankSparse(t *testing.T) {
 	}
 }
 
+func TestPageRankSelfLoops(t *testing.T) {
+	weighted := multi.NewWeightedDirectedGraph()
+	unweighted := multi.NewDirectedGraph()
+	for _, e := range []struct {
+		from, to int64
+		weight   float64
+	}{
+		{from: 0, to: 1, weight: 1},
+		{from: 1, to: 0, weight: 1},
+		{from: 1, to: 2, weight: 1},
+		{from: 2, to: 0, weight: 1},
+		{from: 0, to: 0, weight: 8},
+	} {
+		weighted.SetWeightedLine(weighted.NewWeightedLine(multi.Node(e.from), multi.Node(e.to), e.weight))
+		unweighted.SetLine(unweighted.NewLine(multi.Node(e.from), multi.Node(e.to)))
+	}
+
+	for _, test := range []struct {
+		name string
+		g    graph.Directed
+	}{
+		{name: "weighted", g: weighted},
+		{name: "unweighted", g: unweighted},
+	} {
+		for _, sparse := range []bool{false, true} {
+			with := PageRankWithOptions(test.g, 0.85, 1e-8, PageRankOptions{IncludeSelfLoops: true, Sparse: sparse})
+			without := PageRankWithOptions(test.g, 0.85, 1e-8, PageRankOptions{Sparse: sparse})
+			for _, ranks := range []map[int64]float64{with, without} {
+				var sum float64
+				for _, r := range ranks {
+					sum += r
+				}
+				if !floats.EqualWithinAbsOrRel(sum, 1, 1e-6, 1e-6) {
+					t.Errorf("unexpected total rank for %s graph sparse=%t: got:%v want:1", test.name, sparse, sum)
+				}
+			}
+			if with[0] <= without[0] {
+				t.Errorf("self-loop did not retain rank for %s graph sparse=%t: got:%v without self-loop:%v",
+					test.name, sparse, with[0], without[0])
+			}
+			for _, id := range []int64{1, 2} {
+				if with[id] >= without[id] {
+					t.Errorf("unexpected rank for node %d of %s graph sparse=%t: got:%v without self-loop:%v",
+						id, test.name, sparse, with[id], without[id])
+				}
+			}
+		}
+	}
+
+	// The weight of the self-loop dominates the
+	// rank retained by node 0.
+	if got, unit := PageRank(weighted, 0.85, 1e-8)[0], PageRank(unweighted, 0.85, 1e-8)[0]; got <= unit {
+		t.Errorf("heavy self-loop did not retain more rank than unit self-loop: got:%v unit:%v", got, unit)
+	}
+}
+
 func orderedFloats(w map[int64]float64, prec int) []keyFloatVal {
 	o := make(orderedFloatsMap, 0, len(w))
 	for k, v := range w {
@@ -0,0 +1,131 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// NeighborhoodJaccard returns the Jaccard index of the neighborhoods of
+// the nodes a and b in g,
+//
+//  J(a,b) = |N(a) ∩ N(b)| / |N(a) ∪ N(b)|,
+//
+// where N(v) is the set of nodes joined to v by an edge, excluding v itself.
+// For directed graphs edges in both directions are considered. If both
+// neighborhoods are empty, NeighborhoodJaccard returns zero.
+func NeighborhoodJaccard(a, b graph.Node, g graph.Graph) float64 {
+	return jaccard(neighborhood(g, a.ID()), neighborhood(g, b.ID()))
+}
+
+// AllPairsJaccard returns the Jaccard index of the neighborhoods of every pair
+// of distinct nodes in g, as calculated by NeighborhoodJaccard. Pairs are
+// retained such that u.ID < v.ID where u and v are the nodes of the pair. The
+// number of pairs is quadratic in the number of nodes in g, so AllPairsJaccard
+// is only suitable for small graphs.
+func AllPairsJaccard(g graph.Graph) map[[2]int64]float64 {
+	nodes := graph.NodesOf(g.Nodes())
+	n := make([]map[int64]bool, len(nodes))
+	for i, u := range nodes {
+		n[i] = neighborhood(g, u.ID())
+	}
+	j := make(map[[2]int64]float64, len(nodes)*(len(nodes)-1)/2)
+	for i, u := range nodes {
+		uid := u.ID()
+		for k := i + 1; k < len(nodes); k++ {
+			vid := nodes[k].ID()
+			key := [2]int64{uid, vid}
+			if vid < uid {
+				key = [2]int64{vid, uid}
+			}
+			j[key] = jaccard(n[i], n[k])
+		}
+	}
+	return j
+}
+
+// CommonNeighbors returns the number of common neighbors of the nodes a and b
+// in g, |N(a) ∩ N(b)|, where N(v) is the set of nodes joined to v by an edge,
+// excluding v itself. For directed graphs edges in both directions are
+// considered.
+func CommonNeighbors(a, b graph.Node, g graph.Graph) int {
+	return len(commonNeighbors(neighborhood(g, a.ID()), neighborhood(g, b.ID())))
+}
+
+// AdamicAdar returns the Adamic-Adar index of the nodes a and b in g,
+//
+//  A(a,b) = \sum_{w ∈ N(a) ∩ N(b)} 1 / log(|N(w)|),
+//
+// where N(v) is the set of nodes joined to v by an edge, excluding v itself.
+// Common neighbors with few other neighbors contribute more to the index.
+// For directed graphs edges in both directions are considered. A common
+// neighbor of distinct nodes has at least two neighbors, so the logarithm is
+// positive; common neighbors with a single neighbor, which only arise when a
+// and b are the same node, are ignored.
+//
+// See Adamic and Adar doi:10.1016/S0378-8733(03)00009-1 for details.
+func AdamicAdar(a, b graph.Node, g graph.Graph) float64 {
+	var aa float64
+	for _, w := range commonNeighbors(neighborhood(g, a.ID()), neighborhood(g, b.ID())) {
+		deg := len(neighborhood(g, w))
+		if deg < 2 {
+			continue
+		}
+		aa += 1 / math.Log(float64(deg))
+	}
+	return aa
+}
+
+// commonNeighbors returns the IDs held in both a and b.
+func commonNeighbors(a, b map[int64]bool) []int64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	var common []int64
+	for id := range a {
+		if b[id] {
+			common = append(common, id)
+		}
+	}
+	return common
+}
+
+// neighborhood returns the IDs of the nodes joined to the node with
+// the given ID by an edge in either direction, excluding the node.
+func neighborhood(g graph.Graph, id int64) map[int64]bool {
+	n := make(map[int64]bool)
+	to := g.From(id)
+	for to.Next() {
+		n[to.Node().ID()] = true
+	}
+	if d, ok := g.(graph.Directed); ok {
+		from := d.To(id)
+		for from.Next() {
+			n[from.Node().ID()] = true
+		}
+	}
+	delete(n, id)
+	return n
+}
+
+// jaccard returns the Jaccard index of the sets a and b.
+func jaccard(a, b map[int64]bool) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	var common int
+	for id := range a {
+		if b[id] {
+			common++
+		}
+	}
+	union := len(a) + len(b) - common
+	if union == 0 {
+		return 0
+	}
+	return float64(common) / float64(union)
+}
@@ -0,0 +1,110 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestNeighborhoodJaccard(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{
+		// Nodes 0 and 1 share the neighbors 2 and 3.
+		{0, 2}, {0, 3}, {1, 2}, {1, 3},
+		// Nodes 4 and 5 have disjoint neighborhoods.
+		{4, 6}, {5, 7},
+		// Node 8 shares one of its two neighbors with node 0.
+		{8, 2}, {8, 9},
+	} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	g.AddNode(simple.Node(10))
+
+	for _, test := range []struct {
+		a, b int64
+		want float64
+	}{
+		{a: 0, b: 1, want: 1},
+		{a: 4, b: 5, want: 0},
+		{a: 0, b: 8, want: 1.0 / 3},
+		{a: 2, b: 3, want: 2.0 / 3},
+		{a: 10, b: 10, want: 0},
+	} {
+		got := NeighborhoodJaccard(simple.Node(test.a), simple.Node(test.b), g)
+		if got != test.want {
+			t.Errorf("unexpected Jaccard index for %d and %d: got:%v want:%v", test.a, test.b, got, test.want)
+		}
+	}
+
+	all := AllPairsJaccard(g)
+	n := g.Nodes().Len()
+	if len(all) != n*(n-1)/2 {
+		t.Errorf("unexpected number of pairs: got:%d want:%d", len(all), n*(n-1)/2)
+	}
+	for pair, got := range all {
+		if pair[0] >= pair[1] {
+			t.Errorf("unexpected pair ordering: %v", pair)
+		}
+		want := NeighborhoodJaccard(simple.Node(pair[0]), simple.Node(pair[1]), g)
+		if got != want {
+			t.Errorf("unexpected Jaccard index for %v: got:%v want:%v", pair, got, want)
+		}
+	}
+
+	// Edges in both directions are considered for directed graphs.
+	d := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{{0, 2}, {3, 0}, {1, 2}, {1, 3}} {
+		d.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	if got := NeighborhoodJaccard(simple.Node(0), simple.Node(1), d); got != 1 {
+		t.Errorf("unexpected Jaccard index for directed graph: got:%v want:1", got)
+	}
+}
+
+func TestCommonNeighborsAdamicAdar(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{
+		// Nodes 0 and 1 share the neighbors 2 and 3.
+		{0, 2}, {0, 3}, {1, 2}, {1, 3},
+		// Nodes 4 and 5 have disjoint neighborhoods.
+		{4, 6}, {5, 7},
+		// Node 8 shares one of its two neighbors with node 0.
+		{8, 2}, {8, 9},
+	} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	g.AddNode(simple.Node(10))
+
+	for _, test := range []struct {
+		a, b       int64
+		wantCommon int
+		wantAA     float64
+	}{
+		// Node 2 has three neighbors and node 3 has two.
+		{a: 0, b: 1, wantCommon: 2, wantAA: 1/math.Log(3) + 1/math.Log(2)},
+		{a: 4, b: 5, wantCommon: 0, wantAA: 0},
+		{a: 0, b: 8, wantCommon: 1, wantAA: 1 / math.Log(3)},
+		{a: 2, b: 3, wantCommon: 2, wantAA: 2 / math.Log(2)},
+		{a: 10, b: 10, wantCommon: 0, wantAA: 0},
+
+		// The only neighbor of node 6 is node 4,
+		// which has no other neighbors.
+		{a: 6, b: 6, wantCommon: 1, wantAA: 0},
+	} {
+		if got := CommonNeighbors(simple.Node(test.a), simple.Node(test.b), g); got != test.wantCommon {
+			t.Errorf("unexpected common neighbors for %d and %d: got:%d want:%d", test.a, test.b, got, test.wantCommon)
+		}
+		got := AdamicAdar(simple.Node(test.a), simple.Node(test.b), g)
+		if math.Abs(got-test.wantAA) > 1e-12 {
+			t.Errorf("unexpected Adamic-Adar index for %d and %d: got:%v want:%v", test.a, test.b, got, test.wantAA)
+		}
+		if rev := AdamicAdar(simple.Node(test.b), simple.Node(test.a), g); math.Abs(rev-got) > 1e-12 {
+			t.Errorf("asymmetric Adamic-Adar index for %d and %d: got:%v and %v", test.a, test.b, got, rev)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/mat"
+)
+
+// SpectralBisection partitions the nodes of the simple undirected graph g into two
+// parts using the Fiedler vector of the graph Laplacian, the eigenvector of its
+// second smallest eigenvalue. Nodes with a negative Fiedler vector component are
+// placed in b and all other nodes are placed in a. The orientation of the vector is
+// chosen so that the first node of g.Nodes() is in a. Spectral bisection gives a
+// partition with a small cut that tends to be balanced.
+//
+// The eigendecomposition is computed with mat.EigenSym, so SpectralBisection
+// takes O(n³) time for a graph with n nodes. If g has fewer than two nodes, all
+// nodes are returned in a. If g contains self edges, SpectralBisection will panic.
+func SpectralBisection(g graph.Undirected) (a, b []graph.Node) {
+	if g.Nodes().Len() < 2 {
+		return graph.NodesOf(g.Nodes()), nil
+	}
+	l := NewLaplacian(g)
+
+	var eig mat.EigenSym
+	ok := eig.Factorize(l.Matrix.(mat.Symmetric), true)
+	if !ok {
+		panic("network: eigendecomposition failed")
+	}
+	vecs := eig.VectorsTo(nil)
+
+	// Eigenvalues are returned in ascending order.
+	fiedler := vecs.ColView(1)
+	sign := 1.0
+	if fiedler.AtVec(0) < 0 {
+		sign = -1
+	}
+	for i, n := range l.Nodes {
+		if sign*fiedler.AtVec(i) < 0 {
+			b = append(b, n)
+		} else {
+			a = append(a, n)
+		}
+	}
+	return a, b
+}
+
+// AlgebraicConnectivity returns the algebraic connectivity of the simple undirected
+// graph g, the second smallest eigenvalue of its Laplacian, also known as the
+// Fiedler value. The algebraic connectivity is zero if and only if g is not
+// connected, and values close to zero indicate that g is close to being
+// disconnected. The algebraic connectivity of the complete graph on n nodes is n.
+//
+// The eigenvalues are computed with mat.EigenSym, so AlgebraicConnectivity takes
+// O(n³) time for a graph with n nodes. If g has fewer than two nodes,
+// AlgebraicConnectivity returns zero. If g contains self edges,
+// AlgebraicConnectivity will panic.
+func AlgebraicConnectivity(g graph.Undirected) float64 {
+	if g.Nodes().Len() < 2 {
+		return 0
+	}
+	l := NewLaplacian(g)
+
+	var eig mat.EigenSym
+	ok := eig.Factorize(l.Matrix.(mat.Symmetric), false)
+	if !ok {
+		panic("network: eigendecomposition failed")
+	}
+	// Eigenvalues are returned in ascending order.
+	return eig.Values(nil)[1]
+}
@@ -0,0 +1,118 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestSpectralBisection(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		cliques [][]int64
+		bridges [][2]int64
+	}{
+		{
+			name:    "two K4 joined by an edge",
+			cliques: [][]int64{{0, 1, 2, 3}, {4, 5, 6, 7}},
+			bridges: [][2]int64{{3, 4}},
+		},
+		{
+			name:    "K5 and K4 joined by an edge",
+			cliques: [][]int64{{0, 1, 2, 3, 4}, {5, 6, 7, 8}},
+			bridges: [][2]int64{{0, 8}},
+		},
+		{
+			name:    "two K5 joined by two edges",
+			cliques: [][]int64{{0, 2, 4, 6, 8}, {1, 3, 5, 7, 9}},
+			bridges: [][2]int64{{0, 1}, {4, 9}},
+		},
+	} {
+		g := simple.NewUndirectedGraph()
+		for _, c := range test.cliques {
+			for i, u := range c {
+				for _, v := range c[i+1:] {
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				}
+			}
+		}
+		for _, e := range test.bridges {
+			g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+		}
+
+		a, b := SpectralBisection(g)
+		got := [][]int64{nodeIDs(a), nodeIDs(b)}
+		sort.Sort(ordered.BySliceValues(got))
+		if !reflect.DeepEqual(got, test.cliques) {
+			t.Errorf("%s: unexpected bisection: got:%v want:%v", test.name, got, test.cliques)
+		}
+	}
+
+	g := simple.NewUndirectedGraph()
+	g.AddNode(simple.Node(0))
+	a, b := SpectralBisection(g)
+	if len(a) != 1 || len(b) != 0 {
+		t.Errorf("unexpected bisection of single node graph: got:%v and %v", a, b)
+	}
+}
+
+func nodeIDs(nodes []graph.Node) []int64 {
+	ids := make([]int64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	sort.Sort(ordered.Int64s(ids))
+	return ids
+}
+
+func TestAlgebraicConnectivity(t *testing.T) {
+	const tol = 1e-10
+
+	complete := func(n int) *simple.UndirectedGraph {
+		g := simple.NewUndirectedGraph()
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		return g
+	}
+	path := func(n int) *simple.UndirectedGraph {
+		g := simple.NewUndirectedGraph()
+		g.AddNode(simple.Node(0))
+		for u := 1; u < n; u++ {
+			g.SetEdge(simple.Edge{F: simple.Node(u - 1), T: simple.Node(u)})
+		}
+		return g
+	}
+	disconnected := complete(4)
+	disconnected.SetEdge(simple.Edge{F: simple.Node(10), T: simple.Node(11)})
+
+	for _, test := range []struct {
+		name string
+		g    graph.Undirected
+		want float64
+	}{
+		{name: "K2", g: complete(2), want: 2},
+		{name: "K6", g: complete(6), want: 6},
+		{name: "K10", g: complete(10), want: 10},
+		{name: "P5", g: path(5), want: 2 * (1 - math.Cos(math.Pi/5))},
+		{name: "disconnected", g: disconnected, want: 0},
+		{name: "single node", g: path(1), want: 0},
+		{name: "empty", g: simple.NewUndirectedGraph(), want: 0},
+	} {
+		got := AlgebraicConnectivity(test.g)
+		if math.Abs(got-test.want) > tol {
+			t.Errorf("%s: unexpected algebraic connectivity: got:%v want:%v", test.name, got, test.want)
+		}
+	}
+}
@@ -6,6 +6,7 @@ package path
 
 import (
 	"container/heap"
+	"math"
 
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/internal/set"
@@ -40,15 +41,68 @@ func AStar(s, t graph.Node, g graph.Graph, h Heuristic) (path Shortest, expanded
 			h = NullHeuristic
 		}
 	}
+	return aStar(s, t, g, weight, h)
+}
 
+// aStar is the A* implementation shared by AStar and the A* variants that
+// modify the edge weights used during the search. The nodes s and t must
+// exist in g and weight and h must not be nil.
+func aStar(s, t graph.Node, g graph.Graph, weight Weighting, h Heuristic) (path Shortest, expanded int) {
 	path = newShortestFrom(s, graph.NodesOf(g.Nodes()))
-	tid := t.ID()
-
-	visited := make(set.Int64s)
 	open := &aStarQueue{indexOf: make(map[int64]int)}
 	heap.Push(open, aStarNode{node: s, gscore: 0, fscore: h(s, t)})
+	expanded, _, _ = aStarSearch(t, g, weight, h, path, open, aStarHooks{})
+	return path, expanded
+}
 
+// aStarHooks modify the search made by aStarSearch. The zero
+// value gives an A* search.
+type aStarHooks struct {
+	// reopen is whether visited nodes that are reached with a
+	// lower gscore are returned to the open set, allowing the
+	// search to be seeded with gscores that are upper bounds.
+	reopen bool
+
+	// priority, if not nil, returns the key ordering the open
+	// set for a node v reached with gscore along a path of hops
+	// edges, in place of its fscore, gscore+h(v, t). Hop counts
+	// of seeded nodes other than the source are taken as zero.
+	priority func(v graph.Node, gscore float64, hops int) float64
+
+	// admit, if not nil, reports whether a node newly reached
+	// with the given key is added to the open set.
+	admit func(key float64) bool
+
+	// expand and examine, if not nil, are called before each
+	// node expansion and before each examination of the weight
+	// of an edge. A non-nil error terminates the search.
+	expand  func() error
+	examine func() error
+}
+
+// aStarSearch runs the A* main loop towards t from the nodes held in open,
+// recording the search in path, and modified by hooks. The number of expanded
+// nodes is returned. If the search is terminated by a hook, the error of the
+// hook is returned along with a lower bound on the keys of the nodes not yet
+// expanded.
+func aStarSearch(t graph.Node, g graph.Graph, weight Weighting, h Heuristic, path Shortest, open *aStarQueue, hooks aStarHooks) (expanded int, bound float64, err error) {
+	tid := t.ID()
+	key := hooks.priority
+	var hops map[int64]int
+	if key == nil {
+		key = func(v graph.Node, g float64, _ int) float64 { return g + h(v, t) }
+	} else {
+		hops = make(map[int64]int)
+	}
+	visited := make(set.Int64s)
 	for open.Len() != 0 {
+		if hooks.expand != nil {
+			if err := hooks.expand(); err != nil {
+				// The root of the open heap holds
+				// the minimum key.
+				return expanded, open.nodes[0].fscore, err
+			}
+		}
 		u := heap.Pop(open).(aStarNode)
 		uid := u.node.ID()
 		i := path.indexOf[uid]
@@ -61,9 +115,16 @@ func AStar(s, t graph.Node, g graph.Graph, h Heuristic) (path Shortest, expanded
 		visited.Add(uid)
 		for _, v := range graph.NodesOf(g.From(u.node.ID())) {
 			vid := v.ID()
-			if visited.Has(vid) {
+			if visited.Has(vid) && !hooks.reopen {
 				continue
 			}
+			if hooks.examine != nil {
+				if err := hooks.examine(); err != nil {
+					// The unexamined successors of u
+					// are bounded below by its key.
+					return expanded, u.fscore, err
+				}
+			}
 			j := path.indexOf[vid]
 
 			w, ok := weight(u.node.ID(), vid)
@@ -73,18 +134,52 @@ func AStar(s, t graph.Node, g graph.Graph, h Heuristic) (path Shortest, expanded
 			if w < 0 {
 				panic("A*: negative edge weight")
 			}
+			if math.IsInf(w, 1) {
+				// Edges with infinite weight are
+				// treated as absent.
+				continue
+			}
 			g := u.gscore + w
-			if n, ok := open.node(vid); !ok {
-				path.set(j, g, i)
-				heap.Push(open, aStarNode{node: v, gscore: g, fscore: g + h(v, t)})
-			} else if g < n.gscore {
+			var n int
+			if hops != nil {
+				n = hops[uid] + 1
+			}
+			if visited.Has(vid) {
+				if g < path.dist[j] {
+					visited.Remove(vid)
+					path.set(j, g, i)
+					if hops != nil {
+						hops[vid] = n
+					}
+					heap.Push(open, aStarNode{node: v, gscore: g, fscore: key(v, g, n)})
+				}
+				continue
+			}
+			if c, ok := open.node(vid); !ok {
+				// Nodes seeded with an upper bound
+				// are only improved upon.
+				if g < path.dist[j] {
+					f := key(v, g, n)
+					if hooks.admit != nil && !hooks.admit(f) {
+						continue
+					}
+					path.set(j, g, i)
+					if hops != nil {
+						hops[vid] = n
+					}
+					heap.Push(open, aStarNode{node: v, gscore: g, fscore: f})
+				}
+			} else if g < c.gscore {
 				path.set(j, g, i)
-				open.update(vid, g, g+h(v, t))
+				if hops != nil {
+					hops[vid] = n
+				}
+				open.update(vid, g, key(v, g, n))
 			}
 		}
 	}
 
-	return path, expanded
+	return expanded, 0, nil
 }
 
 // NullHeuristic is an admissible, consistent heuristic that will not speed up computation.
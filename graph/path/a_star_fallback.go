@@ -0,0 +1,44 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"context"
+	"time"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// AStarWithFallback finds a path from s to t in g, first attempting an exact A* search
+// that is limited to the given time budget. If the exact search completes within the
+// budget, its path and cost are returned with exact true. Otherwise a greedy best-first
+// search ordered by the heuristic alone is run to completion and its path and cost are
+// returned with exact false; the greedy path need not be a shortest path. If t is not
+// reachable from s, the path is nil and the cost is +Inf.
+//
+// If ctx is cancelled during the exact search, no fallback search is made and the best
+// path found by the exact search so far is returned, which may be nil with a cost of
+// +Inf, with exact false.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, AStarWithFallback will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. The greedy search is only well directed if h is
+// informative. AStarWithFallback will panic if g has a reachable negative edge
+// weight.
+func AStarWithFallback(ctx context.Context, s, t graph.Node, g graph.Graph, weight Weighting, h Heuristic, budget time.Duration) (path []graph.Node, cost float64, exact bool) {
+	exactCtx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+	path, cost, _, err := AStarWithOptions(s, t, g, AStarOptions{Weight: weight, Heuristic: h, Context: exactCtx})
+	if err == nil {
+		return path, cost, true
+	}
+	if ctx.Err() != nil {
+		return path, cost, false
+	}
+	greedy := func(_, heuristic float64, _ int) float64 { return heuristic }
+	path, cost = AStarCustomPriority(s, t, g, weight, greedy, h)
+	return path, cost, false
+}
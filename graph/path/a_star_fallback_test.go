@@ -0,0 +1,57 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestAStarWithFallback(t *testing.T) {
+	const n = 20
+	g := randomWeightedGrid(n, randomIntWeight(rand.NewSource(1)))
+	manhattan := func(x, y graph.Node) float64 {
+		xr, xc := x.ID()/n, x.ID()%n
+		yr, yc := y.ID()/n, y.ID()%n
+		return math.Abs(float64(xr-yr)) + math.Abs(float64(xc-yc))
+	}
+	s, goal := simple.Node(0), simple.Node(n*n-1)
+	pt, _ := AStar(s, goal, g, manhattan)
+	_, optimal := pt.To(goal.ID())
+
+	path, cost, exact := AStarWithFallback(context.Background(), s, goal, g, nil, manhattan, time.Minute)
+	if !exact {
+		t.Error("unexpected fallback with generous budget")
+	}
+	if cost != optimal {
+		t.Errorf("unexpected exact cost: got:%v want:%v", cost, optimal)
+	}
+
+	path, cost, exact = AStarWithFallback(context.Background(), s, goal, g, nil, manhattan, 0)
+	if exact {
+		t.Error("unexpected exact result with zero budget")
+	}
+	if path == nil || !topo.IsPathIn(g, path) || path[0].ID() != s.ID() || path[len(path)-1].ID() != goal.ID() {
+		t.Fatalf("invalid fallback path: %v", ids(path))
+	}
+	if cost < optimal || cost != weightOf(path, g.Weight) {
+		t.Errorf("invalid fallback cost: got:%v optimal:%v", cost, optimal)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	path, cost, exact = AStarWithFallback(ctx, s, goal, g, nil, manhattan, time.Minute)
+	if exact || path != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected result for cancelled context: got:%v,%v,%t want:nil,+Inf,false", ids(path), cost, exact)
+	}
+}
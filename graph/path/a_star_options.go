@@ -0,0 +1,152 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ErrBudgetExhausted is returned by AStarWithOptions when the search is
+// terminated by reaching a search budget.
+var ErrBudgetExhausted = errors.New("path: search budget exhausted")
+
+// AStarOptions holds the options for an AStarWithOptions search.
+type AStarOptions struct {
+	// Weight is the edge weighting used by the search.
+	// If Weight is nil, the Weight method of the graph
+	// is used if it implements Weighted, falling back
+	// to UniformCost otherwise.
+	Weight Weighting
+
+	// Heuristic is the heuristic used by the search.
+	// If Heuristic is nil, the HeuristicCost method of
+	// the graph is used if it implements HeuristicCoster,
+	// falling back to NullHeuristic otherwise.
+	Heuristic Heuristic
+
+	// MaxExpansions is the maximum number of nodes
+	// expanded by the search. If MaxExpansions is
+	// zero, the number of expansions is not limited.
+	MaxExpansions int
+
+	// MaxEdgeExaminations is the maximum number of
+	// edges examined by the search, counting each
+	// edge whose weight is evaluated. It bounds the
+	// work of the search more closely than
+	// MaxExpansions in graphs with high branching
+	// factor. If MaxEdgeExaminations is zero, the
+	// number of examinations is not limited.
+	MaxEdgeExaminations int
+
+	// UpperBound, if positive, is a known upper
+	// bound on the cost of the path sought, for
+	// example the cost of a candidate path. Nodes
+	// with an fscore at or above UpperBound are
+	// not added to the search frontier, so only
+	// paths cheaper than UpperBound are found.
+	UpperBound float64
+
+	// Context, if not nil, is checked before each
+	// expansion and the search is terminated when
+	// it is done.
+	Context context.Context
+}
+
+// AStarWithOptions finds the A*-shortest path from s to t in g, allowing the search to
+// be terminated early by the budget and cancellation options in opts. The path to t,
+// its cost and a lower bound on the cost of the shortest path are returned.
+//
+// If the search completes, the returned path is the shortest path, the lower bound is
+// equal to its cost and the returned error is nil. If t is not reachable from s, the
+// path is nil and the cost and lower bound are +Inf.
+//
+// If the search is terminated early, the returned path is the best path to t found so
+// far, or nil with a cost of +Inf if t has not yet been reached, and the lower bound is
+// the minimum fscore of the search frontier, or the incumbent cost if that is lower. The
+// difference between the cost and the lower bound gives the optimality gap of the
+// incumbent path. The returned error is ErrBudgetExhausted if a budget was reached, or
+// the error of the context if it was cancelled. The lower bound is only valid if the
+// heuristic is consistent.
+//
+// If opts.UpperBound is positive and no path cheaper than the bound is found, the path
+// is nil, the cost is +Inf and the returned error is nil. The lower bound is then the
+// upper bound, or +Inf if t is not reachable from s. The lower bound is only valid if
+// the heuristic is admissible.
+//
+// AStarWithOptions will panic if g has an A*-reachable negative edge weight.
+func AStarWithOptions(s, t graph.Node, g graph.Graph, opts AStarOptions) (path []graph.Node, cost, lowerBound float64, err error) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1), math.Inf(1), nil
+	}
+	weight := weightFor(g, opts.Weight)
+	h := heuristicFor(g, opts.Heuristic)
+
+	// pruned records whether a node has been
+	// excluded from the search by the upper bound.
+	var pruned bool
+	bounded := func(f float64) bool {
+		if opts.UpperBound > 0 && f >= opts.UpperBound {
+			pruned = true
+			return false
+		}
+		return true
+	}
+
+	tid := t.ID()
+	pt := newShortestFrom(s, graph.NodesOf(g.Nodes()))
+	open := &aStarQueue{indexOf: make(map[int64]int)}
+	if f := h(s, t); bounded(f) {
+		heap.Push(open, aStarNode{node: s, gscore: 0, fscore: f})
+	}
+
+	var expanded, examined int
+	hooks := aStarHooks{admit: bounded}
+	hooks.expand = func() error {
+		if opts.Context != nil {
+			select {
+			case <-opts.Context.Done():
+				return opts.Context.Err()
+			default:
+			}
+		}
+		if opts.MaxExpansions > 0 && expanded >= opts.MaxExpansions {
+			return ErrBudgetExhausted
+		}
+		expanded++
+		return nil
+	}
+	if opts.MaxEdgeExaminations > 0 {
+		hooks.examine = func() error {
+			if examined >= opts.MaxEdgeExaminations {
+				return ErrBudgetExhausted
+			}
+			examined++
+			return nil
+		}
+	}
+	_, frontier, err := aStarSearch(t, g, weight, h, pt, open, hooks)
+	if err != nil {
+		// The incumbent path follows the current
+		// predecessors, which may have improved
+		// since t was reached, so recompute its cost.
+		path, cost := pt.To(tid)
+		if path != nil {
+			cost = weightOf(path, weight)
+		}
+		return path, cost, math.Min(frontier, cost), err
+	}
+	if path, cost = pt.To(tid); path != nil {
+		return path, cost, cost, nil
+	}
+	if pruned {
+		return nil, math.Inf(1), opts.UpperBound, nil
+	}
+	return nil, math.Inf(1), math.Inf(1), nil
+}
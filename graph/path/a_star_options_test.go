@@ -0,0 +1,214 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestAStarWithOptions(t *testing.T) {
+	const n = 12
+	g := randomWeightedGrid(n, randomIntWeight(rand.NewSource(1)))
+	manhattan := func(x, y graph.Node) float64 {
+		xr, xc := x.ID()/n, x.ID()%n
+		yr, yc := y.ID()/n, y.ID()%n
+		return math.Abs(float64(xr-yr)) + math.Abs(float64(xc-yc))
+	}
+
+	rnd := rand.New(rand.NewSource(2))
+	for trial := 0; trial < 10; trial++ {
+		s, goal := simple.Node(rnd.Intn(n*n)), simple.Node(rnd.Intn(n*n))
+		pt, _ := AStar(s, goal, g, manhattan)
+		_, optimal := pt.To(goal.ID())
+
+		for _, budget := range []int{1, 2, 5, 10, 20, 50, 100, 0} {
+			p, cost, bound, err := AStarWithOptions(s, goal, g, AStarOptions{Heuristic: manhattan, MaxExpansions: budget})
+			if bound > optimal+1e-9 {
+				t.Errorf("trial %d budget %d: lower bound exceeds optimal cost: %v > %v", trial, budget, bound, optimal)
+			}
+			if p != nil {
+				if !topo.IsPathIn(g, p) || p[0].ID() != s.ID() || p[len(p)-1].ID() != goal.ID() {
+					t.Errorf("trial %d budget %d: invalid incumbent path: %v", trial, budget, ids(p))
+				}
+				if cost < optimal-1e-9 || cost != weightOf(p, g.Weight) {
+					t.Errorf("trial %d budget %d: invalid incumbent cost: got:%v optimal:%v", trial, budget, cost, optimal)
+				}
+			} else if !math.IsInf(cost, 1) {
+				t.Errorf("trial %d budget %d: unexpected cost without incumbent: %v", trial, budget, cost)
+			}
+			switch err {
+			case nil:
+				if cost != optimal || bound != optimal {
+					t.Errorf("trial %d budget %d: unexpected completed result: cost=%v bound=%v optimal=%v",
+						trial, budget, cost, bound, optimal)
+				}
+			case ErrBudgetExhausted:
+				if budget == 0 {
+					t.Errorf("trial %d: unexpected budget exhaustion without budget", trial)
+				}
+			default:
+				t.Errorf("trial %d budget %d: unexpected error: %v", trial, budget, err)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s, goal := simple.Node(0), simple.Node(n*n-1)
+	p, cost, bound, err := AStarWithOptions(s, goal, g, AStarOptions{Heuristic: manhattan, Context: ctx})
+	if err != context.Canceled {
+		t.Errorf("unexpected error for cancelled search: got:%v want:%v", err, context.Canceled)
+	}
+	if p != nil || !math.IsInf(cost, 1) || bound != manhattan(s, goal) {
+		t.Errorf("unexpected result for cancelled search: path=%v cost=%v bound=%v", ids(p), cost, bound)
+	}
+}
+
+func TestAStarWithOptionsEdgeBudget(t *testing.T) {
+	const n = 12
+	g := randomWeightedGrid(n, randomIntWeight(rand.NewSource(1)))
+	manhattan := func(x, y graph.Node) float64 {
+		xr, xc := x.ID()/n, x.ID()%n
+		yr, yc := y.ID()/n, y.ID()%n
+		return math.Abs(float64(xr-yr)) + math.Abs(float64(xc-yc))
+	}
+	s, goal := simple.Node(0), simple.Node(n*n-1)
+	pt, _ := AStar(s, goal, g, manhattan)
+	_, optimal := pt.To(goal.ID())
+
+	var calls int
+	counting := func(xid, yid int64) (float64, bool) {
+		calls++
+		return g.Weight(xid, yid)
+	}
+	for _, budget := range []int{1, 3, 10, 50, 100, 200, 0} {
+		calls = 0
+		p, cost, bound, err := AStarWithOptions(s, goal, g, AStarOptions{
+			Weight:              counting,
+			Heuristic:           manhattan,
+			MaxEdgeExaminations: budget,
+		})
+		// Weights are also evaluated to recompute
+		// the cost of an incumbent path.
+		relaxations := calls
+		if err != nil && p != nil {
+			relaxations -= len(p) - 1
+		}
+		if budget != 0 && relaxations > budget {
+			t.Errorf("budget %d: too many edges examined: got:%d", budget, relaxations)
+		}
+		if bound > optimal+1e-9 {
+			t.Errorf("budget %d: lower bound exceeds optimal cost: %v > %v", budget, bound, optimal)
+		}
+		switch err {
+		case nil:
+			if cost != optimal {
+				t.Errorf("budget %d: unexpected completed cost: got:%v want:%v", budget, cost, optimal)
+			}
+		case ErrBudgetExhausted:
+			if budget == 0 {
+				t.Error("unexpected budget exhaustion without budget")
+			}
+			if relaxations != budget {
+				t.Errorf("budget %d: search stopped before exhausting budget: examined %d edges", budget, relaxations)
+			}
+			if p != nil && cost < optimal {
+				t.Errorf("budget %d: incumbent cost below optimal: %v < %v", budget, cost, optimal)
+			}
+		default:
+			t.Errorf("budget %d: unexpected error: %v", budget, err)
+		}
+	}
+}
+
+// expansionCounter counts the nodes expanded by a search
+// as the number of calls to its From method.
+type expansionCounter struct {
+	graph.Weighted
+	n *int
+}
+
+func (g expansionCounter) From(id int64) graph.Nodes {
+	*g.n++
+	return g.Weighted.From(id)
+}
+
+func TestAStarWithOptionsUpperBound(t *testing.T) {
+	const n = 12
+	rnd := rand.New(rand.NewSource(1))
+	g := randomWeightedGrid(n, randomIntWeight(rand.NewSource(1)))
+	manhattan := func(x, y graph.Node) float64 {
+		xr, xc := x.ID()/n, x.ID()%n
+		yr, yc := y.ID()/n, y.ID()%n
+		return math.Abs(float64(xr-yr)) + math.Abs(float64(xc-yc))
+	}
+
+	for trial := 0; trial < 20; trial++ {
+		s, goal := simple.Node(rnd.Intn(n*n)), simple.Node(rnd.Intn(n*n))
+		pt, _ := AStar(s, goal, g, manhattan)
+		_, optimal := pt.To(goal.ID())
+
+		// A bound above the optimal cost still
+		// finds the optimal path.
+		p, cost, bound, err := AStarWithOptions(s, goal, g, AStarOptions{Heuristic: manhattan, UpperBound: optimal + 1})
+		if err != nil {
+			t.Errorf("trial %d: unexpected error: %v", trial, err)
+		}
+		if cost != optimal || bound != optimal || !topo.IsPathIn(g, p) || p[0].ID() != s.ID() || p[len(p)-1].ID() != goal.ID() {
+			t.Errorf("trial %d: unexpected bounded result: path=%v cost=%v bound=%v optimal=%v",
+				trial, ids(p), cost, bound, optimal)
+		}
+
+		if s == goal {
+			continue
+		}
+		// A bound at the optimal cost gives no improvement.
+		p, cost, bound, err = AStarWithOptions(s, goal, g, AStarOptions{Heuristic: manhattan, UpperBound: optimal})
+		if p != nil || !math.IsInf(cost, 1) || bound != optimal || err != nil {
+			t.Errorf("trial %d: unexpected result for unimprovable bound: path=%v cost=%v bound=%v err=%v",
+				trial, ids(p), cost, bound, err)
+		}
+	}
+
+	// The goal is only reachable through an expensive edge,
+	// so confirming that no path is cheaper than a candidate
+	// bound does not require exploring the whole grid.
+	wg := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range graph.WeightedEdgesOf(g.(*simple.WeightedUndirectedGraph).WeightedEdges()) {
+		wg.SetWeightedEdge(e)
+	}
+	goal := simple.Node(n * n)
+	wg.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(n*n - 1), T: goal, W: 1000})
+	var unbounded, bounded int
+	_, cost, _, _ := AStarWithOptions(simple.Node(0), goal, expansionCounter{Weighted: wg, n: &unbounded}, AStarOptions{})
+	if cost < 1000 {
+		t.Fatalf("unexpected cost of unbounded search: %v", cost)
+	}
+	p, cost, bound, err := AStarWithOptions(simple.Node(0), goal, expansionCounter{Weighted: wg, n: &bounded}, AStarOptions{UpperBound: 20})
+	if p != nil || !math.IsInf(cost, 1) || bound != 20 || err != nil {
+		t.Errorf("unexpected result for bounded search: path=%v cost=%v bound=%v err=%v", ids(p), cost, bound, err)
+	}
+	if bounded >= unbounded {
+		t.Errorf("upper bound did not reduce expansions: bounded:%d unbounded:%d", bounded, unbounded)
+	}
+
+	// An unreachable goal is reported as unreachable
+	// if the bound does not prune the search.
+	disconnected := simple.NewDirectedGraph()
+	disconnected.AddNode(simple.Node(0))
+	disconnected.AddNode(simple.Node(1))
+	p, cost, bound, err = AStarWithOptions(simple.Node(0), simple.Node(1), disconnected, AStarOptions{UpperBound: 10})
+	if p != nil || !math.IsInf(cost, 1) || !math.IsInf(bound, 1) || err != nil {
+		t.Errorf("unexpected result for unreachable goal: path=%v cost=%v bound=%v err=%v", ids(p), cost, bound, err)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// AStarCustomPriority finds a path from s to t in g using a best-first search that
+// orders its frontier by the key computed by priority from a node's gscore, its
+// heuristic estimate of the cost to t and the number of edges on the path to it. The
+// path to t and its cost are returned. If t is not reachable from s, the path is nil
+// and the cost is +Inf.
+//
+// With a priority of gscore+heuristic, AStarCustomPriority is equivalent to AStar.
+// A priority of gscore+w*heuristic for w greater than one gives weighted A*, and a
+// priority of heuristic alone gives greedy best-first search. The returned path is
+// only guaranteed to be a shortest path if the priority is equivalent to A* with a
+// consistent heuristic.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, AStarCustomPriority will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. AStarCustomPriority will panic if g has a reachable
+// negative edge weight.
+func AStarCustomPriority(s, t graph.Node, g graph.Graph, weight Weighting, priority func(gscore, heuristic float64, hops int) float64, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+	h = heuristicFor(g, h)
+
+	path := newShortestFrom(s, graph.NodesOf(g.Nodes()))
+	open := &aStarQueue{indexOf: make(map[int64]int)}
+	heap.Push(open, aStarNode{node: s, gscore: 0, fscore: priority(0, h(s, t), 0)})
+	aStarSearch(t, g, weight, h, path, open, aStarHooks{
+		priority: func(v graph.Node, gscore float64, hops int) float64 {
+			return priority(gscore, h(v, t), hops)
+		},
+	})
+	return path.To(t.ID())
+}
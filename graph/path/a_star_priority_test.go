@@ -0,0 +1,70 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestAStarCustomPriority(t *testing.T) {
+	aStarPriority := func(gscore, heuristic float64, _ int) float64 { return gscore + heuristic }
+	for _, directed := range []bool{false, true} {
+		g := randomWeightedGraph(100, 3, 50, directed, rand.NewSource(1))
+		// Make shortest paths unique so that the paths
+		// found do not depend on iteration order.
+		wg := weightedGraph{Graph: g, weight: PerturbForUniqueness(g, nil)}
+		for _, q := range [][2]int64{{0, 99}, {5, 42}, {17, 63}, {80, 3}} {
+			s, tn := simple.Node(q[0]), simple.Node(q[1])
+			pt, _ := AStar(s, tn, wg, nil)
+			wantPath, wantCost := pt.To(tn.ID())
+			path, cost := AStarCustomPriority(s, tn, wg, nil, aStarPriority, nil)
+			if !reflect.DeepEqual(ids(path), ids(wantPath)) {
+				t.Errorf("unexpected path for %v directed=%t: got:%v want:%v", q, directed, ids(path), ids(wantPath))
+			}
+			if cost != wantCost {
+				t.Errorf("unexpected cost for %v directed=%t: got:%v want:%v", q, directed, cost, wantCost)
+			}
+		}
+	}
+}
+
+func TestAStarCustomPriorityGreedy(t *testing.T) {
+	// A greedy search guided by hop counts to the goal follows
+	// the expensive direct route 0-2 rather than 0-1-2.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 5},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	hops := func(x, y graph.Node) float64 {
+		if x.ID() == y.ID() {
+			return 0
+		}
+		if x.ID() == 0 {
+			return 1
+		}
+		return 2
+	}
+	greedy := func(_, heuristic float64, _ int) float64 { return heuristic }
+	path, cost := AStarCustomPriority(simple.Node(0), simple.Node(2), g, nil, greedy, hops)
+	if got, want := ids(path), []int64{0, 2}; !reflect.DeepEqual(got, want) || cost != 5 {
+		t.Errorf("unexpected greedy result: got:%v,%v want:%v,5", got, cost, want)
+	}
+
+	path, cost = AStarCustomPriority(simple.Node(2), simple.Node(0), g, nil, greedy, nil)
+	if path != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected result for unreachable goal: got:%v,%v want:nil,+Inf", ids(path), cost)
+	}
+}
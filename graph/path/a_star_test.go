@@ -308,3 +308,39 @@ func TestAStarNullHeuristic(t *testing.T) {
 		}
 	}
 }
+
+func TestAStarInfiniteWeight(t *testing.T) {
+	// Node 1 is reachable from 0, but all edges out of 1 are blocked.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: math.Inf(1)},
+		{F: simple.Node(1), T: simple.Node(3), W: math.Inf(1)},
+		{F: simple.Node(2), T: simple.Node(4), W: 1},
+		{F: simple.Node(3), T: simple.Node(4), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	pt, expanded := AStar(simple.Node(0), simple.Node(4), g, nil)
+	if expanded != 2 {
+		t.Errorf("unexpected number of expanded nodes: got:%d want:2", expanded)
+	}
+	if p, w := pt.To(4); p != nil || !math.IsInf(w, 1) {
+		t.Errorf("unexpected path through blocked edges: got:%v weight=%v", p, w)
+	}
+
+	dt := DijkstraFrom(simple.Node(0), g)
+	all := DijkstraAllPaths(g)
+	for _, id := range []int64{2, 3, 4} {
+		if p, w := dt.To(id); p != nil || !math.IsInf(w, 1) {
+			t.Errorf("unexpected Dijkstra path to %d through blocked edges: got:%v weight=%v", id, p, w)
+		}
+		if p, w, _ := all.Between(0, id); p != nil || !math.IsInf(w, 1) {
+			t.Errorf("unexpected all paths path to %d through blocked edges: got:%v weight=%v", id, p, w)
+		}
+		if paths, w := all.AllBetween(0, id); paths != nil || !math.IsInf(w, 1) {
+			t.Errorf("unexpected all paths paths to %d through blocked edges: got:%v weight=%v", id, paths, w)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// AStarWarmStart finds the A*-shortest path from s to t in g using the heuristic h,
+// seeding the search with the shortest-path tree held in prev from an earlier search
+// starting at s. It is intended for replanning after a small change to g. The path
+// and its cost are returned in a Shortest along with the number of expanded nodes as
+// for AStar.
+//
+// The gscore of each node in prev is revalidated by walking its tree path with the
+// current edge weights; nodes whose tree path no longer exists in g are discarded and
+// nodes whose tree path cost has increased are given the increased cost. The
+// revalidated scores are the costs of real paths in g and so are upper bounds on the
+// optimal costs. Only the nodes with an edge that would reduce the score of a
+// neighbor are placed on the open set, along with t, so nodes whose costs cannot
+// have changed are not expanded again. These are the nodes on the frontier of the
+// previous search and the nodes adjacent to changed edges. The search then proceeds
+// as A* and the returned path has the same cost as the path that AStar would find
+// for an admissible heuristic.
+//
+// If prev is not a search from s, AStarWarmStart is equivalent to AStar. The weight
+// and heuristic defaults and panic conditions are the same as for AStar.
+func AStarWarmStart(prev Shortest, s, t graph.Node, g graph.Graph, h Heuristic) (path Shortest, expanded int) {
+	if prev.from == nil || prev.from.ID() != s.ID() {
+		return AStar(s, t, g, h)
+	}
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return Shortest{from: s}, 0
+	}
+	weight := weightFor(g, nil)
+	h = heuristicFor(g, h)
+
+	path = newShortestFrom(s, graph.NodesOf(g.Nodes()))
+	open := &aStarQueue{indexOf: make(map[int64]int)}
+
+	// Revalidate the previous gscores along the previous
+	// shortest-path tree. score holds NaN for nodes that
+	// have not yet been revalidated and +Inf for nodes
+	// that are no longer reachable through the tree.
+	score := make([]float64, len(prev.nodes))
+	for i := range score {
+		score[i] = math.NaN()
+	}
+	root := prev.indexOf[s.ID()]
+	score[root] = 0
+	var chain []int
+	for i := range prev.nodes {
+		chain = chain[:0]
+		for k := i; math.IsNaN(score[k]); k = prev.next[k] {
+			if prev.next[k] < 0 || len(chain) > len(prev.nodes) {
+				score[k] = math.Inf(1)
+				break
+			}
+			chain = append(chain, k)
+		}
+		for c := len(chain) - 1; c >= 0; c-- {
+			k := chain[c]
+			if !math.IsNaN(score[k]) {
+				continue
+			}
+			p := prev.next[k]
+			score[k] = math.Inf(1)
+			if math.IsInf(score[p], 1) || g.Node(prev.nodes[k].ID()) == nil || g.Edge(prev.nodes[p].ID(), prev.nodes[k].ID()) == nil {
+				continue
+			}
+			w, ok := weight(prev.nodes[p].ID(), prev.nodes[k].ID())
+			if !ok || w < 0 {
+				continue
+			}
+			score[k] = score[p] + w
+		}
+	}
+
+	for i, u := range prev.nodes {
+		if math.IsInf(score[i], 1) {
+			continue
+		}
+		j := path.indexOf[u.ID()]
+		if i == root {
+			path.set(j, 0, -1)
+		} else {
+			path.set(j, score[i], path.indexOf[prev.nodes[prev.next[i]].ID()])
+		}
+	}
+
+	// Only nodes with an edge that would improve the
+	// revalidated score of a neighbor need expanding,
+	// and the target is placed on the open set so the
+	// search stops once no better path can be found.
+	tid := t.ID()
+	for j, u := range path.nodes {
+		d := path.dist[j]
+		if math.IsInf(d, 1) {
+			continue
+		}
+		uid := u.ID()
+		push := uid == tid
+		to := g.From(uid)
+		for !push && to.Next() {
+			vid := to.Node().ID()
+			w, ok := weight(uid, vid)
+			if !ok {
+				panic("A*: unexpected invalid weight")
+			}
+			push = d+w < path.dist[path.indexOf[vid]]
+		}
+		if push {
+			heap.Push(open, aStarNode{node: u, gscore: d, fscore: d + h(u, t)})
+		}
+	}
+
+	expanded, _, _ = aStarSearch(t, g, weight, h, path, open, aStarHooks{reopen: true})
+	return path, expanded
+}
@@ -0,0 +1,134 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestAStarWarmStart(t *testing.T) {
+	const n = 6
+	node := func(r, c int) simple.Node { return simple.Node(r*n + c) }
+	manhattan := func(x, y graph.Node) float64 {
+		xr, xc := x.ID()/n, x.ID()%n
+		yr, yc := y.ID()/n, y.ID()%n
+		return math.Abs(float64(xr-yr)) + math.Abs(float64(xc-yc))
+	}
+
+	for _, test := range []struct {
+		name   string
+		change func(g *simple.WeightedUndirectedGraph, path []graph.Node)
+
+		// local is true for changes for which the
+		// warm start must expand fewer nodes than
+		// a cold start.
+		local bool
+	}{
+		{
+			name:   "unchanged",
+			change: func(*simple.WeightedUndirectedGraph, []graph.Node) {},
+			local:  true,
+		},
+		{
+			name: "increased edge on path",
+			change: func(g *simple.WeightedUndirectedGraph, path []graph.Node) {
+				g.SetWeightedEdge(simple.WeightedEdge{F: path[2], T: path[3], W: 20})
+			},
+		},
+		{
+			name: "removed edge on path",
+			change: func(g *simple.WeightedUndirectedGraph, path []graph.Node) {
+				g.RemoveEdge(path[1].ID(), path[2].ID())
+			},
+		},
+		{
+			name: "decreased edge off path",
+			change: func(g *simple.WeightedUndirectedGraph, _ []graph.Node) {
+				g.SetWeightedEdge(simple.WeightedEdge{F: node(n-1, 0), T: node(n-1, 1), W: 1})
+			},
+			local: true,
+		},
+	} {
+		g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		for r := 0; r < n; r++ {
+			for c := 0; c < n; c++ {
+				if c < n-1 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: node(r, c), T: node(r, c+1), W: 2})
+				}
+				if r < n-1 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: node(r, c), T: node(r+1, c), W: 2})
+				}
+			}
+		}
+		s, goal := node(0, 0), node(n-1, n-1)
+
+		prev, _ := AStar(s, goal, g, manhattan)
+		prevPath, _ := prev.To(goal.ID())
+		test.change(g, prevPath)
+
+		cold, coldExpanded := AStar(s, goal, g, manhattan)
+		warm, warmExpanded := AStarWarmStart(prev, s, goal, g, manhattan)
+		if test.local && warmExpanded >= coldExpanded {
+			t.Errorf("%s: warm start expanded no fewer nodes than cold start: got:%d cold:%d", test.name, warmExpanded, coldExpanded)
+		}
+
+		coldPath, coldWeight := cold.To(goal.ID())
+		warmPath, warmWeight := warm.To(goal.ID())
+		if warmWeight != coldWeight {
+			t.Errorf("%s: unexpected warm start weight: got:%v want:%v", test.name, warmWeight, coldWeight)
+		}
+		if !topo.IsPathIn(g, warmPath) || warmPath[0].ID() != s.ID() || warmPath[len(warmPath)-1].ID() != goal.ID() {
+			t.Errorf("%s: invalid warm start path: %v", test.name, warmPath)
+		}
+		var sum float64
+		for i := 1; i < len(warmPath); i++ {
+			w, _ := g.Weight(warmPath[i-1].ID(), warmPath[i].ID())
+			sum += w
+		}
+		if sum != warmWeight {
+			t.Errorf("%s: warm start path weight does not match reported weight: got:%v want:%v", test.name, sum, warmWeight)
+		}
+		if len(coldPath) == 0 {
+			t.Errorf("%s: unexpected missing cold path", test.name)
+		}
+	}
+}
+
+func TestAStarWarmStartRandom(t *testing.T) {
+	for seed := uint64(1); seed <= 20; seed++ {
+		rnd := rand.New(rand.NewSource(seed))
+		g := randomWeightedGraph(40, 3, 20, seed%2 == 0, rand.NewSource(seed))
+		nodes := graph.NodesOf(g.Nodes())
+		s, goal := nodes[rnd.Intn(len(nodes))], nodes[rnd.Intn(len(nodes))]
+		prev, _ := AStar(s, goal, g, nil)
+
+		// Reweight a few random edges.
+		b := g.(interface {
+			SetWeightedEdge(graph.WeightedEdge)
+		})
+		for i := 0; i < 5; i++ {
+			u := nodes[rnd.Intn(len(nodes))]
+			to := graph.NodesOf(g.From(u.ID()))
+			if len(to) == 0 {
+				continue
+			}
+			v := to[rnd.Intn(len(to))]
+			b.SetWeightedEdge(simple.WeightedEdge{F: u, T: v, W: float64(1 + rnd.Intn(20))})
+		}
+
+		cold, _ := AStar(s, goal, g, nil)
+		warm, _ := AStarWarmStart(prev, s, goal, g, nil)
+		if got, want := warm.WeightTo(goal.ID()), cold.WeightTo(goal.ID()); got != want {
+			t.Errorf("seed %d: unexpected warm start weight: got:%v want:%v", seed, got, want)
+		}
+	}
+}
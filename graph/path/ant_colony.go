@@ -0,0 +1,162 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+const (
+	// antPheromoneInfluence and antCostInfluence are
+	// the exponents of the pheromone level and the
+	// inverse edge cost in the ant colony transition
+	// probabilities.
+	antPheromoneInfluence = 1
+	antCostInfluence      = 2
+	// antEvaporation is the fraction of pheromone
+	// lost at the end of each iteration.
+	antEvaporation = 0.5
+)
+
+// AntColonyPath searches for a short path from s to t in g using ant colony
+// optimization and returns the best path found and its cost. If no ant finds a
+// path, the returned path is nil and the cost is +Inf.
+//
+// In each of the given number of iterations, each ant constructs a simple path
+// from s by choosing the next node with probability proportional to the product
+// of the pheromone level on the edge and the square of the inverse edge weight.
+// At the end of each iteration pheromone evaporates from all edges and each ant
+// that reached t deposits pheromone inversely proportional to its path cost on
+// the edges of its path. If g is undirected, pheromone is deposited on both
+// directions of an edge.
+//
+// AntColonyPath is a heuristic and the returned path is not guaranteed to be a
+// shortest path. Random numbers are drawn from src, or the global rand source
+// if src is nil; runs with equivalent sources are reproducible. If weight is nil,
+// the Weight method of g is used if g implements Weighted, falling back to
+// UniformCost otherwise. Edges with infinite weight are treated as absent.
+// AntColonyPath will panic if g has a negative edge weight.
+func AntColonyPath(s, t graph.Node, ants, iterations int, g graph.Graph, weight Weighting, src rand.Source) ([]graph.Node, float64) {
+	best := math.Inf(1)
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, best
+	}
+	if s.ID() == t.ID() {
+		return []graph.Node{g.Node(s.ID())}, 0
+	}
+	weight = weightFor(g, weight)
+	rnd := rand.Float64
+	if src != nil {
+		rnd = rand.New(src).Float64
+	}
+	_, isDirected := g.(graph.Directed)
+
+	pheromone := make(map[[2]int64]float64)
+	nodes := g.Nodes()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		to := g.From(uid)
+		for to.Next() {
+			pheromone[[2]int64{uid, to.Node().ID()}] = 1
+		}
+	}
+
+	var (
+		bestPath []graph.Node
+		tour     []graph.Node
+		cost     float64
+		cand     []graph.Node
+		prob     []float64
+	)
+	type walk struct {
+		path []graph.Node
+		cost float64
+	}
+	var found []walk
+	for it := 0; it < iterations; it++ {
+		found = found[:0]
+		for a := 0; a < ants; a++ {
+			tour = append(tour[:0], g.Node(s.ID()))
+			visited := map[int64]bool{s.ID(): true}
+			cost = 0
+			for tour[len(tour)-1].ID() != t.ID() {
+				uid := tour[len(tour)-1].ID()
+				cand = cand[:0]
+				prob = prob[:0]
+				var sum float64
+				adj := graph.NodesOf(g.From(uid))
+				sort.Sort(ordered.ByID(adj))
+				for _, v := range adj {
+					if visited[v.ID()] {
+						continue
+					}
+					w, ok := weight(uid, v.ID())
+					if !ok {
+						panic("ant colony: unexpected invalid weight")
+					}
+					if w < 0 {
+						panic("ant colony: negative edge weight")
+					}
+					if math.IsInf(w, 1) {
+						// Edges with infinite weight are
+						// treated as absent.
+						continue
+					}
+					p := math.Pow(pheromone[[2]int64{uid, v.ID()}], antPheromoneInfluence) *
+						math.Pow(1/math.Max(w, 1e-12), antCostInfluence)
+					cand = append(cand, v)
+					prob = append(prob, p)
+					sum += p
+				}
+				if len(cand) == 0 || sum == 0 {
+					break
+				}
+				r := rnd() * sum
+				next := len(cand) - 1
+				for i, p := range prob {
+					if r < p {
+						next = i
+						break
+					}
+					r -= p
+				}
+				v := cand[next]
+				w, _ := weight(uid, v.ID())
+				cost += w
+				visited[v.ID()] = true
+				tour = append(tour, v)
+			}
+			if tour[len(tour)-1].ID() != t.ID() {
+				continue
+			}
+			found = append(found, walk{path: append([]graph.Node(nil), tour...), cost: cost})
+			if cost < best {
+				best = cost
+				bestPath = found[len(found)-1].path
+			}
+		}
+
+		for e, p := range pheromone {
+			pheromone[e] = (1 - antEvaporation) * p
+		}
+		for _, w := range found {
+			deposit := 1 / math.Max(w.cost, 1e-12)
+			for i, u := range w.path[:len(w.path)-1] {
+				uid, vid := u.ID(), w.path[i+1].ID()
+				pheromone[[2]int64{uid, vid}] += deposit
+				if !isDirected {
+					pheromone[[2]int64{vid, uid}] += deposit
+				}
+			}
+		}
+	}
+	return bestPath, best
+}
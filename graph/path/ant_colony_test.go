@@ -0,0 +1,95 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestAntColonyPath(t *testing.T) {
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 2},
+		{F: simple.Node(1), T: simple.Node(5), W: 2},
+		{F: simple.Node(0), T: simple.Node(2), W: 1.5},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+		{F: simple.Node(3), T: simple.Node(5), W: 1},
+		{F: simple.Node(0), T: simple.Node(4), W: 1},
+		{F: simple.Node(4), T: simple.Node(5), W: 4},
+		{F: simple.Node(4), T: simple.Node(6), W: 1},
+		{F: simple.Node(6), T: simple.Node(7), W: 1},
+		{F: simple.Node(2), T: simple.Node(4), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	s, goal := simple.Node(0), simple.Node(5)
+	_, want := DijkstraFrom(s, g).To(goal.ID())
+
+	for seed := uint64(1); seed <= 5; seed++ {
+		p, cost := AntColonyPath(s, goal, 10, 20, g, nil, rand.NewSource(seed))
+		if cost != want {
+			t.Errorf("seed %d: unexpected cost: got:%v want:%v", seed, cost, want)
+		}
+		if !topo.IsPathIn(g, p) || p[0].ID() != s.ID() || p[len(p)-1].ID() != goal.ID() {
+			t.Errorf("seed %d: invalid path: %v", seed, p)
+		}
+	}
+
+	// Runs are reproducible for a given source.
+	p1, _ := AntColonyPath(s, goal, 3, 2, g, nil, rand.NewSource(1))
+	p2, _ := AntColonyPath(s, goal, 3, 2, g, nil, rand.NewSource(1))
+	if !equalPaths(p1, p2) {
+		t.Errorf("paths differ for the same source: %v != %v", p1, p2)
+	}
+
+	// Edges with infinite weight are not walked.
+	inf := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: math.Inf(1)},
+		{F: simple.Node(0), T: simple.Node(3), W: 1},
+		{F: simple.Node(3), T: simple.Node(2), W: 5},
+	} {
+		inf.SetWeightedEdge(e)
+	}
+	for seed := uint64(1); seed <= 5; seed++ {
+		p, cost := AntColonyPath(simple.Node(0), simple.Node(2), 5, 5, inf, nil, rand.NewSource(seed))
+		if got := ids(p); cost != 6 || !reflect.DeepEqual(got, []int64{0, 3, 2}) {
+			t.Errorf("seed %d: unexpected path with infinite weight edge: got:%v cost:%v want:[0 3 2] cost:6", seed, got, cost)
+		}
+	}
+	inf.RemoveEdge(3, 2)
+	if p, cost := AntColonyPath(simple.Node(0), simple.Node(2), 5, 5, inf, nil, rand.NewSource(1)); p != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected path through infinite weight edge: got:%v cost:%v", ids(p), cost)
+	}
+
+	// Unreachable goals give no path.
+	g.AddNode(simple.Node(8))
+	p, cost := AntColonyPath(s, simple.Node(8), 5, 5, g, nil, rand.NewSource(1))
+	if p != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected path to unreachable node: got:%v cost:%v", p, cost)
+	}
+}
+
+// equalPaths returns whether the paths a and b hold the same node IDs.
+func equalPaths(a, b []graph.Node) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID() != b[i].ID() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,176 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+)
+
+// ARAStar returns a function that performs an anytime repairing A* (ARA*) search
+// for a path from s to t in g. Each call of the returned function runs the search
+// with the next inflation factor of epsilons, in which the heuristic h is scaled by
+// the factor, and returns the best path found so far, its cost and a bound on its
+// suboptimality. The cost of the returned path is no more than the bound times the
+// cost of the shortest path. Search effort is reused between calls, with nodes whose
+// cost improves after they have been expanded deferred to the next call rather than
+// reexpanded. When the final factor is 1, the final path is a shortest path.
+//
+// Calls after the inflation factors are exhausted return the final result without
+// further search. If t is not reachable from s, the returned path is nil, the cost is
+// +Inf and the bound is 1.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, ARAStar will use the g.HeuristicCost
+// method if g implements HeuristicCoster, falling back to NullHeuristic otherwise. The
+// suboptimality bounds are only valid if h is consistent. ARAStar will panic if
+// epsilons is empty, if a factor is less than 1 or greater than the previous factor,
+// or if g has an A*-reachable negative edge weight.
+func ARAStar(s, t graph.Node, g graph.Graph, weight Weighting, h Heuristic, epsilons []float64) func() (path []graph.Node, cost, bound float64) {
+	if len(epsilons) == 0 {
+		panic("A*: no inflation factors")
+	}
+	for i, eps := range epsilons {
+		if eps < 1 || (i > 0 && eps > epsilons[i-1]) {
+			panic("A*: invalid inflation factor")
+		}
+	}
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return func() ([]graph.Node, float64, float64) { return nil, math.Inf(1), 1 }
+	}
+	a := &araStar{
+		t:      t,
+		g:      g,
+		weight: weightFor(g, weight),
+		h:      heuristicFor(g, h),
+		pt:     newShortestFrom(s, graph.NodesOf(g.Nodes())),
+		open:   &aStarQueue{indexOf: make(map[int64]int)},
+		closed: make(set.Int64s),
+		incons: make(map[int64]graph.Node),
+	}
+	a.incons[s.ID()] = s
+
+	var (
+		next  int
+		path  []graph.Node
+		cost  = math.Inf(1)
+		bound = math.Inf(1)
+	)
+	return func() ([]graph.Node, float64, float64) {
+		if next < len(epsilons) {
+			path, cost, bound = a.improve(epsilons[next])
+			next++
+		}
+		return append([]graph.Node(nil), path...), cost, bound
+	}
+}
+
+// araStar holds the search state of an ARAStar search.
+type araStar struct {
+	t      graph.Node
+	g      graph.Graph
+	weight Weighting
+	h      Heuristic
+
+	// pt holds the gscores and the
+	// shortest-path tree of the search.
+	pt Shortest
+
+	// open is the open set, closed the nodes
+	// expanded in the current iteration and
+	// incons the nodes whose gscore improved
+	// after they were expanded.
+	open   *aStarQueue
+	closed set.Int64s
+	incons map[int64]graph.Node
+}
+
+// improve runs an iteration of the search with the inflation factor eps
+// and returns the incumbent path, its cost and its suboptimality bound.
+func (a *araStar) improve(eps float64) (path []graph.Node, cost, bound float64) {
+	// Move the inconsistent nodes into the open
+	// set and rekey the open set for eps.
+	for id, n := range a.incons {
+		if _, ok := a.open.node(id); !ok {
+			a.open.nodes = append(a.open.nodes, aStarNode{node: n})
+		}
+	}
+	a.incons = make(map[int64]graph.Node)
+	for i := range a.open.nodes {
+		n := &a.open.nodes[i]
+		n.gscore = a.pt.WeightTo(n.node.ID())
+		n.fscore = n.gscore + eps*a.h(n.node, a.t)
+		a.open.indexOf[n.node.ID()] = i
+	}
+	heap.Init(a.open)
+	a.closed = make(set.Int64s)
+
+	tid := a.t.ID()
+	for a.open.Len() != 0 && a.pt.WeightTo(tid) > a.open.nodes[0].fscore {
+		u := heap.Pop(a.open).(aStarNode)
+		uid := u.node.ID()
+		i := a.pt.indexOf[uid]
+		a.closed.Add(uid)
+		to := a.g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			w, ok := a.weight(uid, vid)
+			if !ok {
+				panic("A*: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("A*: negative edge weight")
+			}
+			if math.IsInf(w, 1) {
+				continue
+			}
+			j := a.pt.indexOf[vid]
+			g := u.gscore + w
+			if g >= a.pt.dist[j] {
+				continue
+			}
+			a.pt.set(j, g, i)
+			if a.closed.Has(vid) {
+				a.incons[vid] = v
+				continue
+			}
+			f := g + eps*a.h(v, a.t)
+			if _, ok := a.open.node(vid); ok {
+				a.open.update(vid, g, f)
+			} else {
+				heap.Push(a.open, aStarNode{node: v, gscore: g, fscore: f})
+			}
+		}
+	}
+
+	path, _ = a.pt.To(tid)
+	if path == nil {
+		return nil, math.Inf(1), 1
+	}
+	// The path follows the current predecessors, which
+	// may have improved since t was reached, so its cost
+	// may be less than the gscore of t.
+	cost = weightOf(path, a.weight)
+
+	// The suboptimality of the incumbent is bounded by
+	// the minimum unscaled fscore of the nodes that may
+	// still lead to an improvement.
+	lower := math.Inf(1)
+	for _, n := range a.open.nodes {
+		lower = math.Min(lower, n.gscore+a.h(n.node, a.t))
+	}
+	for _, n := range a.incons {
+		lower = math.Min(lower, a.pt.WeightTo(n.ID())+a.h(n, a.t))
+	}
+	bound = eps
+	if lower > 0 {
+		bound = math.Min(bound, cost/lower)
+	}
+	return path, cost, math.Max(bound, 1)
+}
@@ -0,0 +1,83 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestARAStar(t *testing.T) {
+	const n = 15
+	g := randomWeightedGrid(n, randomIntWeight(rand.NewSource(1)))
+	manhattan := func(x, y graph.Node) float64 {
+		xr, xc := x.ID()/n, x.ID()%n
+		yr, yc := y.ID()/n, y.ID()%n
+		return math.Abs(float64(xr-yr)) + math.Abs(float64(xc-yc))
+	}
+	epsilons := []float64{3, 2, 1.5, 1}
+
+	rnd := rand.New(rand.NewSource(2))
+	for trial := 0; trial < 20; trial++ {
+		s, goal := simple.Node(rnd.Intn(n*n)), simple.Node(rnd.Intn(n*n))
+		_, optimal := dijkstraCost(s, goal, g, g.Weight)
+
+		next := ARAStar(s, goal, g, nil, manhattan, epsilons)
+		last := math.Inf(1)
+		for i, eps := range epsilons {
+			p, cost, bound := next()
+			if !topo.IsPathIn(g, p) || p[0].ID() != s.ID() || p[len(p)-1].ID() != goal.ID() {
+				t.Errorf("trial %d eps=%v: invalid path: %v", trial, eps, ids(p))
+				continue
+			}
+			if w := weightOf(p, g.Weight); w != cost {
+				t.Errorf("trial %d eps=%v: cost does not match path: got:%v want:%v", trial, eps, cost, w)
+			}
+			if bound < 1 || bound > eps {
+				t.Errorf("trial %d eps=%v: bound out of range: %v", trial, eps, bound)
+			}
+			if cost > bound*optimal+1e-9 {
+				t.Errorf("trial %d eps=%v: cost exceeds bound: cost:%v bound:%v optimal:%v", trial, eps, cost, bound, optimal)
+			}
+			if cost > last {
+				t.Errorf("trial %d eps=%v: cost increased: %v > %v", trial, eps, cost, last)
+			}
+			last = cost
+			if i == len(epsilons)-1 && (cost != optimal || bound != 1) {
+				t.Errorf("trial %d: final path not optimal: cost:%v bound:%v optimal:%v", trial, cost, bound, optimal)
+			}
+		}
+
+		// Further calls return the final result.
+		if _, cost, bound := next(); cost != optimal || bound != 1 {
+			t.Errorf("trial %d: unexpected result after final factor: cost:%v bound:%v", trial, cost, bound)
+		}
+	}
+
+	disconnected := simple.NewDirectedGraph()
+	disconnected.AddNode(simple.Node(0))
+	disconnected.AddNode(simple.Node(1))
+	p, cost, bound := ARAStar(simple.Node(0), simple.Node(1), disconnected, nil, nil, epsilons)()
+	if p != nil || !math.IsInf(cost, 1) || bound != 1 {
+		t.Errorf("unexpected result for unreachable goal: path:%v cost:%v bound:%v", ids(p), cost, bound)
+	}
+
+	for _, eps := range [][]float64{nil, {0.5}, {1, 2}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for inflation factors %v", eps)
+				}
+			}()
+			ARAStar(simple.Node(0), simple.Node(1), disconnected, nil, nil, eps)
+		}()
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// MinArborescence returns a minimum cost arborescence of the directed graph g rooted
+// at root, the directed analog of a minimum spanning tree, using the Chu-Liu/Edmonds
+// algorithm. The returned edges are directed away from root, with one edge entering
+// each node reachable from root other than root, and are sorted by the IDs of their
+// From and To nodes. The total cost of the edges is also returned. If some node of g
+// is not reachable from root, the arborescence spans only the reachable nodes and
+// spanning is false. If root is not in g, the returned edges are nil, the cost is
+// zero and spanning is false.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. Edges with infinite weight are treated as absent and
+// self edges are ignored. Negative edge weights are allowed.
+//
+// The time complexity of MinArborescence is O(|V|.|E|).
+func MinArborescence(root graph.Node, g graph.Directed, weight Weighting) (edges []simple.WeightedEdge, cost float64, spanning bool) {
+	if g.Node(root.ID()) == nil {
+		return nil, 0, false
+	}
+	weight = weightFor(g, weight)
+
+	// Index the nodes reachable from root through edges
+	// with finite weight, in breadth first order with
+	// neighbors visited in order of ID, and collect the
+	// arcs between them. Root is given index zero.
+	nodes := []graph.Node{g.Node(root.ID())}
+	index := map[int64]int{root.ID(): 0}
+	var arcs []arborescenceArc
+	for u := 0; u < len(nodes); u++ {
+		uid := nodes[u].ID()
+		to := graph.NodesOf(g.From(uid))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			vid := v.ID()
+			if vid == uid {
+				continue
+			}
+			w, ok := weight(uid, vid)
+			if !ok {
+				panic("path: unexpected invalid weight")
+			}
+			if math.IsInf(w, 1) {
+				continue
+			}
+			j, ok := index[vid]
+			if !ok {
+				j = len(nodes)
+				index[vid] = j
+				nodes = append(nodes, v)
+			}
+			arcs = append(arcs, arborescenceArc{u: u, v: j, w: w})
+		}
+	}
+	spanning = len(nodes) == g.Nodes().Len()
+
+	for _, i := range edmonds(len(nodes), arcs) {
+		a := arcs[i]
+		edges = append(edges, simple.WeightedEdge{F: nodes[a.u], T: nodes[a.v], W: a.w})
+		cost += a.w
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.F.ID() != b.F.ID() {
+			return a.F.ID() < b.F.ID()
+		}
+		return a.T.ID() < b.T.ID()
+	})
+	return edges, cost, spanning
+}
+
+// arborescenceArc is a weighted arc between node indices.
+type arborescenceArc struct {
+	u, v int
+	w    float64
+}
+
+// edmonds returns the indices into arcs of the arcs of a minimum arborescence
+// rooted at node zero of the graph with n nodes and the given arcs. Every node
+// must be reachable from node zero.
+func edmonds(n int, arcs []arborescenceArc) []int {
+	// Choose the cheapest arc entering each node other
+	// than the root, preferring the earliest arc for ties.
+	best := make([]int, n)
+	for v := range best {
+		best[v] = -1
+	}
+	for i, a := range arcs {
+		if a.v == 0 || a.u == a.v {
+			continue
+		}
+		if best[a.v] < 0 || a.w < arcs[best[a.v]].w {
+			best[a.v] = i
+		}
+	}
+
+	// Find the cycles formed by the chosen arcs by walking
+	// back from each node, marking the walk with its start.
+	// The comp slice holds the node of the contracted graph
+	// for each node, with the root remaining node zero, and
+	// inCycle marks nodes on a cycle.
+	comp := make([]int, n)
+	visit := make([]int, n)
+	for v := range comp {
+		comp[v] = -1
+		visit[v] = -1
+	}
+	comp[0] = 0
+	inCycle := make([]bool, n)
+	contracted := 1
+	for v := 1; v < n; v++ {
+		u := v
+		for u != 0 && visit[u] < 0 && comp[u] < 0 {
+			visit[u] = v
+			u = arcs[best[u]].u
+		}
+		if u != 0 && visit[u] == v && comp[u] < 0 {
+			// A cycle has been found through u.
+			for x := u; comp[x] < 0; x = arcs[best[x]].u {
+				comp[x] = contracted
+				inCycle[x] = true
+			}
+			contracted++
+		}
+	}
+	if contracted == 1 {
+		return best[1:]
+	}
+	for v := 1; v < n; v++ {
+		if !inCycle[v] {
+			comp[v] = contracted
+			contracted++
+		}
+	}
+
+	// Reweight the arcs entering cycles by the cost
+	// of the cycle arc they would replace.
+	var (
+		reduced []arborescenceArc
+		origin  []int
+	)
+	for i, a := range arcs {
+		cu, cv := comp[a.u], comp[a.v]
+		if cu == cv {
+			continue
+		}
+		w := a.w
+		if inCycle[a.v] {
+			w -= arcs[best[a.v]].w
+		}
+		reduced = append(reduced, arborescenceArc{u: cu, v: cv, w: w})
+		origin = append(origin, i)
+	}
+
+	// Expand the contracted solution, keeping the cycle
+	// arcs other than those replaced by entering arcs.
+	var chosen []int
+	entered := make([]bool, n)
+	for _, i := range edmonds(contracted, reduced) {
+		a := origin[i]
+		chosen = append(chosen, a)
+		entered[arcs[a].v] = true
+	}
+	for v := 1; v < n; v++ {
+		if inCycle[v] && !entered[v] {
+			chosen = append(chosen, best[v])
+		}
+	}
+	return chosen
+}
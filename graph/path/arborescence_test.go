@@ -0,0 +1,163 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestMinArborescence(t *testing.T) {
+	// The cheapest edges entering 1, 2 and 3 form
+	// the cycle 1-2-3-1, which must be broken by
+	// the cheaper entry from the root at 1.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 5},
+		{F: simple.Node(0), T: simple.Node(2), W: 6},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(1), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 2},
+		{F: simple.Node(3), T: simple.Node(1), W: 0.5},
+		{F: simple.Node(0), T: simple.Node(3), W: math.Inf(1)},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	edges, cost, spanning := MinArborescence(simple.Node(0), g, nil)
+	want := [][2]int64{{0, 1}, {1, 2}, {2, 3}}
+	if got := arcIDs(edges); !reflect.DeepEqual(got, want) || cost != 8 || !spanning {
+		t.Errorf("unexpected arborescence: got:%v cost:%v spanning:%t want:%v cost:8 spanning:true",
+			got, cost, spanning, want)
+	}
+
+	// Node 4 is unreachable and node 5 is only
+	// reachable through an edge with infinite weight.
+	g.AddNode(simple.Node(4))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(3), T: simple.Node(5), W: math.Inf(1)})
+	edges, cost, spanning = MinArborescence(simple.Node(0), g, nil)
+	if got := arcIDs(edges); !reflect.DeepEqual(got, want) || cost != 8 || spanning {
+		t.Errorf("unexpected arborescence for partially reachable graph: got:%v cost:%v spanning:%t want:%v cost:8 spanning:false",
+			got, cost, spanning, want)
+	}
+
+	edges, cost, spanning = MinArborescence(simple.Node(6), g, nil)
+	if edges != nil || cost != 0 || spanning {
+		t.Errorf("unexpected result for absent root: got:%v cost:%v spanning:%t", arcIDs(edges), cost, spanning)
+	}
+}
+
+func TestMinArborescenceBruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 200; trial++ {
+		n := 2 + rnd.Intn(5)
+		g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for i := 0; i < 3*n; i++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u == v {
+				continue
+			}
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(10) - 2)})
+		}
+		root := simple.Node(rnd.Intn(n))
+
+		edges, cost, spanning := MinArborescence(root, g, nil)
+		want, ok := bruteForceArborescence(root, g)
+		if spanning != ok {
+			t.Errorf("trial %d: unexpected spanning: got:%t want:%t", trial, spanning, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if cost != want {
+			t.Errorf("trial %d: unexpected cost: got:%v want:%v", trial, cost, want)
+		}
+		if !isArborescence(root, g, edges) {
+			t.Errorf("trial %d: result is not an arborescence: %v", trial, arcIDs(edges))
+		}
+	}
+}
+
+// bruteForceArborescence returns the cost of the minimum arborescence of g
+// rooted at root by enumerating all choices of parent for each node.
+func bruteForceArborescence(root graph.Node, g *simple.WeightedDirectedGraph) (float64, bool) {
+	nodes := graph.NodesOf(g.Nodes())
+	var edges []simple.WeightedEdge
+	best := math.Inf(1)
+	var choose func(i int)
+	choose = func(i int) {
+		if i == len(nodes) {
+			if isArborescence(root, g, edges) {
+				best = math.Min(best, weightOfEdges(edges))
+			}
+			return
+		}
+		v := nodes[i]
+		if v.ID() == root.ID() {
+			choose(i + 1)
+			return
+		}
+		for _, u := range graph.NodesOf(g.To(v.ID())) {
+			edges = append(edges, g.WeightedEdge(u.ID(), v.ID()).(simple.WeightedEdge))
+			choose(i + 1)
+			edges = edges[:len(edges)-1]
+		}
+	}
+	choose(0)
+	return best, !math.IsInf(best, 1)
+}
+
+// isArborescence returns whether edges form an arborescence
+// rooted at root spanning all the nodes of g.
+func isArborescence(root graph.Node, g graph.Graph, edges []simple.WeightedEdge) bool {
+	if len(edges) != g.Nodes().Len()-1 {
+		return false
+	}
+	parent := make(map[int64]int64)
+	for _, e := range edges {
+		if _, ok := parent[e.T.ID()]; ok || e.T.ID() == root.ID() {
+			return false
+		}
+		parent[e.T.ID()] = e.F.ID()
+	}
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		// Every node must reach the root within
+		// |V| steps following parents.
+		id := n.ID()
+		for i := 0; id != root.ID(); i++ {
+			p, ok := parent[id]
+			if !ok || i > len(edges) {
+				return false
+			}
+			id = p
+		}
+	}
+	return true
+}
+
+func weightOfEdges(edges []simple.WeightedEdge) float64 {
+	var w float64
+	for _, e := range edges {
+		w += e.W
+	}
+	return w
+}
+
+func arcIDs(edges []simple.WeightedEdge) [][2]int64 {
+	var ids [][2]int64
+	for _, e := range edges {
+		ids = append(ids, [2]int64{e.F.ID(), e.T.ID()})
+	}
+	return ids
+}
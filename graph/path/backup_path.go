@@ -0,0 +1,52 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"gonum.org/v1/gonum/graph"
+)
+
+// PrimaryAndBackup returns the shortest path from s to t in g and the shortest path
+// from s to t that shares no edge with it. The primary path is found first and its
+// edges are then hidden to find the backup; unlike Suurballe's algorithm the pair is
+// not jointly optimized, so a backup may not be found even when two edge-disjoint
+// paths exist. If g is undirected, an edge of the primary path is hidden in both
+// directions. If no primary or no backup path exists, ok is false.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted,
+// falling back to UniformCost otherwise. PrimaryAndBackup will panic if g has an
+// s-reachable negative edge weight.
+func PrimaryAndBackup(s, t graph.Node, g graph.Graph, weight Weighting) (primary, backup []graph.Node, ok bool) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, nil, false
+	}
+	weight = weightFor(g, weight)
+	nodes := graph.NodesOf(g.Nodes())
+
+	pt := newShortestFrom(s, nodes)
+	dijkstraFrom(s, g, weight, &pt)
+	primary, _ = pt.To(t.ID())
+	if len(primary) < 2 {
+		return primary, nil, false
+	}
+
+	_, isDirected := g.(graph.Directed)
+	used := make(map[[2]int64]bool)
+	for i, u := range primary[:len(primary)-1] {
+		uid, vid := u.ID(), primary[i+1].ID()
+		used[[2]int64{uid, vid}] = true
+		if !isDirected {
+			used[[2]int64{vid, uid}] = true
+		}
+	}
+	view := filteredGraph{
+		Graph:   g,
+		canWalk: func(uid, vid int64) bool { return !used[[2]int64{uid, vid}] },
+	}
+	bt := newShortestFrom(s, nodes)
+	dijkstraFrom(s, view, weight, &bt)
+	backup, _ = bt.To(t.ID())
+	return primary, backup, backup != nil
+}
@@ -0,0 +1,109 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+var primaryAndBackupTests = []struct {
+	name     string
+	g        func() graph.WeightedEdgeAdder
+	edges    []simple.WeightedEdge
+	s, t     int64
+	primary  []int64
+	backup   []int64
+	wantOK   bool
+	directed bool
+}{
+	{
+		name: "undirected square with diagonal",
+		g:    func() graph.WeightedEdgeAdder { return simple.NewWeightedUndirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(3), W: 1},
+			{F: simple.Node(0), T: simple.Node(2), W: 2},
+			{F: simple.Node(2), T: simple.Node(3), W: 2},
+			{F: simple.Node(1), T: simple.Node(2), W: 0.5},
+		},
+		s: 0, t: 3,
+		primary: []int64{0, 1, 3},
+		backup:  []int64{0, 2, 3},
+		wantOK:  true,
+	},
+	{
+		name: "directed two routes",
+		g:    func() graph.WeightedEdgeAdder { return simple.NewWeightedDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(0), T: simple.Node(3), W: 2},
+			{F: simple.Node(3), T: simple.Node(2), W: 2},
+		},
+		s: 0, t: 2,
+		primary:  []int64{0, 1, 2},
+		backup:   []int64{0, 3, 2},
+		wantOK:   true,
+		directed: true,
+	},
+	{
+		name: "bridge",
+		g:    func() graph.WeightedEdgeAdder { return simple.NewWeightedUndirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(0), T: simple.Node(2), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+		},
+		s: 0, t: 3,
+		primary: []int64{0, 2, 3},
+		wantOK:  false,
+	},
+}
+
+func TestPrimaryAndBackup(t *testing.T) {
+	for _, test := range primaryAndBackupTests {
+		g := test.g()
+		for _, e := range test.edges {
+			g.SetWeightedEdge(e)
+		}
+		gg := g.(graph.Graph)
+		primary, backup, ok := PrimaryAndBackup(simple.Node(test.s), simple.Node(test.t), gg, nil)
+		if ok != test.wantOK {
+			t.Errorf("%q: unexpected ok: got:%t want:%t", test.name, ok, test.wantOK)
+		}
+		if got := ids(primary); !reflect.DeepEqual(got, test.primary) {
+			t.Errorf("%q: unexpected primary path: got:%v want:%v", test.name, got, test.primary)
+		}
+		if got := ids(backup); !reflect.DeepEqual(got, test.backup) {
+			t.Errorf("%q: unexpected backup path: got:%v want:%v", test.name, got, test.backup)
+		}
+		if !ok {
+			continue
+		}
+		if !topo.IsPathIn(gg, backup) {
+			t.Errorf("%q: backup is not a path in the graph: %v", test.name, backup)
+		}
+		used := make(map[[2]int64]bool)
+		for i := 1; i < len(primary); i++ {
+			u, v := primary[i-1].ID(), primary[i].ID()
+			used[[2]int64{u, v}] = true
+			if !test.directed {
+				used[[2]int64{v, u}] = true
+			}
+		}
+		for i := 1; i < len(backup); i++ {
+			if used[[2]int64{backup[i-1].ID(), backup[i].ID()}] {
+				t.Errorf("%q: backup shares edge %d-%d with primary", test.name, backup[i-1].ID(), backup[i].ID())
+			}
+		}
+	}
+}
@@ -139,3 +139,32 @@ func BenchmarkAStarUndirectedmallWorld_100_5_20_2_Heur(b *testing.B) {
 	}
 	benchmarkAStarHeuristic(b, nswUndirected_100_5_20_2, h)
 }
+
+func benchmarkUnweightedShortestPath(b *testing.B, g graph.Undirected) {
+	var hops int
+	for i := 0; i < b.N; i++ {
+		_, hops = UnweightedShortestPath(simple.Node(0), simple.Node(1), g)
+	}
+	if hops == 0 {
+		b.Fatal("unexpected number of hops")
+	}
+}
+
+func BenchmarkUnweightedShortestPathGnp_10_tenth(b *testing.B) {
+	benchmarkUnweightedShortestPath(b, gnpUndirected_10_tenth)
+}
+func BenchmarkUnweightedShortestPathGnp_100_tenth(b *testing.B) {
+	benchmarkUnweightedShortestPath(b, gnpUndirected_100_tenth)
+}
+func BenchmarkUnweightedShortestPathGnp_1000_tenth(b *testing.B) {
+	benchmarkUnweightedShortestPath(b, gnpUndirected_1000_tenth)
+}
+func BenchmarkUnweightedShortestPathGnp_10_half(b *testing.B) {
+	benchmarkUnweightedShortestPath(b, gnpUndirected_10_half)
+}
+func BenchmarkUnweightedShortestPathGnp_100_half(b *testing.B) {
+	benchmarkUnweightedShortestPath(b, gnpUndirected_100_half)
+}
+func BenchmarkUnweightedShortestPathGnp_1000_half(b *testing.B) {
+	benchmarkUnweightedShortestPath(b, gnpUndirected_1000_half)
+}
@@ -0,0 +1,40 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// AStarWithBottleneck finds the A*-shortest path from s to t in g and returns it with
+// its total cost and the cost of its most expensive edge. The bottleneck edge does
+// not influence the choice of path; for the path that minimizes the most expensive
+// edge, a minimax path, a different search is required. If s and t are the same
+// node, the path has no edges and the maximum edge cost is zero. If no path exists,
+// the path is nil and the total and maximum edge costs are +Inf.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, AStarWithBottleneck will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. AStarWithBottleneck will panic if g has an A*-reachable
+// negative edge weight.
+func AStarWithBottleneck(s, t graph.Node, g graph.Graph, weight Weighting, h Heuristic) (path []graph.Node, total, maxEdgeCost float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1), math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+	pt, _ := aStar(s, t, g, weight, heuristicFor(g, h))
+	path, total = pt.To(t.ID())
+	if path == nil {
+		return nil, math.Inf(1), math.Inf(1)
+	}
+	for i := 1; i < len(path); i++ {
+		w, _ := weight(path[i-1].ID(), path[i].ID())
+		maxEdgeCost = math.Max(maxEdgeCost, w)
+	}
+	return path, total, maxEdgeCost
+}
@@ -0,0 +1,70 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestAStarWithBottleneck(t *testing.T) {
+	// The shortest path 0-1-2-3 has cost 6 and its
+	// most expensive edge is 1-2; the path 0-4-3 of
+	// cost 7 has a smaller bottleneck.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 4},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(4), W: 3.5},
+		{F: simple.Node(4), T: simple.Node(3), W: 3.5},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	path, total, maxEdge := AStarWithBottleneck(simple.Node(0), simple.Node(3), g, nil, nil)
+	if want := []int64{0, 1, 2, 3}; !reflect.DeepEqual(ids(path), want) {
+		t.Errorf("unexpected path: got:%v want:%v", ids(path), want)
+	}
+	if total != 6 || maxEdge != 4 {
+		t.Errorf("unexpected costs: got:%v,%v want:6,4", total, maxEdge)
+	}
+
+	path, total, maxEdge = AStarWithBottleneck(simple.Node(2), simple.Node(2), g, nil, nil)
+	if len(path) != 1 || total != 0 || maxEdge != 0 {
+		t.Errorf("unexpected result for trivial path: got:%v,%v,%v", ids(path), total, maxEdge)
+	}
+
+	g.AddNode(simple.Node(5))
+	path, total, maxEdge = AStarWithBottleneck(simple.Node(0), simple.Node(5), g, nil, nil)
+	if path != nil || !math.IsInf(total, 1) || !math.IsInf(maxEdge, 1) {
+		t.Errorf("unexpected result for unreachable node: got:%v,%v,%v", ids(path), total, maxEdge)
+	}
+}
+
+func TestAStarWithBottleneckRandom(t *testing.T) {
+	const n = 15
+	g := randomWeightedGrid(n, randomIntWeight(rand.NewSource(1)))
+	rnd := rand.New(rand.NewSource(2))
+	for i := 0; i < 20; i++ {
+		s, tgt := simple.Node(rnd.Intn(n*n)), simple.Node(rnd.Intn(n*n))
+		path, total, maxEdge := AStarWithBottleneck(s, tgt, g, nil, nil)
+		var want float64
+		for j := 1; j < len(path); j++ {
+			w, _ := g.Weight(path[j-1].ID(), path[j].ID())
+			want = math.Max(want, w)
+		}
+		if maxEdge != want {
+			t.Errorf("unexpected maximum edge cost from %d to %d: got:%v want:%v", s.ID(), tgt.ID(), maxEdge, want)
+		}
+		if got := weightOf(path, g.Weight); total != got {
+			t.Errorf("unexpected total cost from %d to %d: got:%v want:%v", s.ID(), tgt.ID(), total, got)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+// CapacityAwareCost returns a Weighting that discourages the use of edges with
+// no remaining capacity. The remaining capacity of the edge from x to y is held
+// in remaining under the key {x, y}; edges without an entry are not capacity
+// limited. The cost of an edge with no remaining capacity is fullPenalty, and
+// the cost of any other edge is that given by base, so full edges are avoided
+// when an alternative costs less than fullPenalty but are not forbidden. The
+// remaining map is consulted on each call, so callers may decrement capacities
+// as routes are assigned and the changes are reflected in subsequent searches
+// using the returned Weighting.
+func CapacityAwareCost(base Weighting, remaining map[[2]int64]int, fullPenalty float64) Weighting {
+	return func(xid, yid int64) (w float64, ok bool) {
+		w, ok = base(xid, yid)
+		if !ok || xid == yid {
+			return w, ok
+		}
+		if c, limited := remaining[[2]int64{xid, yid}]; limited && c <= 0 {
+			return fullPenalty, true
+		}
+		return w, true
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestCapacityAwareCost(t *testing.T) {
+	// The express route 0-1-3 is cheaper
+	// than the local route 0-2-3.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(3), W: 2},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	remaining := map[[2]int64]int{{1, 3}: 1}
+	const fullPenalty = 1000
+	weight := CapacityAwareCost(g.Weight, remaining, fullPenalty)
+
+	route := func() ([]int64, float64) {
+		pt, _ := AStar(simple.Node(0), simple.Node(3), weightedGraph{Graph: g, weight: weight}, nil)
+		p, cost := pt.To(3)
+		return ids(p), cost
+	}
+
+	if got, cost := route(); !reflect.DeepEqual(got, []int64{0, 1, 3}) || cost != 2 {
+		t.Errorf("unexpected route with capacity: got:%v cost:%v want:[0 1 3] cost:2", got, cost)
+	}
+
+	// Assign the route, using the last seat.
+	remaining[[2]int64{1, 3}]--
+	if got, ok := weight(1, 3); !ok || got != fullPenalty {
+		t.Errorf("unexpected cost for full edge: got:%v,%t want:%v,true", got, ok, float64(fullPenalty))
+	}
+	if got, cost := route(); !reflect.DeepEqual(got, []int64{0, 2, 3}) || cost != 4 {
+		t.Errorf("unexpected route avoiding full edge: got:%v cost:%v want:[0 2 3] cost:4", got, cost)
+	}
+
+	// Full edges are still used when there is no alternative.
+	g.RemoveEdge(2, 3)
+	if got, cost := route(); !reflect.DeepEqual(got, []int64{0, 1, 3}) || cost != 1+fullPenalty {
+		t.Errorf("unexpected route with only full edges: got:%v cost:%v want:[0 1 3] cost:%v", got, cost, 1+fullPenalty)
+	}
+
+	if got, ok := weight(1, 1); !ok || got != 0 {
+		t.Errorf("unexpected self cost: got:%v,%t want:0,true", got, ok)
+	}
+	if _, ok := weight(3, 0); ok {
+		t.Error("unexpected cost for absent edge")
+	}
+}
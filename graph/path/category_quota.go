@@ -0,0 +1,107 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// CategoryQuotaAStar finds the A*-shortest path from s to t in g that visits at least
+// minVisits distinct nodes for which category returns true, including s and t. The
+// path and its cost are returned. If no such path exists, the path is nil and the
+// cost is +Inf. The returned path may revisit nodes, including t, if that is required
+// to meet the quota.
+//
+// The search is performed over (node, visited category nodes) states until the quota
+// is met, so the number of states grows combinatorially with minVisits and the number
+// of category nodes reachable from s. CategoryQuotaAStar is intended for small quotas.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, CategoryQuotaAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. CategoryQuotaAStar will panic if g has an A*-reachable
+// negative edge weight.
+func CategoryQuotaAStar(s, t graph.Node, category func(graph.Node) bool, minVisits int, g graph.Graph, weight Weighting, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+
+	// The state of a label is the set of distinct category
+	// nodes visited, encoded by encodeIDSet, or quotaMet once
+	// at least minVisits category nodes have been visited.
+	visit := func(state string, n graph.Node) string {
+		if state == quotaMet || !category(n) {
+			return state
+		}
+		visited := decodeIDSet(state)
+		id := n.ID()
+		i := sort.Search(len(visited), func(i int) bool { return visited[i] >= id })
+		if i < len(visited) && visited[i] == id {
+			return state
+		}
+		if len(visited)+1 >= minVisits {
+			return quotaMet
+		}
+		visited = append(visited, 0)
+		copy(visited[i+1:], visited[i:])
+		visited[i] = id
+		return encodeIDSet(visited)
+	}
+	start := quotaMet
+	if minVisits > 0 {
+		start = visit("", s)
+	}
+
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		uid := u.node.ID()
+		state := u.state.(string)
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			w, ok := weight(uid, v.ID())
+			if !ok {
+				panic("A*: unexpected invalid weight")
+			}
+			yield(v, visit(state, v), w)
+		}
+	}
+	tid := t.ID()
+	isGoal := func(l *stateLabel) bool {
+		return l.node.ID() == tid && l.state.(string) == quotaMet
+	}
+	l, _ := stateAStar(s, start, t, heuristicFor(g, h), expand, isGoal)
+	if l == nil {
+		return nil, math.Inf(1)
+	}
+	return l.path(), l.gscore
+}
+
+// quotaMet is the CategoryQuotaAStar search state after the quota has
+// been met. It is distinct from any encoding of visited category nodes
+// since those have a length that is a multiple of 8.
+const quotaMet = "*"
+
+// encodeIDSet returns a comparable encoding of the sorted IDs.
+func encodeIDSet(ids []int64) string {
+	b := make([]byte, 8*len(ids))
+	for i, id := range ids {
+		binary.BigEndian.PutUint64(b[8*i:], uint64(id))
+	}
+	return string(b)
+}
+
+// decodeIDSet returns the sorted IDs encoded in s.
+func decodeIDSet(s string) []int64 {
+	ids := make([]int64, len(s)/8)
+	for i := range ids {
+		ids[i] = int64(binary.BigEndian.Uint64([]byte(s[8*i : 8*i+8])))
+	}
+	return ids
+}
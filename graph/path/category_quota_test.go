@@ -0,0 +1,75 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestCategoryQuotaAStar(t *testing.T) {
+	// The direct route 0-1-9 passes no category nodes.
+	// Category nodes 2 and 3 lie on a longer route and
+	// category node 4 is on a spur off node 0.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(9), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(3), W: 2},
+		{F: simple.Node(3), T: simple.Node(9), W: 2},
+		{F: simple.Node(0), T: simple.Node(4), W: 1.5},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	category := func(n graph.Node) bool {
+		switch n.ID() {
+		case 2, 3, 4:
+			return true
+		}
+		return false
+	}
+
+	for _, test := range []struct {
+		minVisits int
+		wantPath  []int64
+		wantCost  float64
+	}{
+		{minVisits: 0, wantPath: []int64{0, 1, 9}, wantCost: 2},
+		{minVisits: 1, wantPath: []int64{0, 4, 0, 1, 9}, wantCost: 5},
+		{minVisits: 2, wantPath: []int64{0, 2, 3, 9}, wantCost: 6},
+		{minVisits: 3, wantPath: []int64{0, 4, 0, 2, 3, 9}, wantCost: 9},
+		{minVisits: 4, wantPath: nil, wantCost: math.Inf(1)},
+	} {
+		path, cost := CategoryQuotaAStar(simple.Node(0), simple.Node(9), category, test.minVisits, g, nil, nil)
+		if got := ids(path); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("unexpected path for quota %d: got:%v want:%v", test.minVisits, got, test.wantPath)
+		}
+		if cost != test.wantCost {
+			t.Errorf("unexpected cost for quota %d: got:%v want:%v", test.minVisits, cost, test.wantCost)
+		}
+		var visited int
+		seen := make(map[int64]bool)
+		for _, n := range path {
+			if category(n) && !seen[n.ID()] {
+				seen[n.ID()] = true
+				visited++
+			}
+		}
+		if path != nil && visited < test.minVisits {
+			t.Errorf("path for quota %d visits only %d category nodes", test.minVisits, visited)
+		}
+	}
+
+	// The start and goal count toward the quota.
+	path, cost := CategoryQuotaAStar(simple.Node(2), simple.Node(3), category, 2, g, nil, nil)
+	if got, want := ids(path), []int64{2, 3}; !reflect.DeepEqual(got, want) || cost != 2 {
+		t.Errorf("unexpected path with category end points: got:%v,%v want:%v,2", got, cost, want)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ClearanceAStar finds the A*-shortest path from s to t in g, penalizing nodes that
+// lie close to any of the obstacle nodes. The graph distance from the obstacles to
+// each node is found by a multi-source Dijkstra search from the obstacles, and
+// clearancePenalty is added to the cost of entering each node whose distance is
+// no greater than clearanceRadius. Obstacle nodes are at distance zero and so are
+// penalized, but are not removed from the graph. If g is directed, the distance of
+// a node is the distance along the edges of g from the node to the nearest obstacle,
+// found by searching over reversed edges. The returned cost is the sum of
+// the edge weights along the path, excluding penalties. If no path exists, the
+// path is nil and the cost is +Inf. With clearancePenalty zero, ClearanceAStar is
+// equivalent to AStar.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. The same weighting is used for the obstacle distances
+// and the path search. If h is nil, ClearanceAStar will use the g.HeuristicCost method
+// if g implements HeuristicCoster, falling back to NullHeuristic otherwise. A heuristic
+// that is admissible for the edge weights remains admissible for a non-negative
+// clearancePenalty. ClearanceAStar will panic if g has a reachable negative edge weight.
+func ClearanceAStar(s, t graph.Node, obstacles []graph.Node, clearanceRadius, clearancePenalty float64, g graph.Graph, weight Weighting, h Heuristic) (path []graph.Node, cost float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+	search := weight
+	if clearancePenalty != 0 && len(obstacles) != 0 {
+		near := obstacleDistances(obstacles, g, weight, clearanceRadius)
+		search = func(xid, yid int64) (float64, bool) {
+			w, ok := weight(xid, yid)
+			if !ok || xid == yid {
+				return w, ok
+			}
+			if _, ok := near[yid]; ok {
+				w += clearancePenalty
+			}
+			return w, true
+		}
+	}
+	pt, _ := aStar(s, t, g, search, heuristicFor(g, h))
+	path, _ = pt.To(t.ID())
+	if path == nil {
+		return nil, math.Inf(1)
+	}
+	return path, weightOf(path, weight)
+}
+
+// obstacleDistances returns the distances between the nearest of the obstacle
+// nodes and each node of g within radius of an obstacle, found by a Dijkstra
+// search seeded with all the obstacles. If g is directed, the distances are
+// from each node to the obstacles, so the search follows reversed edges.
+func obstacleDistances(obstacles []graph.Node, g graph.Graph, weight Weighting, radius float64) map[int64]float64 {
+	if d, ok := g.(graph.Directed); ok {
+		g = reversedGraph{d}
+		weight = reversedWeight(weight)
+	}
+	dist := make(map[int64]float64)
+	var Q priorityQueue
+	for _, o := range obstacles {
+		if g.Node(o.ID()) == nil {
+			continue
+		}
+		dist[o.ID()] = 0
+		Q = append(Q, distanceNode{node: o})
+	}
+	for Q.Len() != 0 {
+		mid := heap.Pop(&Q).(distanceNode)
+		mnid := mid.node.ID()
+		if mid.dist > dist[mnid] {
+			continue
+		}
+		to := g.From(mnid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			w, ok := weight(mnid, vid)
+			if !ok {
+				panic("dijkstra: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("dijkstra: negative edge weight")
+			}
+			joint := mid.dist + w
+			if joint > radius {
+				continue
+			}
+			if d, ok := dist[vid]; !ok || joint < d {
+				dist[vid] = joint
+				heap.Push(&Q, distanceNode{node: v, dist: joint})
+			}
+		}
+	}
+	return dist
+}
@@ -0,0 +1,82 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestClearanceAStar(t *testing.T) {
+	const n = 7
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			id := int64(r*n + c)
+			if c+1 < n {
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(id), T: simple.Node(id + 1), W: 1})
+			}
+			if r+1 < n {
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(id), T: simple.Node(id + n), W: 1})
+			}
+		}
+	}
+	// clearance returns the smallest grid distance
+	// between a node of path and the obstacle.
+	obstacle := simple.Node(3*n + 3)
+	clearance := func(path []graph.Node) int {
+		min := math.MaxInt32
+		for _, p := range path {
+			r, c := int(p.ID())/n, int(p.ID())%n
+			d := abs(r-3) + abs(c-3)
+			if d < min {
+				min = d
+			}
+		}
+		return min
+	}
+
+	s, goal := simple.Node(3*n), simple.Node(3*n+n-1)
+	straight, cost := ClearanceAStar(s, goal, []graph.Node{obstacle}, 2, 0, g, nil, nil)
+	if cost != n-1 {
+		t.Errorf("unexpected cost without penalty: got:%v want:%d", cost, n-1)
+	}
+	if d := clearance(straight); d != 0 {
+		t.Errorf("unexpected clearance without penalty: got:%d want:0", d)
+	}
+
+	avoid, cost := ClearanceAStar(s, goal, []graph.Node{obstacle}, 2, 10, g, nil, nil)
+	if avoid == nil {
+		t.Fatal("unexpected nil path with penalty")
+	}
+	if d := clearance(avoid); d <= 2 {
+		t.Errorf("path with penalty passes within clearance radius: got clearance:%d path:%v", d, ids(avoid))
+	}
+	if want := weightOf(avoid, g.Weight); cost != want {
+		t.Errorf("unexpected cost with penalty: got:%v want:%v", cost, want)
+	}
+
+	if p, cost := ClearanceAStar(s, simple.Node(-1), nil, 2, 10, g, nil, nil); p != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected result for absent goal: got:%v,%v want:nil,+Inf", ids(p), cost)
+	}
+}
+
+func TestObstacleDistancesDirected(t *testing.T) {
+	// 0 -> 1 -> 2 -> 3 with the obstacle at 2. Only
+	// nodes that lead to the obstacle are near it.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 3}} {
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(e[0]), T: simple.Node(e[1]), W: 1})
+	}
+	got := obstacleDistances([]graph.Node{simple.Node(2)}, g, g.Weight, 1)
+	want := map[int64]float64{1: 1, 2: 0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected obstacle distances: got:%v want:%v", got, want)
+	}
+}
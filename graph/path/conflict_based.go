@@ -0,0 +1,267 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ConflictBasedSearch finds paths through g for each of the {from, to} node pairs in
+// agents such that no two agents occupy the same node at the same time and no two
+// agents traverse an edge between the same pair of nodes in opposite directions at the
+// same time. Agents move in discrete time steps, either traversing an edge or waiting
+// at their current node in each step, and remain at their destination once they have
+// arrived. The returned paths correspond to the agents and hold the node occupied by
+// the agent at each time step, so a waiting agent has repeated nodes in its path. The
+// returned boolean reports whether a conflict-free set of paths was found.
+//
+// The cost of a path is the sum of the weights of the edges it traverses and a cost of
+// one for each time step spent waiting before arriving at the destination, and the
+// returned paths minimize the total cost over all agents.
+// Conflicts are resolved by the Conflict-Based Search algorithm: each agent is routed
+// independently by a time-indexed A* search and the first conflict between the
+// current paths is resolved by branching on which of the two agents involved is
+// forbidden from it. To ensure termination, only conflicts within the first n·k time
+// steps are considered, where n is the number of nodes in g and k is the number of
+// agents; branches with a later conflict are abandoned, so solutions that can only be
+// reached through them are not found. The number of branches may grow exponentially
+// with the number of conflicts.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. The HeuristicCost method of g is used to guide the
+// searches if g implements HeuristicCoster. ConflictBasedSearch will panic if g has a
+// reachable negative edge weight.
+//
+// See Sharon et al. doi:10.1016/j.artint.2014.11.006 for details.
+func ConflictBasedSearch(agents [][2]graph.Node, g graph.Directed, weight Weighting) (paths [][]graph.Node, ok bool) {
+	for _, a := range agents {
+		if g.Node(a[0].ID()) == nil || g.Node(a[1].ID()) == nil {
+			return nil, false
+		}
+	}
+	weight = weightFor(g, weight)
+	h := heuristicFor(g, nil)
+	horizon := g.Nodes().Len() * len(agents)
+
+	root := &cbsNode{
+		paths: make([][]graph.Node, len(agents)),
+		costs: make([]float64, len(agents)),
+	}
+	for i, a := range agents {
+		p, cost, ok := cbsRoute(a[0], a[1], g, weight, h, root.constraintsOf(i))
+		if !ok {
+			return nil, false
+		}
+		root.paths[i] = p
+		root.costs[i] = cost
+		root.cost += cost
+	}
+
+	open := cbsQueue{root}
+	for open.Len() != 0 {
+		n := heap.Pop(&open).(*cbsNode)
+		c, ok := firstConflict(n.paths)
+		if !ok {
+			return n.paths, true
+		}
+		if c.time > horizon {
+			continue
+		}
+		for k, agent := range c.agents {
+			child := &cbsNode{
+				parent:     n,
+				agent:      agent,
+				constraint: c.constraints[k],
+				paths:      append([][]graph.Node(nil), n.paths...),
+				costs:      append([]float64(nil), n.costs...),
+			}
+			a := agents[agent]
+			p, cost, ok := cbsRoute(a[0], a[1], g, weight, h, child.constraintsOf(agent))
+			if !ok {
+				continue
+			}
+			child.paths[agent] = p
+			child.costs[agent] = cost
+			for _, c := range child.costs {
+				child.cost += c
+			}
+			heap.Push(&open, child)
+		}
+	}
+	return nil, false
+}
+
+// cbsConstraint forbids an agent from being at the node from at the
+// given time step, or, if edge is true, from traversing the edge from
+// the node from to the node to between the given time step and the next.
+type cbsConstraint struct {
+	from, to int64
+	edge     bool
+	time     int
+}
+
+// cbsConstraints is the set of constraints on a single agent.
+type cbsConstraints struct {
+	forbidden map[cbsConstraint]bool
+
+	// last is the latest time step
+	// of any of the constraints.
+	last int
+}
+
+// cbsNode is a node of the Conflict-Based Search constraint tree.
+type cbsNode struct {
+	// parent is the node this node was
+	// derived from by adding constraint
+	// to the constraints on agent.
+	parent     *cbsNode
+	agent      int
+	constraint cbsConstraint
+
+	paths [][]graph.Node
+	costs []float64
+	cost  float64
+}
+
+// constraintsOf returns the constraints on the given agent at n.
+func (n *cbsNode) constraintsOf(agent int) cbsConstraints {
+	c := cbsConstraints{forbidden: make(map[cbsConstraint]bool)}
+	for ; n.parent != nil; n = n.parent {
+		if n.agent != agent {
+			continue
+		}
+		c.forbidden[n.constraint] = true
+		if n.constraint.time > c.last {
+			c.last = n.constraint.time
+		}
+	}
+	return c
+}
+
+// cbsConflict is a conflict between two agents at the given time,
+// and the constraints on each of the agents that resolve it.
+type cbsConflict struct {
+	agents      [2]int
+	constraints [2]cbsConstraint
+	time        int
+}
+
+// firstConflict returns the earliest conflict between the agents following
+// paths and whether a conflict exists.
+func firstConflict(paths [][]graph.Node) (cbsConflict, bool) {
+	var end int
+	for _, p := range paths {
+		if len(p) > end {
+			end = len(p)
+		}
+	}
+	at := func(p []graph.Node, time int) int64 {
+		if time >= len(p) {
+			return p[len(p)-1].ID()
+		}
+		return p[time].ID()
+	}
+	for time := 0; time < end; time++ {
+		for i, p := range paths {
+			for j := i + 1; j < len(paths); j++ {
+				q := paths[j]
+				u := at(p, time)
+				if u == at(q, time) {
+					c := cbsConstraint{from: u, time: time}
+					return cbsConflict{agents: [2]int{i, j}, constraints: [2]cbsConstraint{c, c}, time: time}, true
+				}
+				v := at(p, time+1)
+				if u != v && u == at(q, time+1) && v == at(q, time) {
+					return cbsConflict{
+						agents: [2]int{i, j},
+						constraints: [2]cbsConstraint{
+							{from: u, to: v, edge: true, time: time},
+							{from: v, to: u, edge: true, time: time},
+						},
+						time: time,
+					}, true
+				}
+			}
+		}
+	}
+	return cbsConflict{}, false
+}
+
+// cbsRoute returns the A*-shortest time-indexed path from s to t in g that
+// satisfies the constraints c, its cost and whether such a path exists.
+func cbsRoute(s, t graph.Node, g graph.Directed, weight Weighting, h Heuristic, c cbsConstraints) (path []graph.Node, cost float64, ok bool) {
+	if c.forbidden[cbsConstraint{from: s.ID(), time: 0}] {
+		return nil, 0, false
+	}
+
+	// The state of a label is its time step. Beyond the
+	// last constraint all time steps are equivalent, so
+	// the time is capped to keep the state space finite.
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		uid := u.node.ID()
+		time := u.state.(int)
+		next := time + 1
+		if next > c.last+1 {
+			next = c.last + 1
+		}
+		if !c.forbidden[cbsConstraint{from: uid, time: time + 1}] {
+			yield(u.node, next, 1)
+		}
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if vid == uid {
+				continue
+			}
+			if c.forbidden[cbsConstraint{from: vid, time: time + 1}] || c.forbidden[cbsConstraint{from: uid, to: vid, edge: true, time: time}] {
+				continue
+			}
+			w, ok := weight(uid, vid)
+			if !ok {
+				panic("path: unexpected invalid weight")
+			}
+			yield(v, next, w)
+		}
+	}
+
+	// An agent remains at its destination, so it must
+	// not arrive before a constraint on that node.
+	tid := t.ID()
+	isGoal := func(l *stateLabel) bool {
+		if l.node.ID() != tid {
+			return false
+		}
+		time := l.state.(int)
+		for f := range c.forbidden {
+			if !f.edge && f.from == tid && f.time > time {
+				return false
+			}
+		}
+		return true
+	}
+	l, _ := stateAStar(s, 0, t, h, expand, isGoal)
+	if l == nil {
+		return nil, 0, false
+	}
+	return l.path(), l.gscore, true
+}
+
+// cbsQueue implements a priority queue of constraint tree nodes
+// ordered by total cost.
+type cbsQueue []*cbsNode
+
+func (q cbsQueue) Len() int            { return len(q) }
+func (q cbsQueue) Less(i, j int) bool  { return q[i].cost < q[j].cost }
+func (q cbsQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *cbsQueue) Push(n interface{}) { *q = append(*q, n.(*cbsNode)) }
+func (q *cbsQueue) Pop() interface{} {
+	t := *q
+	var n interface{}
+	n, *q = t[len(t)-1], t[:len(t)-1]
+	return n
+}
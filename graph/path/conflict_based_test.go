@@ -0,0 +1,155 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestConflictBasedSearch(t *testing.T) {
+	// cross is a pair of crossing two-way roads, 1-0-2 and 3-0-4,
+	// meeting at node 0.
+	cross := simple.NewDirectedGraph()
+	// siding is a two-way corridor 0-1-2 with a siding at node 3
+	// off node 1.
+	siding := simple.NewDirectedGraph()
+	// corridor is a two-way corridor 0-1.
+	corridor := simple.NewDirectedGraph()
+	// triangle is a two-way triangle 0-1-2.
+	triangle := simple.NewDirectedGraph()
+	for _, e := range []struct {
+		g     *simple.DirectedGraph
+		edges [][2]int64
+	}{
+		{g: cross, edges: [][2]int64{{1, 0}, {0, 2}, {3, 0}, {0, 4}}},
+		{g: siding, edges: [][2]int64{{0, 1}, {1, 2}, {1, 3}}},
+		{g: corridor, edges: [][2]int64{{0, 1}}},
+		{g: triangle, edges: [][2]int64{{0, 1}, {1, 2}, {2, 0}}},
+	} {
+		for _, uv := range e.edges {
+			e.g.SetEdge(simple.Edge{F: simple.Node(uv[0]), T: simple.Node(uv[1])})
+			e.g.SetEdge(simple.Edge{F: simple.Node(uv[1]), T: simple.Node(uv[0])})
+		}
+	}
+
+	for _, test := range []struct {
+		name     string
+		g        graph.Directed
+		agents   [][2]int64
+		wantOK   bool
+		wantCost float64
+	}{
+		{
+			// Both agents reach node 0 at the same
+			// time unless one of them waits.
+			name:     "cross",
+			g:        cross,
+			agents:   [][2]int64{{1, 2}, {3, 4}},
+			wantOK:   true,
+			wantCost: 5,
+		},
+		{
+			name:     "cross three",
+			g:        cross,
+			agents:   [][2]int64{{1, 2}, {3, 4}, {2, 1}},
+			wantOK:   true,
+			wantCost: 11,
+		},
+		{
+			// One agent must step into the siding
+			// to let the other pass.
+			name:     "siding",
+			g:        siding,
+			agents:   [][2]int64{{0, 2}, {2, 0}},
+			wantOK:   true,
+			wantCost: 7,
+		},
+		{
+			name:   "swap",
+			g:      corridor,
+			agents: [][2]int64{{0, 1}, {1, 0}},
+			wantOK: false,
+		},
+		{
+			// The direct routes swap along the edge 0-1,
+			// so one agent must go around via node 2.
+			name:     "swap around",
+			g:        triangle,
+			agents:   [][2]int64{{0, 1}, {1, 0}},
+			wantOK:   true,
+			wantCost: 3,
+		},
+		{
+			name:   "shared start",
+			g:      corridor,
+			agents: [][2]int64{{0, 1}, {0, 1}},
+			wantOK: false,
+		},
+	} {
+		agents := make([][2]graph.Node, len(test.agents))
+		for i, a := range test.agents {
+			agents[i] = [2]graph.Node{simple.Node(a[0]), simple.Node(a[1])}
+		}
+		paths, ok := ConflictBasedSearch(agents, test.g, nil)
+		if ok != test.wantOK {
+			t.Errorf("unexpected success for %s: got:%t want:%t", test.name, ok, test.wantOK)
+			continue
+		}
+		if !ok {
+			if paths != nil {
+				t.Errorf("unexpected paths for failed search %s: %v", test.name, paths)
+			}
+			continue
+		}
+
+		// With unit edge weights, each time step
+		// before arrival costs one.
+		var cost float64
+		for i, p := range paths {
+			cost += float64(len(p) - 1)
+			a := test.agents[i]
+			if p[0].ID() != a[0] || p[len(p)-1].ID() != a[1] {
+				t.Errorf("unexpected end points for agent %d in %s: got:%v want:%v", i, test.name, ids(p), a)
+			}
+			for k, v := range p[1:] {
+				u := p[k]
+				if u.ID() == v.ID() {
+					continue
+				}
+				if !test.g.HasEdgeFromTo(u.ID(), v.ID()) {
+					t.Errorf("unexpected move for agent %d in %s: %v", i, test.name, ids(p))
+				}
+			}
+		}
+		if cost != test.wantCost {
+			t.Errorf("unexpected cost for %s: got:%v want:%v", test.name, cost, test.wantCost)
+		}
+		if c, ok := firstConflict(paths); ok {
+			t.Errorf("unexpected conflict for %s at time %d between agents %v", test.name, c.time, c.agents)
+		}
+	}
+}
+
+func TestFirstConflictEdge(t *testing.T) {
+	// Agents swapping along an edge conflict on
+	// the edge rather than at a node.
+	paths := [][]graph.Node{
+		{simple.Node(0), simple.Node(1)},
+		{simple.Node(1), simple.Node(0)},
+	}
+	c, ok := firstConflict(paths)
+	if !ok {
+		t.Fatal("expected conflict for swapping agents")
+	}
+	for i, want := range [2][2]int64{{0, 1}, {1, 0}} {
+		got := c.constraints[i]
+		if !got.edge || got.from != want[0] || got.to != want[1] || got.time != 0 {
+			t.Errorf("unexpected constraint for agent %d: got:%+v want edge %d->%d at time 0", i, got, want[0], want[1])
+		}
+	}
+}
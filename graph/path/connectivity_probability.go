@@ -0,0 +1,111 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// ConnectivityProbability returns a Monte Carlo estimate of the probability that t
+// is reachable from s in g when each edge of g fails independently. The probability
+// that the edge from u to v survives is given by the value returned by reliability
+// for the IDs of u and v, which must be in [0, 1]. If reliability is nil, every edge
+// survives. The returned value is the fraction of trials in which t was reachable
+// from s.
+//
+// If g is a graph.Undirected, each edge is sampled once per trial and may be
+// traversed in either direction. Random numbers are drawn from src, or the global
+// rand source if src is nil. ConnectivityProbability will panic if trials is not
+// positive.
+func ConnectivityProbability(s, t graph.Node, g graph.Graph, reliability Weighting, trials int, src rand.Source) float64 {
+	if trials <= 0 {
+		panic("path: non-positive trial count")
+	}
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return 0
+	}
+	if s.ID() == t.ID() {
+		return 1
+	}
+	rnd := rand.Float64
+	if src != nil {
+		rnd = rand.New(src).Float64
+	}
+
+	// Collect the edges of g in a deterministic
+	// order so that trials are reproducible.
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	_, undirected := g.(graph.Undirected)
+	type edge struct {
+		from, to int
+		p        float64
+	}
+	var edges []edge
+	for i, u := range nodes {
+		uid := u.ID()
+		to := graph.NodesOf(g.From(uid))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			vid := v.ID()
+			j := indexOf[vid]
+			if undirected && j < i {
+				continue
+			}
+			p := 1.0
+			if reliability != nil {
+				p, _ = reliability(uid, vid)
+			}
+			edges = append(edges, edge{from: i, to: j, p: p})
+		}
+	}
+
+	from, to := indexOf[s.ID()], indexOf[t.ID()]
+	adj := make([][]int, len(nodes))
+	seen := make([]bool, len(nodes))
+	var reached int
+	for n := 0; n < trials; n++ {
+		for i := range adj {
+			adj[i] = adj[i][:0]
+			seen[i] = false
+		}
+		for _, e := range edges {
+			if rnd() >= e.p {
+				continue
+			}
+			adj[e.from] = append(adj[e.from], e.to)
+			if undirected {
+				adj[e.to] = append(adj[e.to], e.from)
+			}
+		}
+
+		seen[from] = true
+		queue := []int{from}
+		for len(queue) != 0 {
+			u := queue[0]
+			queue = queue[1:]
+			if u == to {
+				reached++
+				break
+			}
+			for _, v := range adj[u] {
+				if !seen[v] {
+					seen[v] = true
+					queue = append(queue, v)
+				}
+			}
+		}
+	}
+	return float64(reached) / float64(trials)
+}
@@ -0,0 +1,65 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestConnectivityProbability(t *testing.T) {
+	// Two components: 0-1-2-3 and 4-5.
+	g := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 3}, {4, 5}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	reliable := func(uid, vid int64) (float64, bool) { return 1, true }
+
+	for _, test := range []struct {
+		s, t int64
+		want float64
+	}{
+		{s: 0, t: 3, want: 1},
+		{s: 3, t: 0, want: 1},
+		{s: 4, t: 5, want: 1},
+		{s: 0, t: 4, want: 0},
+		{s: 5, t: 2, want: 0},
+		{s: 2, t: 2, want: 1},
+	} {
+		got := ConnectivityProbability(simple.Node(test.s), simple.Node(test.t), g, reliable, 100, rand.NewSource(1))
+		if got != test.want {
+			t.Errorf("unexpected connectivity probability from %d to %d: got:%v want:%v", test.s, test.t, got, test.want)
+		}
+	}
+
+	// Directed edges may only be traversed forwards.
+	d := simple.NewDirectedGraph()
+	d.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	if got := ConnectivityProbability(simple.Node(1), simple.Node(0), d, reliable, 10, nil); got != 0 {
+		t.Errorf("unexpected connectivity probability against edge direction: got:%v want:0", got)
+	}
+
+	// Two parallel routes of two edges each with reliability 0.5
+	// connect 0 to 3 with probability 1-(1-0.5*0.5)^2 = 0.4375.
+	p := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 3}, {0, 2}, {2, 3}} {
+		p.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	half := func(uid, vid int64) (float64, bool) { return 0.5, true }
+	s, u := graph.Node(simple.Node(0)), graph.Node(simple.Node(3))
+	got := ConnectivityProbability(s, u, p, half, 100000, rand.NewSource(1))
+	if want := 0.4375; math.Abs(got-want) > 0.01 {
+		t.Errorf("unexpected connectivity probability: got:%v want:%v", got, want)
+	}
+	again := ConnectivityProbability(s, u, p, half, 100000, rand.NewSource(1))
+	if again != got {
+		t.Errorf("connectivity probability not reproducible: got:%v and %v", got, again)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// ContractDegree2 returns a compacted copy of g where each maximal chain of
+// degree-2 nodes is replaced by a single edge between the chain's endpoints. The
+// weight of a replacement edge is the sum of the weights of the edges in the chain.
+// The Weight method of the returned graph gives the compacted edge weights. Nodes
+// that are not in the interior of a chain are retained and shortest path costs
+// between them are preserved; where two chains join the same pair of endpoints,
+// the cheaper is kept.
+//
+// A node is in the interior of a chain if it has exactly two neighbors and, when g
+// is directed, it is either passed through in one direction, with one in-neighbor
+// and a different out-neighbor, or in both directions, with both neighbors being
+// in- and out-neighbors. Cycles composed only of chain interior nodes are reduced
+// to their lowest ID node.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise.
+func ContractDegree2(g graph.Graph, weight Weighting) *simple.WeightedDirectedGraph {
+	weight = weightFor(g, weight)
+	to := func(id int64) graph.Nodes { return g.From(id) }
+	if d, ok := g.(graph.Directed); ok {
+		if _, ok := g.(graph.Undirected); !ok {
+			to = d.To
+		}
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	interior := make(map[int64]bool)
+	for _, n := range nodes {
+		id := n.ID()
+		out := neighborIDs(g.From(id), id)
+		in := neighborIDs(to(id), id)
+		switch {
+		case len(out) == 1 && len(in) == 1 && out[0] != in[0]:
+			interior[id] = true
+		case len(out) == 2 && len(in) == 2 && out[0] == in[0] && out[1] == in[1]:
+			interior[id] = true
+		}
+	}
+
+	c := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	visited := make(map[int64]bool)
+	contract := func(u graph.Node) {
+		uid := u.ID()
+		for _, v := range graph.NodesOf(g.From(uid)) {
+			prev, cur := uid, v.ID()
+			w, _ := weight(prev, cur)
+			for interior[cur] && cur != uid {
+				visited[cur] = true
+				var next int64
+				for _, n := range neighborIDs(g.From(cur), cur) {
+					if n != prev {
+						next = n
+						break
+					}
+				}
+				cw, _ := weight(cur, next)
+				w += cw
+				prev, cur = cur, next
+			}
+			if cur == uid {
+				continue
+			}
+			if c.Node(cur) == nil {
+				c.AddNode(g.Node(cur))
+			}
+			if e := c.WeightedEdge(uid, cur); e != nil && e.Weight() <= w {
+				continue
+			}
+			c.SetWeightedEdge(c.NewWeightedEdge(u, c.Node(cur), w))
+		}
+	}
+	for _, n := range nodes {
+		if interior[n.ID()] {
+			continue
+		}
+		if c.Node(n.ID()) == nil {
+			c.AddNode(n)
+		}
+		contract(n)
+	}
+	for _, n := range nodes {
+		if !interior[n.ID()] || visited[n.ID()] {
+			continue
+		}
+		visited[n.ID()] = true
+		c.AddNode(n)
+		contract(n)
+	}
+	return c
+}
+
+// neighborIDs returns the sorted IDs of the nodes in it, excluding id.
+func neighborIDs(it graph.Nodes, id int64) []int64 {
+	var ids []int64
+	for it.Next() {
+		if n := it.Node().ID(); n != id {
+			ids = append(ids, n)
+		}
+	}
+	sort.Sort(ordered.Int64s(ids))
+	return ids
+}
@@ -0,0 +1,162 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var contractDegree2Tests = []struct {
+	name     string
+	directed bool
+	edges    []simple.WeightedEdge
+
+	wantNodes []int64
+	wantEdges map[[2]int64]float64
+}{
+	{
+		name: "chain",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 2},
+			{F: simple.Node(2), T: simple.Node(3), W: 3},
+			{F: simple.Node(3), T: simple.Node(4), W: 4},
+			{F: simple.Node(4), T: simple.Node(5), W: 5},
+		},
+		wantNodes: []int64{0, 5},
+		wantEdges: map[[2]int64]float64{
+			{0, 5}: 15,
+			{5, 0}: 15,
+		},
+	},
+	{
+		name: "junction",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+			{F: simple.Node(3), T: simple.Node(4), W: 1},
+			{F: simple.Node(2), T: simple.Node(5), W: 2},
+			{F: simple.Node(5), T: simple.Node(6), W: 2},
+		},
+		wantNodes: []int64{0, 2, 4, 6},
+		wantEdges: map[[2]int64]float64{
+			{0, 2}: 2, {2, 0}: 2,
+			{2, 4}: 2, {4, 2}: 2,
+			{2, 6}: 4, {6, 2}: 4,
+		},
+	},
+	{
+		name: "parallel chains",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(0), T: simple.Node(3), W: 1},
+			{F: simple.Node(3), T: simple.Node(4), W: 1},
+			{F: simple.Node(4), T: simple.Node(2), W: 1},
+			{F: simple.Node(0), T: simple.Node(5), W: 1},
+			{F: simple.Node(2), T: simple.Node(6), W: 1},
+		},
+		wantNodes: []int64{0, 2, 5, 6},
+		wantEdges: map[[2]int64]float64{
+			{0, 2}: 2, {2, 0}: 2,
+			{0, 5}: 1, {5, 0}: 1,
+			{2, 6}: 1, {6, 2}: 1,
+		},
+	},
+	{
+		name: "cycle",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(0), W: 1},
+		},
+		wantNodes: []int64{0},
+		wantEdges: map[[2]int64]float64{},
+	},
+	{
+		name:     "one way chain",
+		directed: true,
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 2},
+			{F: simple.Node(2), T: simple.Node(3), W: 3},
+			{F: simple.Node(3), T: simple.Node(0), W: 4},
+			{F: simple.Node(0), T: simple.Node(4), W: 1},
+		},
+		wantNodes: []int64{0, 4},
+		wantEdges: map[[2]int64]float64{
+			{0, 4}: 1,
+		},
+	},
+	{
+		name:     "one way sink",
+		directed: true,
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(2), T: simple.Node(1), W: 1},
+			{F: simple.Node(3), T: simple.Node(4), W: 1},
+			{F: simple.Node(4), T: simple.Node(0), W: 1},
+		},
+		wantNodes: []int64{1, 2, 3},
+		wantEdges: map[[2]int64]float64{
+			{2, 1}: 1,
+			{3, 1}: 3,
+		},
+	},
+}
+
+func TestContractDegree2(t *testing.T) {
+	for _, test := range contractDegree2Tests {
+		var g interface {
+			graph.Graph
+			SetWeightedEdge(graph.WeightedEdge)
+		}
+		if test.directed {
+			g = simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		} else {
+			g = simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		}
+		for _, e := range test.edges {
+			g.SetWeightedEdge(e)
+		}
+
+		c := ContractDegree2(g, nil)
+
+		var gotNodes []int64
+		for _, n := range graph.NodesOf(c.Nodes()) {
+			gotNodes = append(gotNodes, n.ID())
+		}
+		sort.Sort(ordered.Int64s(gotNodes))
+		if !reflect.DeepEqual(gotNodes, test.wantNodes) {
+			t.Errorf("%q: unexpected nodes: got:%v want:%v", test.name, gotNodes, test.wantNodes)
+		}
+		gotEdges := make(map[[2]int64]float64)
+		for _, e := range graph.WeightedEdgesOf(c.WeightedEdges()) {
+			gotEdges[[2]int64{e.From().ID(), e.To().ID()}] = e.Weight()
+		}
+		if !reflect.DeepEqual(gotEdges, test.wantEdges) {
+			t.Errorf("%q: unexpected edges: got:%v want:%v", test.name, gotEdges, test.wantEdges)
+		}
+
+		// Shortest path costs between retained nodes are preserved.
+		before := DijkstraAllPaths(g)
+		after := DijkstraAllPaths(c)
+		for _, u := range test.wantNodes {
+			for _, v := range test.wantNodes {
+				if b, a := before.Weight(u, v), after.Weight(u, v); b != a {
+					t.Errorf("%q: unexpected shortest path cost from %d to %d: got:%v want:%v", test.name, u, v, a, b)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,389 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// witnessSettleLimit is the maximum number of nodes settled by a witness
+// search during contraction. Searches that reach the limit add a shortcut,
+// which may be redundant but does not affect query correctness.
+const witnessSettleLimit = 64
+
+// CH is a contraction hierarchy for fast repeated shortest path queries on a
+// static graph.
+type CH struct {
+	nodes   []graph.Node
+	indexOf map[int64]int
+
+	// up holds, for each node, the edges
+	// from it to higher ranked nodes.
+	up [][]chEdge
+	// down holds, for each node, the edges
+	// to it from higher ranked nodes.
+	down [][]chEdge
+
+	// mid holds the contracted node skipped
+	// by each shortcut edge.
+	mid map[[2]int]int
+}
+
+// chEdge is an edge in a contraction hierarchy. For up edges to is the
+// head of the edge and for down edges to is the tail.
+type chEdge struct {
+	to     int
+	weight float64
+}
+
+// BuildCH returns a contraction hierarchy for g. Nodes are contracted in an order
+// given by a lazily updated edge difference heuristic and shortcut edges are added
+// between the neighbors of each contracted node where needed to preserve shortest
+// path distances among the remaining nodes. Undirected edges are treated as a pair
+// of directed edges.
+//
+// The CH holds a snapshot of g and its weights; changes to g after BuildCH returns
+// are not reflected in the CH.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. BuildCH will panic if g has a negative edge weight.
+func BuildCH(g graph.Graph, weight Weighting) *CH {
+	weight = weightFor(g, weight)
+
+	nodes := graph.NodesOf(g.Nodes())
+	ch := &CH{
+		nodes:   nodes,
+		indexOf: make(map[int64]int, len(nodes)),
+		up:      make([][]chEdge, len(nodes)),
+		down:    make([][]chEdge, len(nodes)),
+		mid:     make(map[[2]int]int),
+	}
+	for i, n := range nodes {
+		ch.indexOf[n.ID()] = i
+	}
+
+	// out and in hold the edges of the graph
+	// over the nodes that remain uncontracted.
+	out := make([]map[int]float64, len(nodes))
+	in := make([]map[int]float64, len(nodes))
+	for i := range nodes {
+		out[i] = make(map[int]float64)
+		in[i] = make(map[int]float64)
+	}
+	for i, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			j := ch.indexOf[vid]
+			if i == j {
+				continue
+			}
+			w, ok := weight(uid, vid)
+			if !ok {
+				panic("path: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("path: negative edge weight")
+			}
+			if old, ok := out[i][j]; !ok || w < old {
+				out[i][j] = w
+				in[j][i] = w
+			}
+		}
+	}
+
+	c := contractor{out: out, in: in, deleted: make([]int, len(nodes)), mid: ch.mid}
+	queue := make(chQueue, len(nodes))
+	for i := range nodes {
+		queue[i] = chItem{index: i, priority: c.priority(i)}
+	}
+	heap.Init(&queue)
+	for queue.Len() != 0 {
+		v := heap.Pop(&queue).(chItem)
+		// Lazily update the priority and defer
+		// the node if it is no longer minimal.
+		p := c.priority(v.index)
+		if queue.Len() != 0 && p > queue[0].priority {
+			heap.Push(&queue, chItem{index: v.index, priority: p})
+			continue
+		}
+
+		for u, w := range out[v.index] {
+			ch.up[v.index] = append(ch.up[v.index], chEdge{to: u, weight: w})
+		}
+		for u, w := range in[v.index] {
+			ch.down[v.index] = append(ch.down[v.index], chEdge{to: u, weight: w})
+		}
+		c.contract(v.index)
+	}
+
+	return ch
+}
+
+// contractor holds the state for building a contraction hierarchy.
+type contractor struct {
+	out, in []map[int]float64
+	// deleted holds the number of
+	// contracted neighbors of a node.
+	deleted []int
+	// mid holds the contracted node
+	// skipped by each shortcut.
+	mid map[[2]int]int
+}
+
+// shortcuts calls fn for each shortcut that is needed if v is contracted,
+// returning the number of shortcuts.
+func (c *contractor) shortcuts(v int, fn func(u, w int, weight float64)) int {
+	var n int
+	for u, wu := range c.in[v] {
+		var max float64
+		for w, ww := range c.out[v] {
+			if w != u && wu+ww > max {
+				max = wu + ww
+			}
+		}
+		dist := c.witness(u, v, max)
+		for w, ww := range c.out[v] {
+			if w == u {
+				continue
+			}
+			if d, ok := dist[w]; ok && d <= wu+ww {
+				continue
+			}
+			n++
+			if fn != nil {
+				fn(u, w, wu+ww)
+			}
+		}
+	}
+	return n
+}
+
+// witness returns the distances from u to the uncontracted nodes within limit
+// found by a bounded Dijkstra search that does not pass through avoid.
+func (c *contractor) witness(u, avoid int, limit float64) map[int]float64 {
+	dist := map[int]float64{u: 0}
+	settled := make(map[int]bool)
+	queue := chQueue{{index: u}}
+	for queue.Len() != 0 && len(settled) < witnessSettleLimit {
+		n := heap.Pop(&queue).(chItem)
+		if settled[n.index] {
+			continue
+		}
+		if n.priority > limit {
+			break
+		}
+		settled[n.index] = true
+		for w, weight := range c.out[n.index] {
+			if w == avoid {
+				continue
+			}
+			d := n.priority + weight
+			if old, ok := dist[w]; !ok || d < old {
+				dist[w] = d
+				heap.Push(&queue, chItem{index: w, priority: d})
+			}
+		}
+	}
+	return dist
+}
+
+// priority returns the contraction priority of v. Nodes with lower priority
+// are contracted first.
+func (c *contractor) priority(v int) float64 {
+	return float64(c.shortcuts(v, nil)-len(c.in[v])-len(c.out[v])) + float64(c.deleted[v])
+}
+
+// contract removes v from the remaining graph, adding the required shortcuts.
+func (c *contractor) contract(v int) {
+	c.shortcuts(v, func(u, w int, weight float64) {
+		if old, ok := c.out[u][w]; !ok || weight < old {
+			c.out[u][w] = weight
+			c.in[w][u] = weight
+			c.mid[[2]int{u, w}] = v
+		}
+	})
+	for u := range c.in[v] {
+		delete(c.out[u], v)
+		c.deleted[u]++
+	}
+	for w := range c.out[v] {
+		delete(c.in[w], v)
+		c.deleted[w]++
+	}
+	c.out[v] = nil
+	c.in[v] = nil
+}
+
+// Query returns a shortest path from s to t and its cost using a bidirectional
+// search over the upward edges of the hierarchy. If t is not reachable from s, the
+// returned path is nil and the cost is +Inf.
+//
+// The returned path is a path in the hierarchy and may include shortcut edges that
+// skip over contracted nodes of the original graph. The path in the original graph
+// is obtained by passing the returned path to UnpackPath.
+func (ch *CH) Query(s, t graph.Node) ([]graph.Node, float64) {
+	si, ok := ch.indexOf[s.ID()]
+	if !ok {
+		return nil, math.Inf(1)
+	}
+	ti, ok := ch.indexOf[t.ID()]
+	if !ok {
+		return nil, math.Inf(1)
+	}
+
+	fwd := newCHSearch(si)
+	bwd := newCHSearch(ti)
+	best := math.Inf(1)
+	meet := -1
+	for fwd.queue.Len() != 0 || bwd.queue.Len() != 0 {
+		if fwd.min() >= best && bwd.min() >= best {
+			break
+		}
+		for _, dir := range []struct {
+			search, other *chSearch
+			edges         [][]chEdge
+		}{
+			{search: fwd, other: bwd, edges: ch.up},
+			{search: bwd, other: fwd, edges: ch.down},
+		} {
+			u, ok := dir.search.settle(dir.edges, best)
+			if !ok {
+				continue
+			}
+			if d, ok := dir.other.dist[u]; ok && dir.search.dist[u]+d < best {
+				best = dir.search.dist[u] + d
+				meet = u
+			}
+		}
+	}
+	if meet < 0 {
+		return nil, math.Inf(1)
+	}
+
+	var path []graph.Node
+	for i := meet; i != si; i = fwd.prev[i] {
+		path = append(path, ch.nodes[i])
+	}
+	path = append(path, ch.nodes[si])
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	for i := meet; i != ti; {
+		i = bwd.prev[i]
+		path = append(path, ch.nodes[i])
+	}
+	return path, best
+}
+
+// UnpackPath returns the path in the original graph corresponding to a path in
+// the hierarchy returned by Query, recursively expanding each shortcut edge into
+// the nodes it skips. UnpackPath will panic if path contains a node that is not
+// in the hierarchy.
+func (ch *CH) UnpackPath(path []graph.Node) []graph.Node {
+	if len(path) == 0 {
+		return nil
+	}
+	idx := make([]int, len(path))
+	for i, n := range path {
+		j, ok := ch.indexOf[n.ID()]
+		if !ok {
+			panic("path: node not in contraction hierarchy")
+		}
+		idx[i] = j
+	}
+	full := []graph.Node{ch.nodes[idx[0]]}
+	for i := 1; i < len(idx); i++ {
+		full = ch.unpackEdge(full, idx[i-1], idx[i])
+	}
+	return full
+}
+
+// unpackEdge appends the nodes after u on the original path from u to
+// w represented by the hierarchy edge from u to w to dst.
+func (ch *CH) unpackEdge(dst []graph.Node, u, w int) []graph.Node {
+	m, ok := ch.mid[[2]int{u, w}]
+	if !ok {
+		return append(dst, ch.nodes[w])
+	}
+	dst = ch.unpackEdge(dst, u, m)
+	return ch.unpackEdge(dst, m, w)
+}
+
+// chSearch is a single direction of a contraction hierarchy query.
+type chSearch struct {
+	dist    map[int]float64
+	prev    map[int]int
+	settled map[int]bool
+	queue   chQueue
+}
+
+func newCHSearch(from int) *chSearch {
+	return &chSearch{
+		dist:    map[int]float64{from: 0},
+		prev:    make(map[int]int),
+		settled: make(map[int]bool),
+		queue:   chQueue{{index: from}},
+	}
+}
+
+// min returns the lowest tentative distance in the queue.
+func (s *chSearch) min() float64 {
+	if s.queue.Len() == 0 {
+		return math.Inf(1)
+	}
+	return s.queue[0].priority
+}
+
+// settle settles the next node in the search, relaxing its edges, and returns
+// it. If the search is exhausted or cannot improve on best, ok is false.
+func (s *chSearch) settle(edges [][]chEdge, best float64) (u int, ok bool) {
+	for s.queue.Len() != 0 {
+		n := heap.Pop(&s.queue).(chItem)
+		if s.settled[n.index] {
+			continue
+		}
+		if n.priority >= best {
+			// Clear the queue since no later
+			// node can improve on best.
+			s.queue = s.queue[:0]
+			return -1, false
+		}
+		s.settled[n.index] = true
+		for _, e := range edges[n.index] {
+			d := n.priority + e.weight
+			if old, ok := s.dist[e.to]; !ok || d < old {
+				s.dist[e.to] = d
+				s.prev[e.to] = n.index
+				heap.Push(&s.queue, chItem{index: e.to, priority: d})
+			}
+		}
+		return n.index, true
+	}
+	return -1, false
+}
+
+type chItem struct {
+	index    int
+	priority float64
+}
+
+// chQueue implements a no-dec priority queue of chItems.
+type chQueue []chItem
+
+func (q chQueue) Len() int            { return len(q) }
+func (q chQueue) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q chQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *chQueue) Push(n interface{}) { *q = append(*q, n.(chItem)) }
+func (q *chQueue) Pop() interface{} {
+	t := *q
+	var n interface{}
+	n, *q = t[len(t)-1], t[:len(t)-1]
+	return n
+}
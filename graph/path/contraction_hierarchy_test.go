@@ -0,0 +1,186 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// randomWeightedGraph returns a weighted graph with n nodes where each
+// node has an edge to each of the following degree nodes modulo n, and
+// random additional edges, with random integer weights.
+func randomWeightedGraph(n, degree, extra int, directed bool, src rand.Source) graph.Weighted {
+	rnd := rand.New(src)
+	var g interface {
+		graph.Weighted
+		SetWeightedEdge(graph.WeightedEdge)
+	}
+	if directed {
+		g = simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	} else {
+		g = simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	}
+	set := func(u, v int) {
+		if u == v {
+			return
+		}
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(1 + rnd.Intn(10))})
+	}
+	for u := 0; u < n; u++ {
+		for d := 1; d <= degree; d++ {
+			set(u, (u+d)%n)
+		}
+	}
+	for i := 0; i < extra; i++ {
+		set(rnd.Intn(n), rnd.Intn(n))
+	}
+	return g
+}
+
+func TestCH(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		g    graph.Weighted
+	}{
+		{name: "undirected", g: randomWeightedGraph(100, 2, 100, false, rand.NewSource(1))},
+		{name: "directed", g: randomWeightedGraph(100, 1, 200, true, rand.NewSource(2))},
+		{name: "sparse directed", g: randomWeightedGraph(60, 0, 90, true, rand.NewSource(3))},
+		{name: "grid", g: randomWeightedGrid(10, randomIntWeight(rand.NewSource(4)))},
+	} {
+		ch := BuildCH(test.g, nil)
+		want := DijkstraAllPaths(test.g)
+		for _, s := range graph.NodesOf(test.g.Nodes()) {
+			for _, u := range graph.NodesOf(test.g.Nodes()) {
+				p, got := ch.Query(s, u)
+				if w := want.Weight(s.ID(), u.ID()); got != w {
+					t.Errorf("%s: unexpected query cost from %d to %d: got:%v want:%v", test.name, s.ID(), u.ID(), got, w)
+					continue
+				}
+				if math.IsInf(got, 1) {
+					if p != nil {
+						t.Errorf("%s: unexpected path for unreachable pair %d to %d: %v", test.name, s.ID(), u.ID(), p)
+					}
+					continue
+				}
+				if p[0].ID() != s.ID() || p[len(p)-1].ID() != u.ID() {
+					t.Errorf("%s: unexpected path end points from %d to %d: %v", test.name, s.ID(), u.ID(), ids(p))
+				}
+
+				full := ch.UnpackPath(p)
+				if !topo.IsPathIn(test.g, full) {
+					t.Errorf("%s: unpacked path from %d to %d is not a path in the graph: %v", test.name, s.ID(), u.ID(), ids(full))
+					continue
+				}
+				if w := weightOf(full, test.g.Weight); w != got {
+					t.Errorf("%s: unexpected unpacked path cost from %d to %d: got:%v want:%v", test.name, s.ID(), u.ID(), w, got)
+				}
+				for _, n := range p {
+					if !containsNode(full, n) {
+						t.Errorf("%s: unpacked path from %d to %d does not contain packed path node %d", test.name, s.ID(), u.ID(), n.ID())
+					}
+				}
+			}
+		}
+	}
+
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	ch := BuildCH(g, nil)
+	if p := ch.UnpackPath(nil); p != nil {
+		t.Errorf("unexpected unpacked empty path: %v", p)
+	}
+	if p, cost := ch.Query(simple.Node(0), simple.Node(2)); p != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected path to missing node: got:%v cost:%v", p, cost)
+	}
+}
+
+func TestCHUnpackPath(t *testing.T) {
+	// Real valued weights make shortest paths unique so the
+	// unpacked paths can be compared with the Dijkstra paths.
+	rnd := rand.New(rand.NewSource(5))
+	g := randomWeightedGrid(8, func() float64 { return 1 + rnd.Float64() })
+	ch := BuildCH(g, nil)
+	nodes := graph.NodesOf(g.Nodes())
+	for _, s := range nodes {
+		pt := DijkstraFrom(s, g)
+		for _, u := range nodes {
+			want, _ := pt.To(u.ID())
+			p, _ := ch.Query(s, u)
+			got := ch.UnpackPath(p)
+			for _, n := range want {
+				if !containsNode(got, n) {
+					t.Errorf("unpacked path from %d to %d does not contain Dijkstra path node %d:\ngot: %v\nwant:%v",
+						s.ID(), u.ID(), n.ID(), ids(got), ids(want))
+					break
+				}
+			}
+			if len(got) != len(want) {
+				t.Errorf("unexpected unpacked path length from %d to %d: got:%d want:%d", s.ID(), u.ID(), len(got), len(want))
+			}
+		}
+	}
+}
+
+func containsNode(path []graph.Node, n graph.Node) bool {
+	for _, u := range path {
+		if u.ID() == n.ID() {
+			return true
+		}
+	}
+	return false
+}
+
+// randomWeightedGrid returns an undirected n×n grid graph with edge
+// weights drawn from weight, resembling a simple road network.
+func randomWeightedGrid(n int, weight func() float64) graph.Weighted {
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			u := simple.Node(r*n + c)
+			if c+1 < n {
+				g.SetWeightedEdge(simple.WeightedEdge{F: u, T: simple.Node(r*n + c + 1), W: weight()})
+			}
+			if r+1 < n {
+				g.SetWeightedEdge(simple.WeightedEdge{F: u, T: simple.Node((r+1)*n + c), W: weight()})
+			}
+		}
+	}
+	return g
+}
+
+const chBenchSize = 40
+
+var chBenchGraph = randomWeightedGrid(chBenchSize, randomIntWeight(rand.NewSource(1)))
+
+// randomIntWeight returns a function returning random
+// integer weights in [1, 10].
+func randomIntWeight(src rand.Source) func() float64 {
+	rnd := rand.New(src)
+	return func() float64 { return float64(1 + rnd.Intn(10)) }
+}
+
+func BenchmarkCHQuery(b *testing.B) {
+	ch := BuildCH(chBenchGraph, nil)
+	rnd := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ch.Query(simple.Node(rnd.Intn(chBenchSize*chBenchSize)), simple.Node(rnd.Intn(chBenchSize*chBenchSize)))
+	}
+}
+
+func BenchmarkCHDijkstraQuery(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		pt := DijkstraFrom(simple.Node(rnd.Intn(chBenchSize*chBenchSize)), chBenchGraph)
+		pt.To(int64(rnd.Intn(chBenchSize * chBenchSize)))
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import "gonum.org/v1/gonum/graph"
+
+// PathToCoordinates returns the sequence of {lat, lon} coordinate pairs of the
+// nodes of path, in path order, where the coordinates of each node are given by
+// coordOf. The result is suitable for rendering as a GeoJSON LineString after
+// swapping each pair into GeoJSON's {lon, lat} order. If path is empty, the
+// returned slice is empty and not nil.
+func PathToCoordinates(path []graph.Node, coordOf func(graph.Node) (lat, lon float64)) [][2]float64 {
+	coords := make([][2]float64, len(path))
+	for i, n := range path {
+		lat, lon := coordOf(n)
+		coords[i] = [2]float64{lat, lon}
+	}
+	return coords
+}
@@ -0,0 +1,36 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestPathToCoordinates(t *testing.T) {
+	coords := map[int64][2]float64{
+		1: {51.5, -0.12},
+		2: {48.85, 2.35},
+		3: {52.52, 13.4},
+	}
+	coordOf := func(n graph.Node) (lat, lon float64) {
+		c := coords[n.ID()]
+		return c[0], c[1]
+	}
+
+	got := PathToCoordinates([]graph.Node{simple.Node(3), simple.Node(1), simple.Node(2)}, coordOf)
+	want := [][2]float64{coords[3], coords[1], coords[2]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected coordinates: got:%v want:%v", got, want)
+	}
+
+	got = PathToCoordinates(nil, coordOf)
+	if got == nil || len(got) != 0 {
+		t.Errorf("unexpected coordinates for empty path: got:%#v want:empty non-nil slice", got)
+	}
+}
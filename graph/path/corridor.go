@@ -0,0 +1,59 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// NearOptimalCorridor returns the nodes of g that lie on some path from s to t
+// whose cost is within slack of the cost of the shortest path, the nodes v for
+// which
+//
+//  d(s,v) + d(v,t) <= d(s,t) + slack.
+//
+// The distances from s and to t are found by forward and backward Dijkstra
+// searches. With a slack of zero the corridor holds exactly the nodes on shortest
+// paths from s to t, subject to floating point rounding of the path costs. The
+// returned nodes are sorted by ID. If t is not reachable from s, NearOptimalCorridor
+// returns nil.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted,
+// falling back to UniformCost otherwise. NearOptimalCorridor will panic if g has
+// an s- or t-reachable negative edge weight.
+func NearOptimalCorridor(s, t graph.Node, slack float64, g graph.Graph, weight Weighting) []graph.Node {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil
+	}
+	weight = weightFor(g, weight)
+
+	nodes := graph.NodesOf(g.Nodes())
+	fwd := newShortestFrom(s, nodes)
+	dijkstraFrom(s, g, weight, &fwd)
+	optimal := fwd.WeightTo(t.ID())
+	if math.IsInf(optimal, 1) {
+		return nil
+	}
+
+	bwd := newShortestFrom(t, nodes)
+	if d, ok := g.(graph.Directed); ok {
+		dijkstraFrom(t, reversedGraph{d}, reversedWeight(weight), &bwd)
+	} else {
+		dijkstraFrom(t, g, weight, &bwd)
+	}
+
+	var corridor []graph.Node
+	for _, v := range nodes {
+		if fwd.WeightTo(v.ID())+bwd.WeightTo(v.ID()) <= optimal+slack {
+			corridor = append(corridor, v)
+		}
+	}
+	sort.Sort(ordered.ByID(corridor))
+	return corridor
+}
@@ -0,0 +1,89 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestNearOptimalCorridor(t *testing.T) {
+	// Two shortest paths 0-1-3 and 0-2-3 of cost 2, a
+	// detour 0-4-3 of cost 3 and a spur 1-5 off the
+	// optimal paths. The edge 3-6 leads away from t.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(4), W: 1.5},
+		{F: simple.Node(4), T: simple.Node(3), W: 1.5},
+		{F: simple.Node(1), T: simple.Node(5), W: 0.5},
+		{F: simple.Node(5), T: simple.Node(1), W: 0.5},
+		{F: simple.Node(3), T: simple.Node(6), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	for _, test := range []struct {
+		slack float64
+		want  []int64
+	}{
+		{slack: 0, want: []int64{0, 1, 2, 3}},
+		{slack: 0.5, want: []int64{0, 1, 2, 3}},
+		{slack: 1, want: []int64{0, 1, 2, 3, 4, 5}},
+		{slack: 10, want: []int64{0, 1, 2, 3, 4, 5}},
+	} {
+		got := ids(NearOptimalCorridor(simple.Node(0), simple.Node(3), test.slack, g, nil))
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected corridor for slack=%v: got:%v want:%v", test.slack, got, test.want)
+		}
+	}
+
+	if got := NearOptimalCorridor(simple.Node(6), simple.Node(0), 1, g, nil); got != nil {
+		t.Errorf("unexpected corridor to unreachable node: %v", ids(got))
+	}
+}
+
+func TestNearOptimalCorridorZeroSlack(t *testing.T) {
+	const n = 50
+	src := rand.NewSource(1)
+	for _, directed := range []bool{true, false} {
+		for i := 0; i < 5; i++ {
+			g := randomWeightedGraph(n, 3, 2, directed, src)
+			s, tgt := simple.Node(0), simple.Node(n-1)
+			got := ids(NearOptimalCorridor(s, tgt, 0, g, nil))
+
+			// Collect the nodes on all shortest paths.
+			paths, _ := DijkstraAllPaths(g).AllBetween(s.ID(), tgt.ID())
+			on := make(map[int64]bool)
+			for _, p := range paths {
+				for _, v := range p {
+					on[v.ID()] = true
+				}
+			}
+			nodes := graph.NodesOf(g.Nodes())
+			sort.Sort(ordered.ByID(nodes))
+			var want []int64
+			for _, v := range nodes {
+				if on[v.ID()] {
+					want = append(want, v.ID())
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected zero slack corridor directed=%t: got:%v want:%v", directed, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/stat"
+)
+
+// CostSensitivity returns the mean and sample standard deviation of the cost of the
+// shortest path from s to t in g when edge costs are perturbed by noise. For each of
+// the given number of samples, the cost of each edge is multiplied by a factor drawn
+// uniformly from [1-perturbation, 1+perturbation] and the cost of the shortest path
+// under the perturbed costs is found by an A* search. Each edge receives a single
+// factor per sample; if g is undirected, both directions of an edge share the factor.
+// If t is not reachable from s, the returned mean and standard deviation are +Inf.
+//
+// The searches do not use a heuristic since a heuristic that is admissible for the
+// unperturbed costs is not necessarily admissible for the perturbed costs. Random
+// numbers are drawn from src, or the global rand source if src is nil; runs with
+// equivalent sources are reproducible. If weight is nil, the Weight method of g is
+// used if g implements Weighted, falling back to UniformCost otherwise.
+// CostSensitivity will panic if samples is less than one, if perturbation is not in
+// [0, 1] or if g has an A*-reachable negative edge weight.
+func CostSensitivity(s, t graph.Node, g graph.Graph, weight Weighting, perturbation float64, samples int, src rand.Source) (mean, std float64) {
+	if samples < 1 {
+		panic("path: invalid number of samples")
+	}
+	if perturbation < 0 || perturbation > 1 {
+		panic("path: invalid perturbation")
+	}
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return math.Inf(1), math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+	rnd := rand.Float64
+	if src != nil {
+		rnd = rand.New(src).Float64
+	}
+	_, undirected := g.(graph.Undirected)
+
+	// Collect the edges in a stable order so that the
+	// factors drawn from src are reproducible.
+	var edges [][2]int64
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	for _, u := range nodes {
+		uid := u.ID()
+		to := graph.NodesOf(g.From(uid))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			vid := v.ID()
+			if uid == vid || (undirected && vid < uid) {
+				continue
+			}
+			edges = append(edges, [2]int64{uid, vid})
+		}
+	}
+
+	costs := make([]float64, samples)
+	factor := make(map[[2]int64]float64, len(edges))
+	perturbed := func(xid, yid int64) (float64, bool) {
+		w, ok := weight(xid, yid)
+		if !ok || xid == yid {
+			return w, ok
+		}
+		if undirected && yid < xid {
+			xid, yid = yid, xid
+		}
+		return w * factor[[2]int64{xid, yid}], true
+	}
+	for i := range costs {
+		for _, e := range edges {
+			factor[e] = 1 + perturbation*(2*rnd()-1)
+		}
+		pt, _ := aStar(s, t, g, perturbed, NullHeuristic)
+		_, costs[i] = pt.To(t.ID())
+		if math.IsInf(costs[i], 1) {
+			return math.Inf(1), math.Inf(1)
+		}
+	}
+	if samples == 1 {
+		return costs[0], 0
+	}
+	return stat.MeanStdDev(costs, nil)
+}
@@ -0,0 +1,50 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestCostSensitivity(t *testing.T) {
+	g := randomWeightedGraph(100, 4, 100, false, rand.NewSource(1))
+	s, tn := simple.Node(0), simple.Node(99)
+	pt, _ := AStar(s, tn, g, nil)
+	_, want := pt.To(tn.ID())
+
+	mean, std := CostSensitivity(s, tn, g, nil, 0, 10, rand.NewSource(1))
+	if mean != want {
+		t.Errorf("unexpected mean cost with zero perturbation: got:%v want:%v", mean, want)
+	}
+	if std != 0 {
+		t.Errorf("unexpected standard deviation with zero perturbation: got:%v want:0", std)
+	}
+
+	mean, std = CostSensitivity(s, tn, g, nil, 0.5, 50, rand.NewSource(1))
+	if std <= 0 {
+		t.Errorf("unexpected standard deviation with perturbation: got:%v want:>0", std)
+	}
+	// The perturbed optimum is no more than the perturbed cost of the
+	// unperturbed shortest path, whose expectation is want. It is also
+	// no less than the unperturbed optimum scaled by the minimum factor.
+	if mean > want*1.1 || mean < want*0.5 {
+		t.Errorf("unexpected mean cost with perturbation: got:%v want in [%v, %v]", mean, want*0.5, want*1.1)
+	}
+
+	again, _ := CostSensitivity(s, tn, g, nil, 0.5, 50, rand.NewSource(1))
+	if again != mean {
+		t.Errorf("unexpected mean for equivalent source: got:%v want:%v", again, mean)
+	}
+
+	mean, std = CostSensitivity(s, simple.Node(-1), g, nil, 0.5, 10, nil)
+	if !math.IsInf(mean, 1) || !math.IsInf(std, 1) {
+		t.Errorf("unexpected result for absent target: got:%v,%v want:+Inf,+Inf", mean, std)
+	}
+}
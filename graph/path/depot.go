@@ -0,0 +1,66 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// DepotRouter holds the shortest paths from every node of a graph to a single
+// goal node. The shortest path tree towards the goal is computed once when the
+// router is created, so each query follows the tree without searching. This is
+// much faster than a search per query when many paths to the same destination
+// are needed.
+//
+// DepotRouter assumes that the graph and edge weights do not change; if they
+// do, a new DepotRouter must be created.
+type DepotRouter struct {
+	goal graph.Node
+
+	// tree holds the shortest paths from the goal
+	// in the reverse of the graph, or nil if the
+	// goal is not in the graph.
+	tree *Shortest
+}
+
+// NewDepotRouter returns a DepotRouter for the paths to goal in g. The construction
+// performs one Dijkstra search from goal over the reversed edges of g.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. NewDepotRouter will panic if g has a goal-reaching
+// negative edge weight.
+func NewDepotRouter(goal graph.Node, g graph.Graph, weight Weighting) *DepotRouter {
+	if g.Node(goal.ID()) == nil {
+		return &DepotRouter{goal: goal}
+	}
+	weight = weightFor(g, weight)
+	tree := newShortestFrom(goal, graph.NodesOf(g.Nodes()))
+	if d, ok := g.(graph.Directed); ok {
+		dijkstraFrom(goal, reversedGraph{d}, reversedWeight(weight), &tree)
+	} else {
+		dijkstraFrom(goal, g, weight, &tree)
+	}
+	return &DepotRouter{goal: goal, tree: &tree}
+}
+
+// Goal returns the goal node of the router.
+func (r *DepotRouter) Goal() graph.Node { return r.goal }
+
+// PathFrom returns the shortest path from s to the goal and its cost. If the goal
+// is not reachable from s, the path is nil and the cost is +Inf.
+func (r *DepotRouter) PathFrom(s graph.Node) ([]graph.Node, float64) {
+	if r.tree == nil {
+		return nil, math.Inf(1)
+	}
+	path, weight := r.tree.To(s.ID())
+	if path == nil {
+		return nil, math.Inf(1)
+	}
+	ordered.Reverse(path)
+	return path, weight
+}
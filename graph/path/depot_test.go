@@ -0,0 +1,58 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestDepotRouter(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		g := randomWeightedGraph(100, 3, 50, directed, rand.NewSource(1))
+		goal := simple.Node(7)
+		r := NewDepotRouter(goal, g, nil)
+		if r.Goal().ID() != goal.ID() {
+			t.Errorf("unexpected goal: got:%d want:%d", r.Goal().ID(), goal.ID())
+		}
+		for _, n := range graph.NodesOf(g.Nodes()) {
+			p, cost := r.PathFrom(n)
+			pt := DijkstraFrom(n, g)
+			_, want := pt.To(goal.ID())
+			if math.IsInf(want, 1) {
+				if p != nil || !math.IsInf(cost, 1) {
+					t.Errorf("unexpected path from %d in directed=%t: got:%v %v want:[] +Inf", n.ID(), directed, ids(p), cost)
+				}
+				continue
+			}
+			if math.Abs(cost-want) > 1e-9 {
+				t.Errorf("unexpected cost from %d in directed=%t: got:%v want:%v", n.ID(), directed, cost, want)
+			}
+			if p[0].ID() != n.ID() || p[len(p)-1].ID() != goal.ID() {
+				t.Errorf("unexpected path end points from %d in directed=%t: %v", n.ID(), directed, ids(p))
+			}
+			for i, v := range p[1:] {
+				if g.Edge(p[i].ID(), v.ID()) == nil {
+					t.Errorf("path from %d in directed=%t has missing edge %d->%d", n.ID(), directed, p[i].ID(), v.ID())
+				}
+			}
+			if w := weightOf(p, g.Weight); math.Abs(w-cost) > 1e-9 {
+				t.Errorf("path weight mismatch from %d in directed=%t: got:%v want:%v", n.ID(), directed, w, cost)
+			}
+		}
+	}
+
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	r := NewDepotRouter(simple.Node(2), g, nil)
+	if p, cost := r.PathFrom(simple.Node(0)); p != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected path to absent goal: got:%v %v want:[] +Inf", ids(p), cost)
+	}
+}
@@ -6,6 +6,7 @@ package path
 
 import (
 	"container/heap"
+	"math"
 
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/traverse"
@@ -39,7 +40,16 @@ func DijkstraFrom(u graph.Node, g traverse.Graph) Shortest {
 	} else {
 		weight = UniformCost(g)
 	}
+	dijkstraFrom(u, g, weight, &path)
 
+	return path
+}
+
+// dijkstraFrom is the single-source implementation of Dijkstra. It is shared
+// between DijkstraFrom and functions that use a caller-provided Weighting. It
+// returns nothing, but stores the result of the work in the path parameter
+// which must have been initialised with the node u.
+func dijkstraFrom(u graph.Node, g traverse.Graph, weight Weighting, path *Shortest) {
 	// Dijkstra's algorithm here is implemented essentially as
 	// described in Function B.2 in figure 6 of UTCS Technical
 	// Report TR-07-54.
@@ -60,10 +70,6 @@ func DijkstraFrom(u graph.Node, g traverse.Graph) Shortest {
 		mnid := mid.node.ID()
 		for _, v := range graph.NodesOf(g.From(mnid)) {
 			vid := v.ID()
-			j, ok := path.indexOf[vid]
-			if !ok {
-				j = path.add(v)
-			}
 			w, ok := weight(mnid, vid)
 			if !ok {
 				panic("dijkstra: unexpected invalid weight")
@@ -71,6 +77,15 @@ func DijkstraFrom(u graph.Node, g traverse.Graph) Shortest {
 			if w < 0 {
 				panic("dijkstra: negative edge weight")
 			}
+			if math.IsInf(w, 1) {
+				// Edges with infinite weight are
+				// treated as absent.
+				continue
+			}
+			j, ok := path.indexOf[vid]
+			if !ok {
+				j = path.add(v)
+			}
 			joint := path.dist[k] + w
 			if joint < path.dist[j] {
 				heap.Push(&Q, distanceNode{node: v, dist: joint})
@@ -78,8 +93,6 @@ func DijkstraFrom(u graph.Node, g traverse.Graph) Shortest {
 			}
 		}
 	}
-
-	return path
 }
 
 // DijkstraAllPaths returns a shortest-path tree for shortest paths in the graph g.
@@ -133,6 +146,9 @@ func dijkstraAllPaths(g graph.Graph, paths AllShortest) {
 				if w < 0 {
 					panic("dijkstra: negative edge weight")
 				}
+				if math.IsInf(w, 1) {
+					continue
+				}
 				joint := paths.dist.At(i, k) + w
 				if joint < paths.dist.At(i, j) {
 					heap.Push(&Q, distanceNode{node: v, dist: joint})
@@ -0,0 +1,74 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// DijkstraArray returns a shortest-path tree from s to all nodes in g, with node IDs
+// in [0, maxID], held as flat slices indexed by node ID. The parent of each node on
+// its shortest path from s is held in parent. A parent of -1 indicates that the node
+// is s, is unreachable from s or is not in g, and the distance of unreachable nodes
+// is +Inf. If s is not in g, all parents are -1 and all distances are +Inf.
+//
+// DijkstraArray avoids the map overhead of DijkstraFrom, so it is suited to graphs
+// with dense contiguous ID spaces. It will panic if g has a node reachable from s
+// with an ID outside [0, maxID].
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. DijkstraArray will panic if g has an s-reachable
+// negative edge weight.
+func DijkstraArray(s graph.Node, g graph.Graph, weight Weighting, maxID int64) (parent []int64, dist []float64) {
+	parent = make([]int64, maxID+1)
+	dist = make([]float64, maxID+1)
+	for i := range parent {
+		parent[i] = -1
+		dist[i] = math.Inf(1)
+	}
+	sid := s.ID()
+	if g.Node(sid) == nil {
+		return parent, dist
+	}
+	if sid < 0 || sid > maxID {
+		panic("path: node ID out of range")
+	}
+	weight = weightFor(g, weight)
+
+	dist[sid] = 0
+	Q := priorityQueue{{node: s, dist: 0}}
+	for Q.Len() != 0 {
+		mid := heap.Pop(&Q).(distanceNode)
+		mnid := mid.node.ID()
+		if mid.dist > dist[mnid] {
+			continue
+		}
+		to := g.From(mnid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if vid < 0 || vid > maxID {
+				panic("path: node ID out of range")
+			}
+			w, ok := weight(mnid, vid)
+			if !ok {
+				panic("dijkstra: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("dijkstra: negative edge weight")
+			}
+			joint := mid.dist + w
+			if joint < dist[vid] {
+				heap.Push(&Q, distanceNode{node: v, dist: joint})
+				dist[vid] = joint
+				parent[vid] = mnid
+			}
+		}
+	}
+	return parent, dist
+}
@@ -0,0 +1,84 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestDijkstraArray(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		g    graph.Weighted
+	}{
+		{name: "undirected", g: randomWeightedGraph(100, 2, 100, false, rand.NewSource(1))},
+		{name: "directed", g: randomWeightedGraph(100, 1, 200, true, rand.NewSource(2))},
+		{name: "sparse directed", g: randomWeightedGraph(60, 0, 60, true, rand.NewSource(3))},
+	} {
+		const maxID = 99
+		for _, s := range []int64{0, 7, 42} {
+			parent, dist := DijkstraArray(simple.Node(s), test.g, nil, maxID)
+			if len(parent) != maxID+1 || len(dist) != maxID+1 {
+				t.Fatalf("%s: unexpected result lengths: got:%d,%d want:%d", test.name, len(parent), len(dist), maxID+1)
+			}
+			if test.g.Node(s) == nil {
+				continue
+			}
+			want := DijkstraFrom(simple.Node(s), test.g)
+			for id := int64(0); id <= maxID; id++ {
+				_, d := want.To(id)
+				if test.g.Node(id) == nil {
+					d = math.Inf(1)
+				}
+				if dist[id] != d {
+					t.Errorf("%s: unexpected distance from %d to %d: got:%v want:%v", test.name, s, id, dist[id], d)
+				}
+				switch {
+				case id == s || math.IsInf(d, 1):
+					if parent[id] != -1 {
+						t.Errorf("%s: unexpected parent of %d from %d: got:%d want:-1", test.name, id, s, parent[id])
+					}
+				default:
+					p := parent[id]
+					if p < 0 {
+						t.Errorf("%s: missing parent of reachable node %d from %d", test.name, id, s)
+						continue
+					}
+					w, _ := test.g.Weight(p, id)
+					if dist[p]+w != dist[id] {
+						t.Errorf("%s: parent %d of %d from %d is not on a shortest path", test.name, p, id, s)
+					}
+				}
+			}
+		}
+	}
+
+	parent, dist := DijkstraArray(simple.Node(5), simple.NewDirectedGraph(), nil, 3)
+	for i := range parent {
+		if parent[i] != -1 || !math.IsInf(dist[i], 1) {
+			t.Errorf("unexpected result for missing start node at %d: parent=%d dist=%v", i, parent[i], dist[i])
+		}
+	}
+}
+
+var dijkstraArrayBenchGraph = randomWeightedGraph(10000, 2, 20000, true, rand.NewSource(1))
+
+func BenchmarkDijkstraArray(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		DijkstraArray(simple.Node(0), dijkstraArrayBenchGraph, nil, 9999)
+	}
+}
+
+func BenchmarkDijkstraFromMap(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		DijkstraFrom(simple.Node(0), dijkstraArrayBenchGraph)
+	}
+}
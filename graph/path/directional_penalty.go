@@ -0,0 +1,43 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// DirectionalPenaltyAStar finds the A*-shortest path from s to t in g where traversing
+// an edge from a node u to a node v costs the edge weight plus penalty(u, v). This
+// allows each direction of an edge in an undirected graph to have a different cost.
+// The path and its penalised cost are returned. If no path exists, the path is nil and
+// the cost is +Inf.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, DirectionalPenaltyAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to NullHeuristic
+// otherwise. The path will be the shortest path if h is admissible with respect to the
+// penalised weights; a heuristic admissible for the unpenalised weights remains so for
+// non-negative penalties. DirectionalPenaltyAStar will panic if a penalised edge weight
+// is negative.
+func DirectionalPenaltyAStar(s, t graph.Node, g graph.Graph, weight Weighting, penalty func(from, to graph.Node) float64, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+	if penalty != nil {
+		base := weight
+		weight = func(xid, yid int64) (float64, bool) {
+			w, ok := base(xid, yid)
+			if !ok || xid == yid {
+				return w, ok
+			}
+			return w + penalty(g.Node(xid), g.Node(yid)), true
+		}
+	}
+	pt, _ := aStar(s, t, g, weight, heuristicFor(g, h))
+	return pt.To(t.ID())
+}
@@ -0,0 +1,74 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestDirectionalPenaltyAStar(t *testing.T) {
+	// 0 and 3 are joined by two equal cost routes, via 1 and via 2,
+	// so the path taken in each direction is decided by the penalty.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	// Travelling uphill from 0 towards 1 and from 3 towards 2 is penalised.
+	uphill := map[[2]int64]float64{
+		{0, 1}: 2,
+		{3, 2}: 2,
+	}
+	penalty := func(from, to graph.Node) float64 {
+		return uphill[[2]int64{from.ID(), to.ID()}]
+	}
+
+	out, outCost := DirectionalPenaltyAStar(simple.Node(0), simple.Node(3), g, nil, penalty, nil)
+	back, backCost := DirectionalPenaltyAStar(simple.Node(3), simple.Node(0), g, nil, penalty, nil)
+
+	wantOut := []int64{0, 2, 3}
+	wantBack := []int64{3, 1, 0}
+	if got := ids(out); !reflect.DeepEqual(got, wantOut) {
+		t.Errorf("unexpected outbound path: got:%v want:%v", got, wantOut)
+	}
+	if outCost != 2 {
+		t.Errorf("unexpected outbound cost: got:%v want:2", outCost)
+	}
+	if got := ids(back); !reflect.DeepEqual(got, wantBack) {
+		t.Errorf("unexpected return path: got:%v want:%v", got, wantBack)
+	}
+	if backCost != 2 {
+		t.Errorf("unexpected return cost: got:%v want:2", backCost)
+	}
+
+	// Each route is penalised in one direction only, so
+	// the shortest route in each direction is unpenalised.
+	_, plainCost := DirectionalPenaltyAStar(simple.Node(0), simple.Node(3), g, nil, nil, nil)
+	if plainCost != outCost {
+		t.Errorf("unexpected unpenalised cost: got:%v want:%v", plainCost, outCost)
+	}
+}
+
+// ids returns the IDs of the nodes in path.
+func ids(path []graph.Node) []int64 {
+	if path == nil {
+		return nil
+	}
+	id := make([]int64, len(path))
+	for i, n := range path {
+		id[i] = n.ID()
+	}
+	return id
+}
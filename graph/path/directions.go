@@ -0,0 +1,86 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import "gonum.org/v1/gonum/graph"
+
+// Turn is the change in heading at the start of a segment of a path.
+type Turn int
+
+const (
+	// Depart is the turn of the first segment of a path.
+	Depart Turn = iota
+
+	// Straight indicates the heading is unchanged.
+	Straight
+
+	// Left indicates the heading turns anticlockwise.
+	Left
+
+	// Right indicates the heading turns clockwise.
+	Right
+
+	// UTurn indicates the heading is reversed.
+	UTurn
+)
+
+// Direction is a single step of a set of directions along a path.
+type Direction struct {
+	// From and To are the nodes at the
+	// start and end of the segment.
+	From, To graph.Node
+
+	// Cost is the cost of the segment.
+	Cost float64
+
+	// Turn is the turn onto the segment
+	// from the previous segment.
+	Turn Turn
+}
+
+// TurnByTurn returns the directions for following path in g, with one Direction
+// for each edge of the path. The heading of the edge from u to v is given by
+// directionOf(u, v) in degrees measured clockwise, and the turn onto each segment
+// is determined by the change in heading from the previous segment, modulo 360.
+// A change of zero is Straight, a change of 180 is a UTurn, changes of less than
+// 180 are Right turns and changes of more than 180 are Left turns. If path has
+// fewer than two nodes, TurnByTurn returns nil.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. TurnByTurn will panic if an edge of path is not in
+// g.
+func TurnByTurn(path []graph.Node, g graph.Graph, weight Weighting, directionOf func(from, to graph.Node) int) []Direction {
+	if len(path) < 2 {
+		return nil
+	}
+	weight = weightFor(g, weight)
+
+	directions := make([]Direction, len(path)-1)
+	var last int
+	for i := range directions {
+		u, v := path[i], path[i+1]
+		w, ok := weight(u.ID(), v.ID())
+		if !ok {
+			panic("path: edge not in graph")
+		}
+		heading := directionOf(u, v)
+		turn := Depart
+		if i != 0 {
+			switch change := ((heading-last)%360 + 360) % 360; {
+			case change == 0:
+				turn = Straight
+			case change == 180:
+				turn = UTurn
+			case change < 180:
+				turn = Right
+			default:
+				turn = Left
+			}
+		}
+		directions[i] = Direction{From: u, To: v, Cost: w, Turn: turn}
+		last = heading
+	}
+	return directions
+}
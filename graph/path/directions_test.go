@@ -0,0 +1,106 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestTurnByTurn(t *testing.T) {
+	// Nodes are laid out on a 4×4 grid with node ID r*4+c
+	// and edge weights of 1 horizontally and 2 vertically.
+	const n = 4
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			u := simple.Node(r*n + c)
+			if c+1 < n {
+				g.SetWeightedEdge(simple.WeightedEdge{F: u, T: simple.Node(r*n + c + 1), W: 1})
+			}
+			if r+1 < n {
+				g.SetWeightedEdge(simple.WeightedEdge{F: u, T: simple.Node((r+1)*n + c), W: 2})
+			}
+		}
+	}
+	// heading returns the compass heading of the step from
+	// u to v with north towards decreasing row numbers.
+	heading := func(u, v graph.Node) int {
+		dr := v.ID()/n - u.ID()/n
+		dc := v.ID()%n - u.ID()%n
+		switch {
+		case dr < 0:
+			return 0
+		case dc > 0:
+			return 90
+		case dr > 0:
+			return 180
+		default:
+			return 270
+		}
+	}
+
+	for _, test := range []struct {
+		name      string
+		path      []int64
+		wantTurns []Turn
+		wantCosts []float64
+	}{
+		{
+			name:      "straight",
+			path:      []int64{0, 1, 2, 3},
+			wantTurns: []Turn{Depart, Straight, Straight},
+			wantCosts: []float64{1, 1, 1},
+		},
+		{
+			name:      "zig-zag",
+			path:      []int64{0, 1, 5, 6, 10, 11},
+			wantTurns: []Turn{Depart, Right, Left, Right, Left},
+			wantCosts: []float64{1, 2, 1, 2, 1},
+		},
+		{
+			name:      "wrap",
+			path:      []int64{4, 0, 1},
+			wantTurns: []Turn{Depart, Right},
+			wantCosts: []float64{2, 1},
+		},
+		{
+			name:      "u-turn",
+			path:      []int64{0, 1, 0},
+			wantTurns: []Turn{Depart, UTurn},
+			wantCosts: []float64{1, 1},
+		},
+		{
+			name: "single node",
+			path: []int64{0},
+		},
+	} {
+		var path []graph.Node
+		for _, id := range test.path {
+			path = append(path, simple.Node(id))
+		}
+		directions := TurnByTurn(path, g, nil, heading)
+		var (
+			turns []Turn
+			costs []float64
+		)
+		for i, d := range directions {
+			if d.From.ID() != test.path[i] || d.To.ID() != test.path[i+1] {
+				t.Errorf("%s: unexpected segment %d: got:%d->%d want:%d->%d",
+					test.name, i, d.From.ID(), d.To.ID(), test.path[i], test.path[i+1])
+			}
+			turns = append(turns, d.Turn)
+			costs = append(costs, d.Cost)
+		}
+		if !reflect.DeepEqual(turns, test.wantTurns) || !reflect.DeepEqual(costs, test.wantCosts) {
+			t.Errorf("%s: unexpected directions: got turns:%v costs:%v want turns:%v costs:%v",
+				test.name, turns, costs, test.wantTurns, test.wantCosts)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/stat"
+)
+
+// DistanceCorrelation returns the Pearson correlation coefficient between the
+// shortest path distances in a and b over the node pairs in pairs. A value near
+// one indicates that b preserves the distance structure of a, so it can be used
+// to assess spanners and contracted or sparsified graphs. Pairs that are
+// unreachable in either graph are excluded. If fewer than two pairs remain, or
+// the distances in either graph do not vary, the result is NaN.
+//
+// If weight is nil, the Weight method of each graph is used if it implements
+// Weighted, falling back to UniformCost otherwise; otherwise weight is used for
+// both graphs. DistanceCorrelation will panic if a or b has a reachable negative
+// edge weight.
+func DistanceCorrelation(a, b graph.Graph, weight Weighting, pairs [][2]graph.Node) float64 {
+	da := pairDistances(a, weight, pairs)
+	db := pairDistances(b, weight, pairs)
+	var x, y []float64
+	for i := range pairs {
+		if math.IsInf(da[i], 1) || math.IsInf(db[i], 1) {
+			continue
+		}
+		x = append(x, da[i])
+		y = append(y, db[i])
+	}
+	if len(x) < 2 {
+		return math.NaN()
+	}
+	return stat.Correlation(x, y, nil)
+}
+
+// pairDistances returns the shortest path distances in g between each of the
+// node pairs, running a single Dijkstra search for each distinct source node.
+func pairDistances(g graph.Graph, weight Weighting, pairs [][2]graph.Node) []float64 {
+	weight = weightFor(g, weight)
+	nodes := graph.NodesOf(g.Nodes())
+	trees := make(map[int64]Shortest)
+	dist := make([]float64, len(pairs))
+	for i, p := range pairs {
+		if g.Node(p[0].ID()) == nil || g.Node(p[1].ID()) == nil {
+			dist[i] = math.Inf(1)
+			continue
+		}
+		path, ok := trees[p[0].ID()]
+		if !ok {
+			path = newShortestFrom(p[0], nodes)
+			dijkstraFrom(p[0], g, weight, &path)
+			trees[p[0].ID()] = path
+		}
+		dist[i] = path.WeightTo(p[1].ID())
+	}
+	return dist
+}
@@ -0,0 +1,49 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestDistanceCorrelation(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	g := randomWeightedGraph(60, 2, 60, false, rand.NewSource(1))
+	var pairs [][2]graph.Node
+	for i := 0; i < 100; i++ {
+		pairs = append(pairs, [2]graph.Node{simple.Node(rnd.Intn(60)), simple.Node(rnd.Intn(60))})
+	}
+
+	if got := DistanceCorrelation(g, g, nil, pairs); math.Abs(got-1) > 1e-12 {
+		t.Errorf("unexpected correlation for identical graphs: got:%v want:1", got)
+	}
+
+	// Scaling all weights preserves the correlation.
+	scaled := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	edges := g.(*simple.WeightedUndirectedGraph).WeightedEdges()
+	for edges.Next() {
+		e := edges.WeightedEdge()
+		scaled.SetWeightedEdge(simple.WeightedEdge{F: e.From(), T: e.To(), W: 2 * e.Weight()})
+	}
+	if got := DistanceCorrelation(g, scaled, nil, pairs); math.Abs(got-1) > 1e-12 {
+		t.Errorf("unexpected correlation for scaled graph: got:%v want:1", got)
+	}
+
+	// An unrelated graph has a much lower correlation.
+	other := randomWeightedGraph(60, 2, 60, false, rand.NewSource(2))
+	if got := DistanceCorrelation(g, other, nil, pairs); got > 0.9 {
+		t.Errorf("unexpectedly high correlation for unrelated graphs: got:%v", got)
+	}
+
+	if got := DistanceCorrelation(g, g, nil, pairs[:1]); !math.IsNaN(got) {
+		t.Errorf("unexpected correlation for single pair: got:%v want:NaN", got)
+	}
+}
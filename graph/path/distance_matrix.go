@@ -0,0 +1,37 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import "gonum.org/v1/gonum/graph"
+
+// DistanceMatrix returns the shortest path distances between all pairs of nodes
+// in g as a flat row-major n×n matrix. The distance from the node with index i to
+// the node with index j is held in data[i*n+j], and index maps node IDs to their
+// index. Unreachable pairs are given a distance of +Inf.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted,
+// falling back to UniformCost otherwise. DistanceMatrix will panic if g has a
+// negative edge weight.
+//
+// The time complexity of DistanceMatrix is O(|V|.|E|.log|V|).
+func DistanceMatrix(g graph.Graph, weight Weighting) (data []float64, index map[int64]int, n int) {
+	weight = weightFor(g, weight)
+
+	nodes := graph.NodesOf(g.Nodes())
+	n = len(nodes)
+	index = make(map[int64]int, n)
+	for i, u := range nodes {
+		index[u.ID()] = i
+	}
+	data = make([]float64, n*n)
+	for i, u := range nodes {
+		path := newShortestFrom(u, nodes)
+		dijkstraFrom(u, g, weight, &path)
+		for j, v := range nodes {
+			data[i*n+j] = path.dist[path.indexOf[v.ID()]]
+		}
+	}
+	return data, index, n
+}
@@ -0,0 +1,67 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+)
+
+func TestDistanceMatrix(t *testing.T) {
+	for _, test := range testgraphs.ShortestPathTests {
+		if test.HasNegativeWeight {
+			continue
+		}
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetWeightedEdge(e)
+		}
+		gg := g.(graph.Graph)
+
+		for _, weight := range []struct {
+			name string
+			fn   Weighting
+		}{
+			{name: "graph", fn: nil},
+			{name: "uniform", fn: UniformCost(gg)},
+		} {
+			data, index, n := DistanceMatrix(gg, weight.fn)
+			nodes := graph.NodesOf(gg.Nodes())
+			if n != len(nodes) {
+				t.Errorf("%q %s: unexpected matrix size: got:%d want:%d", test.Name, weight.name, n, len(nodes))
+			}
+			if len(data) != n*n {
+				t.Errorf("%q %s: unexpected data length: got:%d want:%d", test.Name, weight.name, len(data), n*n)
+			}
+			for _, u := range nodes {
+				var pt Shortest
+				if weight.fn == nil {
+					pt = DijkstraFrom(u, gg)
+				} else {
+					pt = DijkstraFrom(u, unitWeighted{gg})
+				}
+				for _, v := range nodes {
+					got := data[index[u.ID()]*n+index[v.ID()]]
+					want := pt.WeightTo(v.ID())
+					if got != want {
+						t.Errorf("%q %s: unexpected distance from %d to %d: got:%v want:%v",
+							test.Name, weight.name, u.ID(), v.ID(), got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+// unitWeighted is a graph.Graph that uses UniformCost for its Weight method.
+type unitWeighted struct {
+	graph.Graph
+}
+
+func (g unitWeighted) Weight(xid, yid int64) (w float64, ok bool) {
+	return UniformCost(g.Graph)(xid, yid)
+}
@@ -0,0 +1,266 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// DistanceOracle answers approximate shortest path distance queries on an undirected
+// graph using the distance oracle of Thorup and Zwick. For a parameter k, the oracle
+// samples a hierarchy of k nested sets of nodes, A₀ ⊇ A₁ ⊇ … ⊇ Aₖ₋₁, where A₀ holds all
+// the nodes and each node of Aᵢ₋₁ is retained in Aᵢ with probability n^(-1/k) for a
+// graph with n nodes. Each node v stores the distance to its nearest node in each set
+// and the distances to the nodes of its bunch, the nodes w in Aᵢ but not Aᵢ₊₁ that
+// are closer to v than any node of Aᵢ₊₁.
+//
+// A query takes O(k) time and returns a distance that is at least the true distance
+// and at most 2k-1 times it. The oracle has an expected size of O(k·n^(1+1/k)), so
+// increasing k reduces the space required at the cost of a larger stretch; with k=1
+// the oracle holds the exact distances between all pairs of nodes.
+//
+// DistanceOracle assumes that the graph and edge weights do not change; if they do,
+// a new DistanceOracle must be created.
+//
+// See Thorup and Zwick doi:10.1145/1044731.1044732 for details.
+type DistanceOracle struct {
+	k       int
+	indexOf map[int64]int
+
+	// pivot[i][v] is the index of the nearest node in
+	// Aᵢ to node v, or -1 if no node of Aᵢ is reachable,
+	// and pivotDist[i][v] is the distance to it.
+	pivot     [][]int
+	pivotDist [][]float64
+
+	// bunch[v] holds the distances from node v
+	// to the nodes of its bunch.
+	bunch []map[int]float64
+}
+
+// NewDistanceOracle returns a DistanceOracle for the undirected graph g with the
+// stretch parameter k. The construction performs k multiple-source Dijkstra
+// searches to find the nearest node of each set and a pruned Dijkstra search
+// from each node to find the bunches.
+//
+// Random numbers are drawn from src, or the global rand source if src is nil;
+// constructions with equivalent sources are reproducible. If weight is nil, the
+// Weight method of g is used if g implements Weighted, falling back to UniformCost
+// otherwise. Edges with infinite weight are treated as absent. NewDistanceOracle
+// will panic if k is not positive or if g has a negative edge weight.
+func NewDistanceOracle(g graph.Undirected, weight Weighting, k int, src rand.Source) *DistanceOracle {
+	if k <= 0 {
+		panic("path: non-positive stretch parameter")
+	}
+	weight = weightFor(g, weight)
+	rnd := rand.Float64
+	if src != nil {
+		rnd = rand.New(src).Float64
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	o := &DistanceOracle{
+		k:         k,
+		indexOf:   make(map[int64]int, len(nodes)),
+		pivot:     make([][]int, k),
+		pivotDist: make([][]float64, k),
+		bunch:     make([]map[int]float64, len(nodes)),
+	}
+	for i, n := range nodes {
+		o.indexOf[n.ID()] = i
+		o.bunch[i] = make(map[int]float64)
+	}
+	if len(nodes) == 0 {
+		return o
+	}
+
+	// level[v] is the index of the
+	// last set that holds node v.
+	level := make([]int, len(nodes))
+	p := math.Pow(float64(len(nodes)), -1/float64(k))
+	for {
+		var top int
+		for v := range level {
+			level[v] = 0
+			for level[v] < k-1 && rnd() < p {
+				level[v]++
+			}
+			if level[v] == k-1 {
+				top++
+			}
+		}
+		// The last set must not be empty.
+		if top != 0 {
+			break
+		}
+	}
+
+	s := oracleSearch{g: g, weight: weight, nodes: nodes, indexOf: o.indexOf}
+	for i := 0; i < k; i++ {
+		var sources []int
+		for v, l := range level {
+			if l >= i {
+				sources = append(sources, v)
+			}
+		}
+		o.pivotDist[i], o.pivot[i] = s.nearest(sources)
+	}
+
+	// The cluster of each node w in Aᵢ but not Aᵢ₊₁ holds
+	// the nodes with w in their bunch. Clusters are found
+	// by Dijkstra searches from w that only enter nodes
+	// closer to w than to any node of Aᵢ₊₁.
+	for w, i := range level {
+		var bound []float64
+		if i+1 < k {
+			bound = o.pivotDist[i+1]
+		}
+		for v, d := range s.cluster(w, bound) {
+			o.bunch[v][w] = d
+		}
+	}
+	return o
+}
+
+// Distance returns an estimate of the shortest path distance between u and v that
+// is at least the true distance and at most 2k-1 times it. The query of Thorup and
+// Zwick is made from both u and v, and the smaller estimate is returned, so the
+// returned distance is symmetric in u and v. If u and v are not connected or either
+// is not in the graph, Distance returns +Inf.
+func (o *DistanceOracle) Distance(u, v graph.Node) float64 {
+	a, ok := o.indexOf[u.ID()]
+	if !ok {
+		return math.Inf(1)
+	}
+	b, ok := o.indexOf[v.ID()]
+	if !ok {
+		return math.Inf(1)
+	}
+	if a == b {
+		return 0
+	}
+	return math.Min(o.query(a, b), o.query(b, a))
+}
+
+// query returns the Thorup-Zwick estimate of the distance between
+// the nodes with indexes a and b, starting from the pivots of a.
+func (o *DistanceOracle) query(a, b int) float64 {
+	for i := 0; i < o.k; i++ {
+		w := o.pivot[i][a]
+		if w < 0 {
+			break
+		}
+		if d, ok := o.bunch[b][w]; ok {
+			return o.pivotDist[i][a] + d
+		}
+		a, b = b, a
+	}
+	return math.Inf(1)
+}
+
+// oracleSearch performs the Dijkstra searches used
+// to construct a DistanceOracle.
+type oracleSearch struct {
+	g       graph.Graph
+	weight  Weighting
+	nodes   []graph.Node
+	indexOf map[int64]int
+}
+
+// nearest returns the distance from the nearest of the source
+// nodes to each node and the index of that source node, or
+// -1 if no source is reachable.
+func (s oracleSearch) nearest(sources []int) (dist []float64, nearest []int) {
+	dist = make([]float64, len(s.nodes))
+	nearest = make([]int, len(s.nodes))
+	for i := range dist {
+		dist[i] = math.Inf(1)
+		nearest[i] = -1
+	}
+	var q priorityQueue
+	for _, v := range sources {
+		dist[v] = 0
+		nearest[v] = v
+		q = append(q, distanceNode{node: s.nodes[v], dist: 0})
+	}
+	heap.Init(&q)
+	for q.Len() != 0 {
+		mid := heap.Pop(&q).(distanceNode)
+		k := s.indexOf[mid.node.ID()]
+		if mid.dist > dist[k] {
+			continue
+		}
+		s.relax(mid, func(j int, d float64) bool {
+			if d >= dist[j] {
+				return false
+			}
+			dist[j] = d
+			nearest[j] = nearest[k]
+			return true
+		}, &q)
+	}
+	return dist, nearest
+}
+
+// cluster returns the distances from the node w to the nodes
+// that are closer to w than the given bound. A nil bound is
+// treated as infinite.
+func (s oracleSearch) cluster(w int, bound []float64) map[int]float64 {
+	if bound != nil && bound[w] <= 0 {
+		return nil
+	}
+	dist := map[int]float64{w: 0}
+	q := priorityQueue{{node: s.nodes[w], dist: 0}}
+	for q.Len() != 0 {
+		mid := heap.Pop(&q).(distanceNode)
+		if mid.dist > dist[s.indexOf[mid.node.ID()]] {
+			continue
+		}
+		s.relax(mid, func(j int, d float64) bool {
+			if bound != nil && d >= bound[j] {
+				return false
+			}
+			if old, ok := dist[j]; ok && d >= old {
+				return false
+			}
+			dist[j] = d
+			return true
+		}, &q)
+	}
+	return dist
+}
+
+// relax offers the neighbors of u at their distance through u
+// to update, pushing those for which update returns true onto q.
+func (s oracleSearch) relax(u distanceNode, update func(j int, d float64) bool, q *priorityQueue) {
+	uid := u.node.ID()
+	to := s.g.From(uid)
+	for to.Next() {
+		v := to.Node()
+		vid := v.ID()
+		w, ok := s.weight(uid, vid)
+		if !ok {
+			panic("path: unexpected invalid weight")
+		}
+		if w < 0 {
+			panic("path: negative edge weight")
+		}
+		if math.IsInf(w, 1) {
+			continue
+		}
+		d := u.dist + w
+		if update(s.indexOf[vid], d) {
+			heap.Push(q, distanceNode{node: v, dist: d})
+		}
+	}
+}
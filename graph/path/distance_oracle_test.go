@@ -0,0 +1,66 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestDistanceOracle(t *testing.T) {
+	const tol = 1e-9
+	for _, test := range []struct {
+		name string
+		g    graph.Weighted
+	}{
+		{name: "grid", g: randomWeightedGrid(7, randomIntWeight(rand.NewSource(1)))},
+		{name: "random", g: randomWeightedGraph(60, 3, 30, false, rand.NewSource(1))},
+	} {
+		g := test.g.(graph.Undirected)
+		paths := DijkstraAllPaths(g)
+		nodes := graph.NodesOf(g.Nodes())
+		for k := 1; k <= 4; k++ {
+			for seed := uint64(1); seed <= 3; seed++ {
+				o := NewDistanceOracle(g, nil, k, rand.NewSource(seed))
+				stretch := float64(2*k - 1)
+				for _, u := range nodes {
+					for _, v := range nodes {
+						want := paths.Weight(u.ID(), v.ID())
+						got := o.Distance(u, v)
+						if got < want-tol || got > stretch*want+tol {
+							t.Errorf("%s: distance from %d to %d with k=%d and seed %d outside stretch: got:%v want:[%v, %v]",
+								test.name, u.ID(), v.ID(), k, seed, got, want, stretch*want)
+						}
+						if got != o.Distance(v, u) {
+							t.Errorf("%s: asymmetric distance between %d and %d with k=%d and seed %d",
+								test.name, u.ID(), v.ID(), k, seed)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Disconnected and absent nodes are at infinite distance.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(2), T: simple.Node(3), W: 1})
+	for k := 1; k <= 3; k++ {
+		o := NewDistanceOracle(g, nil, k, rand.NewSource(1))
+		if d := o.Distance(simple.Node(0), simple.Node(1)); d != 1 {
+			t.Errorf("unexpected distance between adjacent nodes with k=%d: got:%v want:1", k, d)
+		}
+		for _, p := range [][2]int64{{0, 2}, {3, 1}, {0, 4}} {
+			if d := o.Distance(simple.Node(p[0]), simple.Node(p[1])); !math.IsInf(d, 1) {
+				t.Errorf("unexpected distance between %d and %d with k=%d: got:%v want:+Inf", p[0], p[1], k, d)
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// DwellTimeAStar finds the earliest arrival path from s, departing at startTime, to t
+// in g where each intermediate node of the path requires a fixed service time before
+// the agent may depart. The time taken to traverse each edge is given by travelTime
+// and the service time at each node by dwell. No dwell is incurred at s, which is
+// departed at startTime, or at t, so the returned time is the arrival time at t; the
+// completion of service at t is obtained by adding dwell(t). The path and the arrival
+// time are returned. If t is not reachable from s, the path is nil and the arrival
+// time is +Inf.
+//
+// If travelTime is nil, the Weight method of g is used if g implements Weighted,
+// falling back to UniformCost otherwise. If h is nil, DwellTimeAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. The heuristic must not overestimate the remaining travel
+// time. DwellTimeAStar will panic if g has an A*-reachable negative travel time or
+// dwell time.
+func DwellTimeAStar(s graph.Node, startTime float64, t graph.Node, g graph.Directed, travelTime Weighting, dwell func(graph.Node) float64, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	travelTime = weightFor(g, travelTime)
+
+	sid := s.ID()
+	// The dwell at each intermediate node is
+	// charged to the edge departing from it.
+	weight := func(xid, yid int64) (float64, bool) {
+		w, ok := travelTime(xid, yid)
+		if !ok || xid == sid || xid == yid {
+			return w, ok
+		}
+		d := dwell(g.Node(xid))
+		if d < 0 {
+			panic("A*: negative dwell time")
+		}
+		return w + d, true
+	}
+	pt, _ := aStar(s, t, g, weight, heuristicFor(g, h))
+	path, cost := pt.To(t.ID())
+	if path == nil {
+		return nil, math.Inf(1)
+	}
+	return path, startTime + cost
+}
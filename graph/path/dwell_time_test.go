@@ -0,0 +1,78 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestDwellTimeAStar(t *testing.T) {
+	// A route through two stops 0-1-2-4 and a
+	// longer direct route 0-3-4.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(4), W: 1},
+		{F: simple.Node(0), T: simple.Node(3), W: 2.5},
+		{F: simple.Node(3), T: simple.Node(4), W: 2.5},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	dwells := func(d map[int64]float64) func(graph.Node) float64 {
+		return func(n graph.Node) float64 { return d[n.ID()] }
+	}
+
+	for _, test := range []struct {
+		name     string
+		dwell    map[int64]float64
+		wantPath []int64
+		wantTime float64
+	}{
+		{name: "no dwell", wantPath: []int64{0, 1, 2, 4}, wantTime: 13},
+		{name: "short dwells", dwell: map[int64]float64{1: 0.5, 2: 0.5}, wantPath: []int64{0, 1, 2, 4}, wantTime: 14},
+		{name: "long dwells", dwell: map[int64]float64{1: 1.5, 2: 1.5, 3: 1}, wantPath: []int64{0, 3, 4}, wantTime: 16},
+		{name: "end dwells", dwell: map[int64]float64{0: 10, 4: 10}, wantPath: []int64{0, 1, 2, 4}, wantTime: 13},
+	} {
+		path, arrival := DwellTimeAStar(simple.Node(0), 10, simple.Node(4), g, nil, dwells(test.dwell), nil)
+		if !reflect.DeepEqual(ids(path), test.wantPath) {
+			t.Errorf("%s: unexpected path: got:%v want:%v", test.name, ids(path), test.wantPath)
+		}
+		if arrival != test.wantTime {
+			t.Errorf("%s: unexpected arrival time: got:%v want:%v", test.name, arrival, test.wantTime)
+		}
+	}
+}
+
+func TestDwellTimeAStarSumOfDwells(t *testing.T) {
+	// On a directed path graph the route is fixed, so
+	// dwell times add their sum over interior nodes.
+	const n = 20
+	rnd := rand.New(rand.NewSource(1))
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	dwell := make(map[int64]float64)
+	for i := 0; i < n-1; i++ {
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i), T: simple.Node(i + 1), W: float64(1 + rnd.Intn(5))})
+		dwell[int64(i)] = float64(rnd.Intn(4))
+	}
+	dwell[n-1] = 7
+	var sum float64
+	for i := 1; i < n-1; i++ {
+		sum += dwell[int64(i)]
+	}
+
+	_, base := DwellTimeAStar(simple.Node(0), 0, simple.Node(n-1), g, nil, func(graph.Node) float64 { return 0 }, nil)
+	_, got := DwellTimeAStar(simple.Node(0), 0, simple.Node(n-1), g, nil, func(v graph.Node) float64 { return dwell[v.ID()] }, nil)
+	if got != base+sum {
+		t.Errorf("unexpected arrival time with dwells: got:%v want:%v", got, base+sum)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import "gonum.org/v1/gonum/graph"
+
+// EdgeUsageCounts returns the number of times each edge of g is used by the A*
+// shortest paths between the query node pairs. Each query is a {from, to} pair of
+// nodes. The returned map is keyed by the {from, to} node IDs of the edges; if g
+// is a graph.Undirected, the smaller ID is placed first. Queries without a path
+// do not contribute to the counts.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. The HeuristicCost method of g is used as the search
+// heuristic if g implements HeuristicCoster. EdgeUsageCounts will panic if g has an
+// A*-reachable negative edge weight.
+func EdgeUsageCounts(queries [][2]graph.Node, g graph.Graph, weight Weighting) map[[2]int64]int {
+	weight = weightFor(g, weight)
+	h := heuristicFor(g, nil)
+	_, undirected := g.(graph.Undirected)
+
+	counts := make(map[[2]int64]int)
+	for _, q := range queries {
+		s, t := q[0], q[1]
+		if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+			continue
+		}
+		pt, _ := aStar(s, t, g, weight, h)
+		p, _ := pt.To(t.ID())
+		for i := 1; i < len(p); i++ {
+			uid, vid := p[i-1].ID(), p[i].ID()
+			if undirected && vid < uid {
+				uid, vid = vid, uid
+			}
+			counts[[2]int64{uid, vid}]++
+		}
+	}
+	return counts
+}
@@ -0,0 +1,53 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestEdgeUsageCounts(t *testing.T) {
+	// Two triangles joined by the bottleneck edge 2-3.
+	//
+	//  0       4
+	//  | \   / |
+	//  |  2-3  |
+	//  | /   \ |
+	//  1       5
+	g := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {0, 2}, {1, 2}, {2, 3}, {3, 4}, {3, 5}, {4, 5}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	g.AddNode(simple.Node(6))
+
+	query := func(s, t int64) [2]graph.Node { return [2]graph.Node{simple.Node(s), simple.Node(t)} }
+	queries := [][2]graph.Node{
+		query(0, 4),
+		query(5, 1),
+		query(2, 5),
+		query(0, 1),
+		query(4, 5),
+		query(0, 6), // Unreachable.
+		query(3, 3),
+	}
+
+	got := EdgeUsageCounts(queries, g, nil)
+	want := map[[2]int64]int{
+		{0, 2}: 1,
+		{1, 2}: 1,
+		{2, 3}: 3,
+		{3, 4}: 1,
+		{3, 5}: 2,
+		{0, 1}: 1,
+		{4, 5}: 1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected edge usage counts:\ngot: %v\nwant:%v", got, want)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ElevationLimitedAStar finds the A*-shortest path from s to t in g whose total
+// elevation gain does not exceed maxGain. The elevation of each node is given by
+// elevation and only uphill changes in elevation count towards the gain. The path
+// and its cost are returned. If no path stays within the gain budget, the path is
+// nil and the cost is +Inf.
+//
+// The search is performed over (node, accumulated gain) states. The number of
+// distinct gains at a node is bounded by the number of distinct uphill path
+// profiles reaching it within the budget, so ElevationLimitedAStar may be
+// expensive for large budgets on graphs with many distinct elevations.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, ElevationLimitedAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. ElevationLimitedAStar will panic if g has an
+// A*-reachable negative edge weight.
+func ElevationLimitedAStar(s, t graph.Node, maxGain float64, elevation func(graph.Node) float64, g graph.Graph, weight Weighting, h Heuristic) ([]graph.Node, float64) {
+	return elevationBudgetAStar(s, t, maxGain, func(u, v graph.Node) float64 { return elevation(v) - elevation(u) }, g, weight, h)
+}
+
+// DescentLimitedAStar finds the A*-shortest path from s to t in g whose total
+// elevation loss does not exceed maxDescent. The elevation of each node is given by
+// elevation and only downhill changes in elevation count towards the loss. The path
+// and its cost are returned. If no path stays within the descent budget, the path is
+// nil and the cost is +Inf. DescentLimitedAStar is the downhill counterpart of
+// ElevationLimitedAStar and has the same search cost.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, DescentLimitedAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. DescentLimitedAStar will panic if g has an
+// A*-reachable negative edge weight.
+func DescentLimitedAStar(s, t graph.Node, maxDescent float64, elevation func(graph.Node) float64, g graph.Graph, weight Weighting, h Heuristic) ([]graph.Node, float64) {
+	return elevationBudgetAStar(s, t, maxDescent, func(u, v graph.Node) float64 { return elevation(u) - elevation(v) }, g, weight, h)
+}
+
+// elevationBudgetAStar finds the A*-shortest path from s to t in g for which
+// the sum of the positive values of change over its edges does not exceed
+// budget.
+func elevationBudgetAStar(s, t graph.Node, budget float64, change func(u, v graph.Node) float64, g graph.Graph, weight Weighting, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		used := u.state.(float64)
+		uid := u.node.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			w, ok := weight(uid, v.ID())
+			if !ok {
+				panic("A*: unexpected invalid weight")
+			}
+			next := used
+			if dz := change(u.node, v); dz > 0 {
+				next += dz
+			}
+			if next > budget {
+				continue
+			}
+			yield(v, next, w)
+		}
+	}
+	l, _ := stateAStar(s, 0.0, t, heuristicFor(g, h), expand, isNode(t))
+	if l == nil {
+		return nil, math.Inf(1)
+	}
+	return l.path(), l.gscore
+}
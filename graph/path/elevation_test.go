@@ -0,0 +1,100 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestElevationLimitedAStar(t *testing.T) {
+	// A short route 0-1-3 over the hill at 1, a longer
+	// route 0-2-3 over a low rise at 2 and a flat valley
+	// route 0-4-5-3.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(3), W: 2},
+		{F: simple.Node(0), T: simple.Node(4), W: 2},
+		{F: simple.Node(4), T: simple.Node(5), W: 2},
+		{F: simple.Node(5), T: simple.Node(3), W: 2},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	heights := map[int64]float64{0: 10, 1: 50, 2: 20, 3: 10, 4: 5, 5: 5}
+	elevation := func(n graph.Node) float64 { return heights[n.ID()] }
+
+	for _, test := range []struct {
+		s, t     int64
+		maxGain  float64
+		wantPath []int64
+		wantCost float64
+	}{
+		{s: 0, t: 3, maxGain: 100, wantPath: []int64{0, 1, 3}, wantCost: 2},
+		{s: 0, t: 3, maxGain: 40, wantPath: []int64{0, 1, 3}, wantCost: 2},
+		{s: 0, t: 3, maxGain: 39, wantPath: []int64{0, 2, 3}, wantCost: 4},
+		{s: 0, t: 3, maxGain: 5, wantPath: []int64{0, 4, 5, 3}, wantCost: 6},
+		{s: 4, t: 3, maxGain: 4, wantPath: nil, wantCost: math.Inf(1)},
+
+		// Downhill changes do not count.
+		{s: 1, t: 3, maxGain: 0, wantPath: []int64{1, 3}, wantCost: 1},
+	} {
+		p, cost := ElevationLimitedAStar(simple.Node(test.s), simple.Node(test.t), test.maxGain, elevation, g, nil, nil)
+		if got := ids(p); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("maxGain=%v: unexpected path from %d to %d: got:%v want:%v", test.maxGain, test.s, test.t, got, test.wantPath)
+		}
+		if cost != test.wantCost {
+			t.Errorf("maxGain=%v: unexpected cost from %d to %d: got:%v want:%v", test.maxGain, test.s, test.t, cost, test.wantCost)
+		}
+	}
+}
+
+func TestDescentLimitedAStar(t *testing.T) {
+	// A short route 0-1-3 over a rise at 1 and down
+	// a steep slope to 3, and a longer route 0-2-3
+	// descending steadily. Every route descends at
+	// least the 40 between 0 and 3.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(3), W: 2},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	heights := map[int64]float64{0: 100, 1: 110, 2: 90, 3: 60}
+	elevation := func(n graph.Node) float64 { return heights[n.ID()] }
+
+	for _, test := range []struct {
+		s, t       int64
+		maxDescent float64
+		wantPath   []int64
+		wantCost   float64
+	}{
+		{s: 0, t: 3, maxDescent: 100, wantPath: []int64{0, 1, 3}, wantCost: 2},
+		{s: 0, t: 3, maxDescent: 50, wantPath: []int64{0, 1, 3}, wantCost: 2},
+		{s: 0, t: 3, maxDescent: 49, wantPath: []int64{0, 2, 3}, wantCost: 4},
+		{s: 0, t: 3, maxDescent: 40, wantPath: []int64{0, 2, 3}, wantCost: 4},
+		{s: 0, t: 3, maxDescent: 39, wantPath: nil, wantCost: math.Inf(1)},
+
+		// Uphill changes do not count.
+		{s: 0, t: 1, maxDescent: 0, wantPath: []int64{0, 1}, wantCost: 1},
+	} {
+		p, cost := DescentLimitedAStar(simple.Node(test.s), simple.Node(test.t), test.maxDescent, elevation, g, nil, nil)
+		if got := ids(p); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("maxDescent=%v: unexpected path from %d to %d: got:%v want:%v", test.maxDescent, test.s, test.t, got, test.wantPath)
+		}
+		if cost != test.wantCost {
+			t.Errorf("maxDescent=%v: unexpected cost from %d to %d: got:%v want:%v", test.maxDescent, test.s, test.t, cost, test.wantCost)
+		}
+	}
+}
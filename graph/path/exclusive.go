@@ -0,0 +1,99 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ExclusiveNodeAStar finds the A*-shortest path from s to t in g that does not visit
+// both of the nodes of any of the given exclusive pairs of node IDs. A pair holding
+// the same ID twice excludes that node from the path. The path and its cost are
+// returned. If no such path exists, the path is nil and the cost is +Inf.
+//
+// The search is performed over (node, visited constrained nodes) states, where the
+// constrained nodes are those appearing in exclusive, so the number of states may
+// grow as 2^k times the number of nodes for k constrained nodes. ExclusiveNodeAStar
+// is intended for a small number of exclusive pairs.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, ExclusiveNodeAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. ExclusiveNodeAStar will panic if g has an A*-reachable
+// negative edge weight.
+func ExclusiveNodeAStar(s, t graph.Node, exclusive [][2]int64, g graph.Graph, weight Weighting, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+
+	partners := make(map[int64][]int64)
+	for _, p := range exclusive {
+		partners[p[0]] = append(partners[p[0]], p[1])
+		if p[0] != p[1] {
+			partners[p[1]] = append(partners[p[1]], p[0])
+		}
+	}
+
+	// The state of a label is the set of constrained
+	// nodes visited, encoded by encodeIDSet. visit returns
+	// the state after visiting n and whether the visit
+	// is permitted by the exclusions.
+	visit := func(state string, n graph.Node) (string, bool) {
+		id := n.ID()
+		excluded, ok := partners[id]
+		if !ok {
+			return state, true
+		}
+		visited := decodeIDSet(state)
+		i := sort.Search(len(visited), func(i int) bool { return visited[i] >= id })
+		if i < len(visited) && visited[i] == id {
+			return state, true
+		}
+		for _, b := range excluded {
+			if b == id {
+				return "", false
+			}
+			j := sort.Search(len(visited), func(j int) bool { return visited[j] >= b })
+			if j < len(visited) && visited[j] == b {
+				return "", false
+			}
+		}
+		visited = append(visited, 0)
+		copy(visited[i+1:], visited[i:])
+		visited[i] = id
+		return encodeIDSet(visited), true
+	}
+	start, ok := visit("", s)
+	if !ok {
+		return nil, math.Inf(1)
+	}
+
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		uid := u.node.ID()
+		state := u.state.(string)
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			next, ok := visit(state, v)
+			if !ok {
+				continue
+			}
+			w, ok := weight(uid, v.ID())
+			if !ok {
+				panic("A*: unexpected invalid weight")
+			}
+			yield(v, next, w)
+		}
+	}
+	l, _ := stateAStar(s, start, t, heuristicFor(g, h), expand, isNode(t))
+	if l == nil {
+		return nil, math.Inf(1)
+	}
+	return l.path(), l.gscore
+}
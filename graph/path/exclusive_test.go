@@ -0,0 +1,89 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestExclusiveNodeAStar(t *testing.T) {
+	// The cheapest route from 0 to 5 passes through
+	// nodes 1 and 3. Node 1 is the cheaper first step,
+	// but avoiding node 3 after it costs more than the
+	// route through node 2.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(3), T: simple.Node(5), W: 1},
+		{F: simple.Node(1), T: simple.Node(4), W: 3},
+		{F: simple.Node(4), T: simple.Node(5), W: 3},
+		{F: simple.Node(0), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(5), W: 2},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	for _, test := range []struct {
+		name      string
+		exclusive [][2]int64
+		wantPath  []int64
+		wantCost  float64
+	}{
+		{
+			name:     "unconstrained",
+			wantPath: []int64{0, 1, 3, 5},
+			wantCost: 3,
+		},
+		{
+			name:      "1 or 3",
+			exclusive: [][2]int64{{1, 3}},
+			wantPath:  []int64{0, 2, 5},
+			wantCost:  4,
+		},
+		{
+			name:      "3 or 1",
+			exclusive: [][2]int64{{3, 1}},
+			wantPath:  []int64{0, 2, 5},
+			wantCost:  4,
+		},
+		{
+			name:      "1 or 3 and 2 or 5",
+			exclusive: [][2]int64{{1, 3}, {2, 5}},
+			wantPath:  []int64{0, 1, 4, 5},
+			wantCost:  7,
+		},
+		{
+			name:      "not 3",
+			exclusive: [][2]int64{{3, 3}},
+			wantPath:  []int64{0, 2, 5},
+			wantCost:  4,
+		},
+		{
+			name:      "unrelated",
+			exclusive: [][2]int64{{2, 4}},
+			wantPath:  []int64{0, 1, 3, 5},
+			wantCost:  3,
+		},
+		{
+			name:      "0 or 5",
+			exclusive: [][2]int64{{0, 5}},
+			wantPath:  nil,
+			wantCost:  math.Inf(1),
+		},
+	} {
+		p, cost := ExclusiveNodeAStar(simple.Node(0), simple.Node(5), test.exclusive, g, nil, nil)
+		if got := ids(p); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("unexpected path for %s: got:%v want:%v", test.name, got, test.wantPath)
+		}
+		if cost != test.wantCost {
+			t.Errorf("unexpected cost for %s: got:%v want:%v", test.name, cost, test.wantCost)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ExpectedCostAStar finds the A*-shortest path from s to t in g under stochastic
+// edge costs, minimizing the expected path cost given by the edge means in mean.
+// The path is returned with its expected cost, the sum of the edge means along the
+// path, and the variance of its cost, the sum of the edge variances given by
+// variance along the path under the assumption that edge costs are independent.
+// If no path exists, the path is nil and the expected cost and variance are +Inf.
+//
+// The variance does not influence the choice of path. If mean is nil, the Weight
+// method of g is used if g implements Weighted, falling back to UniformCost
+// otherwise. If h is nil, ExpectedCostAStar will use the g.HeuristicCost method
+// if g implements HeuristicCoster, falling back to NullHeuristic otherwise.
+// The variance function is required; ExpectedCostAStar will panic if variance is
+// nil, if g has an A*-reachable negative mean cost or if variance does not return
+// a valid value for an edge of the path.
+func ExpectedCostAStar(s, t graph.Node, g graph.Graph, mean, variance Weighting, h Heuristic) (path []graph.Node, expected, costVariance float64) {
+	if variance == nil {
+		panic("path: nil variance")
+	}
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1), math.Inf(1)
+	}
+	mean = weightFor(g, mean)
+	pt, _ := aStar(s, t, g, mean, heuristicFor(g, h))
+	path, expected = pt.To(t.ID())
+	if path == nil {
+		return nil, math.Inf(1), math.Inf(1)
+	}
+	for i := 1; i < len(path); i++ {
+		v, ok := variance(path[i-1].ID(), path[i].ID())
+		if !ok {
+			panic("A*: unexpected invalid variance")
+		}
+		costVariance += v
+	}
+	return path, expected, costVariance
+}
@@ -0,0 +1,107 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestExpectedCostAStar(t *testing.T) {
+	// The route 0-1-3 has a lower mean cost but
+	// a higher variance than the route 0-2-3.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	vars := make(map[[2]int64]float64)
+	for _, e := range []struct {
+		from, to       int64
+		mean, variance float64
+	}{
+		{from: 0, to: 1, mean: 1, variance: 4},
+		{from: 1, to: 3, mean: 1, variance: 5},
+		{from: 0, to: 2, mean: 2, variance: 0.5},
+		{from: 2, to: 3, mean: 2, variance: 0.25},
+	} {
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(e.from), T: simple.Node(e.to), W: e.mean})
+		vars[[2]int64{e.from, e.to}] = e.variance
+	}
+	variance := func(xid, yid int64) (float64, bool) {
+		v, ok := vars[[2]int64{xid, yid}]
+		return v, ok
+	}
+	path, expected, v := ExpectedCostAStar(simple.Node(0), simple.Node(3), g, nil, variance, nil)
+	if got, want := ids(path), []int64{0, 1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected path: got:%v want:%v", got, want)
+	}
+	if expected != 2 {
+		t.Errorf("unexpected expected cost: got:%v want:2", expected)
+	}
+	if v != 9 {
+		t.Errorf("unexpected variance: got:%v want:9", v)
+	}
+}
+
+func TestExpectedCostAStarRandom(t *testing.T) {
+	g := randomWeightedGraph(100, 4, 100, false, rand.NewSource(1))
+	wg := g.(*simple.WeightedUndirectedGraph)
+	rnd := rand.New(rand.NewSource(2))
+	vars := make(map[[2]int64]float64)
+	edges := wg.Edges()
+	for edges.Next() {
+		e := edges.Edge()
+		uid, vid := e.From().ID(), e.To().ID()
+		if vid < uid {
+			uid, vid = vid, uid
+		}
+		vars[[2]int64{uid, vid}] = rnd.Float64() * 5
+	}
+	variance := func(xid, yid int64) (float64, bool) {
+		if yid < xid {
+			xid, yid = yid, xid
+		}
+		v, ok := vars[[2]int64{xid, yid}]
+		return v, ok
+	}
+
+	for _, q := range [][2]int64{{0, 99}, {3, 57}, {12, 80}} {
+		s, tn := simple.Node(q[0]), simple.Node(q[1])
+		path, expected, v := ExpectedCostAStar(s, tn, g, nil, variance, nil)
+		pt, _ := AStar(s, tn, g, nil)
+		_, want := pt.To(tn.ID())
+		if expected != want {
+			t.Errorf("unexpected expected cost for %v: got:%v want:%v", q, expected, want)
+		}
+		var sum float64
+		for i := 1; i < len(path); i++ {
+			w, _ := variance(path[i-1].ID(), path[i].ID())
+			sum += w
+		}
+		if math.Abs(v-sum) > 1e-12 {
+			t.Errorf("unexpected variance for %v: got:%v want:%v", q, v, sum)
+		}
+	}
+
+	path, expected, v := ExpectedCostAStar(simple.Node(0), simple.Node(-1), g, nil, variance, nil)
+	if path != nil || !math.IsInf(expected, 1) || !math.IsInf(v, 1) {
+		t.Errorf("unexpected result for absent goal: got:%v,%v,%v", ids(path), expected, v)
+	}
+}
+
+func TestExpectedCostAStarNilVariance(t *testing.T) {
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	panicked := func() (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		ExpectedCostAStar(simple.Node(0), simple.Node(1), g, nil, nil, nil)
+		return false
+	}()
+	if !panicked {
+		t.Error("expected panic for nil variance")
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/iterator"
+)
+
+// filteredGraph is a view of a graph that hides nodes and edges
+// from traversal without altering the embedded graph. Hidden nodes
+// remain in the node set so that shortest path trees can be built
+// over all the nodes of the graph.
+type filteredGraph struct {
+	graph.Graph
+
+	// canEnter reports whether a node may be
+	// entered during traversal. A nil canEnter
+	// allows all nodes.
+	canEnter func(graph.Node) bool
+	// canWalk reports whether the edge from u
+	// to v may be traversed. A nil canWalk
+	// allows all edges.
+	canWalk func(uid, vid int64) bool
+}
+
+func (g filteredGraph) From(id int64) graph.Nodes {
+	nodes := graph.NodesOf(g.Graph.From(id))
+	for i := 0; i < len(nodes); {
+		if g.allows(id, nodes[i]) {
+			i++
+			continue
+		}
+		nodes[i] = nodes[len(nodes)-1]
+		nodes = nodes[:len(nodes)-1]
+	}
+	return iterator.NewOrderedNodes(nodes)
+}
+
+func (g filteredGraph) Edge(uid, vid int64) graph.Edge {
+	e := g.Graph.Edge(uid, vid)
+	if e == nil || !g.allows(uid, e.To()) {
+		return nil
+	}
+	return e
+}
+
+func (g filteredGraph) allows(uid int64, v graph.Node) bool {
+	return (g.canEnter == nil || g.canEnter(v)) && (g.canWalk == nil || g.canWalk(uid, v.ID()))
+}
@@ -0,0 +1,91 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"gonum.org/v1/gonum/graph"
+)
+
+// fitRate is the factor by which FitWeightsToPaths scales
+// the weight of an edge in a single update.
+const fitRate = 1.1
+
+// FitWeightsToPaths returns a Weighting under which each of the observed paths through
+// g is, as far as can be attained within the given number of iterations, a shortest
+// path between its end points. Starting from initial, each iteration compares every
+// observed path with a shortest path between the same end points under the current
+// weights and, if the observed path is more expensive, applies a structured perceptron
+// update: the weights of the edges only on the observed path are divided by 1.1 and
+// the weights of the edges only on the shortest path are multiplied by 1.1.
+// Multiplicative updates keep positive weights positive. Fitting stops early when
+// every observed path is a shortest path. Edges that are not traversed by any of the
+// compared paths keep their initial weights.
+//
+// Observed paths that are consistent with a single set of weights will all be shortest
+// paths after the fitting converges; conflicting observations are resolved by
+// compromise. The returned Weighting refers to initial for the weights of edges that
+// have not been updated. If initial is nil, the Weight method of g is used if g
+// implements Weighted, falling back to UniformCost otherwise. FitWeightsToPaths will
+// panic if an observed path is not a path in g or if g has a negative edge weight
+// reachable from the start of an observed path.
+func FitWeightsToPaths(g graph.Directed, observed [][]graph.Node, initial Weighting, iterations int) Weighting {
+	initial = weightFor(g, initial)
+
+	fitted := make(map[[2]int64]float64)
+	weight := func(xid, yid int64) (w float64, ok bool) {
+		if w, ok := fitted[[2]int64{xid, yid}]; ok {
+			return w, true
+		}
+		return initial(xid, yid)
+	}
+	for _, p := range observed {
+		for i := 1; i < len(p); i++ {
+			if _, ok := weight(p[i-1].ID(), p[i].ID()); !ok || g.Edge(p[i-1].ID(), p[i].ID()) == nil {
+				panic("path: observed path not in graph")
+			}
+		}
+	}
+
+	for it := 0; it < iterations; it++ {
+		converged := true
+		for _, p := range observed {
+			if len(p) < 2 {
+				continue
+			}
+			s, t := p[0], p[len(p)-1]
+			pt, _ := aStar(s, t, g, weight, NullHeuristic)
+			q, cost := pt.To(t.ID())
+			if weightOf(p, weight) <= cost {
+				continue
+			}
+			converged = false
+
+			on := make(map[[2]int64]int)
+			for i := 1; i < len(p); i++ {
+				on[[2]int64{p[i-1].ID(), p[i].ID()}]++
+			}
+			for i := 1; i < len(q); i++ {
+				on[[2]int64{q[i-1].ID(), q[i].ID()}]--
+			}
+			for e, c := range on {
+				if c == 0 {
+					continue
+				}
+				w, _ := weight(e[0], e[1])
+				if c > 0 {
+					w /= fitRate
+				} else {
+					w *= fitRate
+				}
+				fitted[e] = w
+			}
+		}
+		if converged {
+			break
+		}
+	}
+
+	return weight
+}
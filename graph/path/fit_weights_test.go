@@ -0,0 +1,82 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestFitWeightsToPaths(t *testing.T) {
+	// The observed route from 0 to 3 via 2 is initially
+	// more expensive than the route via 1.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(3), W: 2},
+		{F: simple.Node(3), T: simple.Node(4), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	observed := []graph.Node{simple.Node(0), simple.Node(2), simple.Node(3)}
+
+	weight := FitWeightsToPaths(g, [][]graph.Node{observed}, nil, 0)
+	if w, _ := weight(0, 2); w != 2 {
+		t.Errorf("unexpected weight without iterations: got:%v want:2", w)
+	}
+
+	weight = FitWeightsToPaths(g, [][]graph.Node{observed}, nil, 100)
+	pt, _ := aStar(simple.Node(0), simple.Node(3), g, weight, NullHeuristic)
+	if got, want := weightOf(observed, weight), pt.WeightTo(3); got > want {
+		t.Errorf("observed path is not a shortest path after fitting: got cost:%v shortest:%v", got, want)
+	}
+	if w, _ := weight(3, 4); w != 1 {
+		t.Errorf("unexpected weight for edge not on compared paths: got:%v want:1", w)
+	}
+	for _, e := range [][2]int64{{0, 1}, {1, 3}, {0, 2}, {2, 3}} {
+		if w, _ := weight(e[0], e[1]); w <= 0 {
+			t.Errorf("unexpected non-positive weight for edge %v: %v", e, w)
+		}
+	}
+}
+
+func TestFitWeightsToPathsRandom(t *testing.T) {
+	// Paths observed under hidden weights are shortest
+	// paths after fitting from uniform weights.
+	for seed := uint64(1); seed <= 5; seed++ {
+		g := randomWeightedGraph(30, 3, 15, true, rand.NewSource(seed))
+		rnd := rand.New(rand.NewSource(seed))
+		nodes := graph.NodesOf(g.Nodes())
+
+		var observed [][]graph.Node
+		for len(observed) < 10 {
+			s := nodes[rnd.Intn(len(nodes))]
+			u := nodes[rnd.Intn(len(nodes))]
+			pt, _ := aStar(s, u, g, g.Weight, NullHeuristic)
+			p, _ := pt.To(u.ID())
+			if len(p) < 3 {
+				continue
+			}
+			observed = append(observed, p)
+		}
+
+		weight := FitWeightsToPaths(g.(graph.Directed), observed, UniformCost(g), 1000)
+		for _, p := range observed {
+			s, u := p[0], p[len(p)-1]
+			pt, _ := aStar(s, u, g, weight, NullHeuristic)
+			if got, want := weightOf(p, weight), pt.WeightTo(u.ID()); got > want {
+				t.Errorf("seed %d: observed path %v is not a shortest path after fitting: got cost:%v shortest:%v",
+					seed, ids(p), got, want)
+			}
+		}
+	}
+}
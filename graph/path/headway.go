@@ -0,0 +1,79 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// HeadwayAStar finds the earliest arrival path from s, departing at startTime, to t
+// in g where agents entering the same edge must be separated by at least headway.
+// The times at which other agents have reserved entry to each edge are held in
+// reservations keyed by the IDs of the edge's from and to nodes. An edge may not be
+// entered at a time strictly within headway of any of its reservations; an agent
+// arriving at the tail of such an edge waits at the node until entry is allowed.
+// The time taken to traverse each edge is given by travelTime. The path and the
+// arrival time at t are returned. If t cannot be reached, the path is nil and the
+// arrival time is +Inf.
+//
+// If travelTime is nil, the Weight method of g is used if g implements Weighted,
+// falling back to UniformCost otherwise. If h is nil, HeadwayAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. The heuristic must not overestimate the remaining travel
+// time. HeadwayAStar will panic if g has an A*-reachable negative travel time.
+func HeadwayAStar(s graph.Node, startTime float64, t graph.Node, g graph.Directed, travelTime Weighting, reservations map[[2]int64][]float64, headway float64, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	travelTime = weightFor(g, travelTime)
+
+	reserved := make(map[[2]int64][]float64, len(reservations))
+	for e, times := range reservations {
+		times = append([]float64(nil), times...)
+		sort.Float64s(times)
+		reserved[e] = times
+	}
+
+	// Since waiting is allowed, arriving at a node earlier
+	// is never worse than arriving later, so labels only
+	// need to hold the earliest arrival at each node.
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		now := startTime + u.gscore
+		uid := u.node.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			w, ok := travelTime(uid, vid)
+			if !ok {
+				panic("A*: unexpected invalid weight")
+			}
+			depart := earliestEntry(now, reserved[[2]int64{uid, vid}], headway)
+			yield(v, nil, depart-now+w)
+		}
+	}
+	l, _ := stateAStar(s, nil, t, heuristicFor(g, h), expand, isNode(t))
+	if l == nil {
+		return nil, math.Inf(1)
+	}
+	return l.path(), startTime + l.gscore
+}
+
+// earliestEntry returns the earliest time not before now that is not strictly
+// within headway of any of the sorted reservation times.
+func earliestEntry(now float64, reserved []float64, headway float64) float64 {
+	for _, r := range reserved {
+		if now <= r-headway {
+			break
+		}
+		if now < r+headway {
+			now = r + headway
+		}
+	}
+	return now
+}
@@ -0,0 +1,77 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestHeadwayAStar(t *testing.T) {
+	// A direct track 0-1-3 and a longer detour 0-2-3.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(3), W: 2},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	for _, test := range []struct {
+		name         string
+		reservations map[[2]int64][]float64
+		headway      float64
+		wantPath     []int64
+		wantTime     float64
+	}{
+		{
+			name:     "no reservations",
+			headway:  2,
+			wantPath: []int64{0, 1, 3},
+			wantTime: 2,
+		},
+		{
+			name:         "later departure",
+			reservations: map[[2]int64][]float64{{1, 3}: {1.5}},
+			headway:      1,
+			wantPath:     []int64{0, 1, 3},
+			wantTime:     3.5,
+		},
+		{
+			name:         "detour",
+			reservations: map[[2]int64][]float64{{0, 1}: {0.5, -1}},
+			headway:      2,
+			wantPath:     []int64{0, 2, 3},
+			wantTime:     4,
+		},
+		{
+			name:         "exact headway",
+			reservations: map[[2]int64][]float64{{0, 1}: {-1}, {1, 3}: {2}},
+			headway:      1,
+			wantPath:     []int64{0, 1, 3},
+			wantTime:     2,
+		},
+		{
+			name:         "chained reservations",
+			reservations: map[[2]int64][]float64{{1, 3}: {2.5, 1.5}, {2, 3}: {1}},
+			headway:      1,
+			wantPath:     []int64{0, 2, 3},
+			wantTime:     4,
+		},
+	} {
+		p, arrival := HeadwayAStar(simple.Node(0), 0, simple.Node(3), g, nil, test.reservations, test.headway, nil)
+		if got := ids(p); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("%s: unexpected path: got:%v want:%v", test.name, got, test.wantPath)
+		}
+		if arrival != test.wantTime {
+			t.Errorf("%s: unexpected arrival time: got:%v want:%v", test.name, arrival, test.wantTime)
+		}
+	}
+}
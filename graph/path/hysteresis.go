@@ -0,0 +1,67 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// HysteresisAStar finds a path from s to t in g that only departs from a previously
+// returned path when doing so is worthwhile. The A*-shortest path from s to t is
+// returned only if its cost is more than stickiness less than the current cost of
+// previous; otherwise previous is returned with its current cost. Calling
+// HysteresisAStar with the result of the previous call when replanning avoids route
+// changes in response to small changes in edge weights. If previous is no longer a
+// path from s to t in g, because it is empty, does not start at s and end at t, or
+// traverses an edge that is no longer in g or has an infinite weight, the A*-shortest
+// path is returned. If t is not reachable from s, the path is nil and the cost is
+// +Inf.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, HysteresisAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. HysteresisAStar will panic if g has an A*-reachable
+// negative edge weight.
+func HysteresisAStar(s, t graph.Node, previous []graph.Node, stickiness float64, g graph.Graph, weight Weighting, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+
+	pt, _ := aStar(s, t, g, weight, heuristicFor(g, h))
+	p, cost := pt.To(t.ID())
+	if p == nil {
+		return nil, math.Inf(1)
+	}
+	prev, ok := pathCost(s, t, previous, g, weight)
+	if !ok || cost < prev-stickiness {
+		return p, cost
+	}
+	return previous, prev
+}
+
+// pathCost returns the cost of the path p from s to t in g under
+// weight and whether p is still a finite-cost path from s to t in g.
+func pathCost(s, t graph.Node, p []graph.Node, g graph.Graph, weight Weighting) (cost float64, ok bool) {
+	if len(p) == 0 || p[0].ID() != s.ID() || p[len(p)-1].ID() != t.ID() {
+		return math.Inf(1), false
+	}
+	for i, n := range p {
+		if g.Node(n.ID()) == nil {
+			return math.Inf(1), false
+		}
+		if i == 0 {
+			continue
+		}
+		w, ok := weight(p[i-1].ID(), n.ID())
+		if !ok || math.IsInf(w, 1) {
+			return math.Inf(1), false
+		}
+		cost += w
+	}
+	return cost, true
+}
@@ -0,0 +1,72 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestHysteresisAStar(t *testing.T) {
+	// Two routes from 0 to 3; the route via 2 was previously
+	// the shortest and the weight of the route via 1 is varied.
+	previous := []graph.Node{simple.Node(0), simple.Node(2), simple.Node(3)}
+	for _, test := range []struct {
+		via      float64
+		remove   bool
+		wantPath []int64
+		wantCost float64
+	}{
+		{via: 3, wantPath: []int64{0, 2, 3}, wantCost: 2},
+		{via: 1.9, wantPath: []int64{0, 2, 3}, wantCost: 2},
+		{via: 1.6, wantPath: []int64{0, 2, 3}, wantCost: 2},
+		{via: 1.4, wantPath: []int64{0, 1, 3}, wantCost: 1.4},
+		{via: 1, wantPath: []int64{0, 1, 3}, wantCost: 1},
+
+		// The previous route is no longer available.
+		{via: 3, remove: true, wantPath: []int64{0, 1, 3}, wantCost: 3},
+	} {
+		g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		for _, e := range []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: test.via / 2},
+			{F: simple.Node(1), T: simple.Node(3), W: test.via / 2},
+			{F: simple.Node(0), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+		} {
+			g.SetWeightedEdge(e)
+		}
+		if test.remove {
+			g.RemoveEdge(2, 3)
+		}
+
+		p, cost := HysteresisAStar(simple.Node(0), simple.Node(3), previous, 0.5, g, nil, nil)
+		if got := ids(p); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("unexpected path for via=%v remove=%t: got:%v want:%v", test.via, test.remove, got, test.wantPath)
+		}
+		if math.Abs(cost-test.wantCost) > 1e-12 {
+			t.Errorf("unexpected cost for via=%v remove=%t: got:%v want:%v", test.via, test.remove, cost, test.wantCost)
+		}
+	}
+
+	// A previous path to a different target is ignored.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: 1})
+	p, cost := HysteresisAStar(simple.Node(0), simple.Node(1), []graph.Node{simple.Node(0), simple.Node(1), simple.Node(2)}, 10, g, nil, nil)
+	if got, want := ids(p), []int64{0, 1}; !reflect.DeepEqual(got, want) || cost != 1 {
+		t.Errorf("unexpected path for mismatched previous path: got:%v cost=%v want:%v cost=1", got, cost, want)
+	}
+
+	// Unreachable targets are reported as such.
+	g.AddNode(simple.Node(3))
+	p, cost = HysteresisAStar(simple.Node(0), simple.Node(3), nil, 10, g, nil, nil)
+	if p != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected result for unreachable target: got:%v cost=%v want:[] cost=+Inf", ids(p), cost)
+	}
+}
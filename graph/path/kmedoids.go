@@ -0,0 +1,169 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// GraphKMedoids partitions the nodes of g into k clusters around medoid nodes using the
+// shortest path distances in g, and returns the medoids, sorted by ID, and the index
+// of the cluster of each node of g, keyed by node ID. Each node is assigned to the
+// cluster of the nearest medoid, where the distance from a medoid to a node is the
+// cost of the shortest path from the medoid to the node.
+//
+// The initial medoids are k distinct nodes chosen at random, which are improved by the
+// swap phase of the Partitioning Around Medoids algorithm: in each iteration the swap
+// of a medoid with a non-medoid node that most reduces the total distance from the
+// nodes to their nearest medoids is made, until no swap reduces the total distance or
+// maxIter iterations have been made. Nodes that are not reachable from any medoid have
+// a cluster index of -1, and swaps that reach more nodes are preferred over any
+// reduction in distance. Distances are found by a Dijkstra search from each node when
+// it is first considered as a medoid, so GraphKMedoids may perform a search from every
+// node of g, and each iteration takes O(k·n²) time for a graph with n nodes.
+//
+// Random numbers are drawn from src, or the global rand source if src is nil;
+// runs with equivalent sources are reproducible. If weight is nil, the Weight method
+// of g is used if g implements Weighted, falling back to UniformCost otherwise.
+// GraphKMedoids will panic if k is less than one or greater than the number of nodes
+// in g, or if g has a negative edge weight.
+//
+// See Kaufman and Rousseeuw doi:10.1002/9780470316801.ch2 for details.
+func GraphKMedoids(g graph.Graph, weight Weighting, k, maxIter int, src rand.Source) (medoids []graph.Node, cluster map[int64]int) {
+	nodes := graph.NodesOf(g.Nodes())
+	if k < 1 || len(nodes) < k {
+		panic("path: invalid number of clusters")
+	}
+	sort.Sort(ordered.ByID(nodes))
+	weight = weightFor(g, weight)
+	perm := rand.Perm
+	if src != nil {
+		perm = rand.New(src).Perm
+	}
+
+	// dist holds the distances from the nodes to
+	// all nodes, indexed by position in nodes.
+	dist := make([][]float64, len(nodes))
+	distFrom := func(i int) []float64 {
+		if dist[i] != nil {
+			return dist[i]
+		}
+		pt := newShortestFrom(nodes[i], nodes)
+		dijkstraFrom(nodes[i], g, weight, &pt)
+		d := make([]float64, len(nodes))
+		for j, n := range nodes {
+			d[j] = pt.WeightTo(n.ID())
+		}
+		dist[i] = d
+		return d
+	}
+
+	medoid := perm(len(nodes))[:k]
+	isMedoid := make([]bool, len(nodes))
+	for _, m := range medoid {
+		isMedoid[m] = true
+		distFrom(m)
+	}
+
+	// near and second hold the distances from the nearest and
+	// second nearest medoids to each node, and nearest holds
+	// the position in medoid of the nearest medoid.
+	near := make([]float64, len(nodes))
+	second := make([]float64, len(nodes))
+	nearest := make([]int, len(nodes))
+	update := func() {
+		for j := range nodes {
+			near[j], second[j] = math.Inf(1), math.Inf(1)
+			nearest[j] = -1
+			for c, m := range medoid {
+				d := dist[m][j]
+				switch {
+				case d < near[j]:
+					near[j], second[j] = d, near[j]
+					nearest[j] = c
+				case d < second[j]:
+					second[j] = d
+				}
+			}
+		}
+	}
+	update()
+
+	for it := 0; it < maxIter; it++ {
+		var (
+			bestReached int
+			bestChange  float64
+			bestMedoid  = -1
+			bestNode    int
+		)
+		for c := range medoid {
+			for h := range nodes {
+				if isMedoid[h] {
+					continue
+				}
+				dh := distFrom(h)
+				var (
+					reached int
+					change  float64
+				)
+				for j := range nodes {
+					d := near[j]
+					if nearest[j] == c {
+						d = second[j]
+					}
+					d = math.Min(d, dh[j])
+					r, v := medoidCostChange(near[j], d)
+					reached += r
+					change += v
+				}
+				if reached > bestReached || (reached == bestReached && change < bestChange) {
+					bestReached, bestChange = reached, change
+					bestMedoid, bestNode = c, h
+				}
+			}
+		}
+		if bestMedoid < 0 {
+			break
+		}
+		isMedoid[medoid[bestMedoid]] = false
+		isMedoid[bestNode] = true
+		medoid[bestMedoid] = bestNode
+		update()
+	}
+
+	sort.Ints(medoid)
+	update()
+	medoids = make([]graph.Node, k)
+	for c, m := range medoid {
+		medoids[c] = nodes[m]
+	}
+	cluster = make(map[int64]int, len(nodes))
+	for j, n := range nodes {
+		cluster[n.ID()] = nearest[j]
+	}
+	return medoids, cluster
+}
+
+// medoidCostChange returns the change in the number of nodes reached
+// and in the total distance when the distance from the nearest medoid
+// to a node changes from before to after.
+func medoidCostChange(before, after float64) (reached int, change float64) {
+	switch beforeInf, afterInf := math.IsInf(before, 1), math.IsInf(after, 1); {
+	case beforeInf && afterInf:
+		return 0, 0
+	case beforeInf:
+		return 1, after
+	case afterInf:
+		return -1, -before
+	default:
+		return 0, after - before
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestGraphKMedoids(t *testing.T) {
+	// Two cliques, {0, 1, 2, 3, 4} and {5, 6, 7, 8, 9}, joined
+	// by an expensive edge, with node 10 isolated.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for c := int64(0); c < 2; c++ {
+		for i := int64(0); i < 5; i++ {
+			for j := i + 1; j < 5; j++ {
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(5*c + i), T: simple.Node(5*c + j), W: 1})
+			}
+		}
+	}
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(4), T: simple.Node(5), W: 10})
+	g.AddNode(simple.Node(10))
+
+	for seed := uint64(1); seed <= 10; seed++ {
+		medoids, cluster := GraphKMedoids(g, nil, 3, 100, rand.NewSource(seed))
+		if len(medoids) != 3 {
+			t.Fatalf("unexpected number of medoids for seed %d: got:%d want:3", seed, len(medoids))
+		}
+		if medoids[2].ID() != 10 {
+			t.Errorf("isolated node is not a medoid for seed %d: got medoids:%v", seed, ids(medoids))
+		}
+		for id, c := range cluster {
+			var want int
+			switch {
+			case id < 5:
+				want = 0
+			case id < 10:
+				want = 1
+			default:
+				want = 2
+			}
+			if c != want {
+				t.Errorf("unexpected cluster for node %d with seed %d: got:%d want:%d", id, seed, c, want)
+			}
+			if m := medoids[c].ID(); id < 10 && m/5 != id/5 {
+				t.Errorf("medoid %d of node %d with seed %d is not in its clique", m, id, seed)
+			}
+		}
+	}
+
+	// With fewer medoids, reaching the isolated
+	// node takes precedence over the distances.
+	medoids, cluster := GraphKMedoids(g, nil, 2, 100, rand.NewSource(1))
+	if medoids[1].ID() != 10 {
+		t.Errorf("isolated node is not a medoid with two medoids: got medoids:%v", ids(medoids))
+	}
+	for id, c := range cluster {
+		var want int
+		if id == 10 {
+			want = 1
+		}
+		if c != want {
+			t.Errorf("unexpected cluster for node %d with two medoids: got:%d want:%d", id, c, want)
+		}
+	}
+
+	// Without the isolated node, the two cliques
+	// are separated by two medoids.
+	g.RemoveNode(10)
+	for seed := uint64(1); seed <= 10; seed++ {
+		_, cluster := GraphKMedoids(g, nil, 2, 100, rand.NewSource(seed))
+		for id, c := range cluster {
+			if want := int(id / 5); c != want {
+				t.Errorf("unexpected cluster for node %d with two medoids and seed %d: got:%d want:%d", id, seed, c, want)
+			}
+		}
+	}
+}
+
+func TestGraphKMedoidsLocalOptimum(t *testing.T) {
+	// No single swap of a medoid with another node
+	// reduces the total distance to the medoids.
+	for seed := uint64(1); seed <= 5; seed++ {
+		g := randomWeightedGraph(30, 3, 15, false, rand.NewSource(seed))
+		const k = 4
+		medoids, cluster := GraphKMedoids(g, nil, k, 1000, rand.NewSource(seed))
+		paths := DijkstraAllPaths(g)
+		total := func(medoids []graph.Node) float64 {
+			var sum float64
+			for _, n := range graph.NodesOf(g.Nodes()) {
+				d := math.Inf(1)
+				for _, m := range medoids {
+					d = math.Min(d, paths.Weight(m.ID(), n.ID()))
+				}
+				sum += d
+			}
+			return sum
+		}
+
+		got := total(medoids)
+		for id, c := range cluster {
+			if c < 0 {
+				t.Errorf("node %d with seed %d not reached by any medoid", id, seed)
+				continue
+			}
+			for _, m := range medoids {
+				if paths.Weight(m.ID(), id) < paths.Weight(medoids[c].ID(), id) {
+					t.Errorf("node %d with seed %d not assigned to nearest medoid", id, seed)
+				}
+			}
+		}
+		for i := range medoids {
+			for _, n := range graph.NodesOf(g.Nodes()) {
+				swapped := append([]graph.Node(nil), medoids...)
+				swapped[i] = n
+				if s := total(swapped); s < got-1e-9 {
+					t.Errorf("swap of medoid %d with node %d with seed %d reduces total distance: %v < %v",
+						medoids[i].ID(), n.ID(), seed, s, got)
+				}
+			}
+		}
+	}
+}
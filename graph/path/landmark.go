@@ -0,0 +1,128 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// LandmarkSearcher is a Searcher that performs A* searches guided by the ALT
+// (A*, landmarks and triangle inequality) heuristic. The distances between a set
+// of landmark nodes and all other nodes are computed once when the searcher is
+// created and are reused by every query, amortizing the preprocessing cost over
+// many searches.
+//
+// LandmarkSearcher assumes that the graph and edge weights do not change; if they
+// do, a new LandmarkSearcher must be created.
+type LandmarkSearcher struct {
+	g      graph.Graph
+	weight Weighting
+
+	// from and to hold the shortest path
+	// trees from and to each landmark.
+	landmarks []graph.Node
+	from, to  []Shortest
+}
+
+// NewLandmarkSearcher returns a LandmarkSearcher for g using n landmarks, or all the
+// nodes of g if g has fewer than n nodes. The landmarks are chosen by farthest point
+// selection, starting from the node with the lowest ID and repeatedly adding the node
+// farthest from all previously chosen landmarks, with nodes unreachable from any
+// chosen landmark preferred. The construction performs two Dijkstra searches for each
+// landmark.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. NewLandmarkSearcher will panic if n is not positive
+// or if g has a negative edge weight.
+func NewLandmarkSearcher(g graph.Graph, weight Weighting, n int) *LandmarkSearcher {
+	if n <= 0 {
+		panic("path: non-positive landmark count")
+	}
+	l := &LandmarkSearcher{g: g, weight: weightFor(g, weight)}
+
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) == 0 {
+		return l
+	}
+	sort.Sort(ordered.ByID(nodes))
+	if n > len(nodes) {
+		n = len(nodes)
+	}
+
+	// nearest holds the distance from the nearest
+	// landmark to each node.
+	nearest := make([]float64, len(nodes))
+	for i := range nearest {
+		nearest[i] = math.Inf(1)
+	}
+	next := nodes[0]
+	for len(l.landmarks) < n {
+		l.add(next, nodes)
+		fwd := l.from[len(l.from)-1]
+		far := -1
+		for i, v := range nodes {
+			nearest[i] = math.Min(nearest[i], fwd.WeightTo(v.ID()))
+			if nearest[i] != 0 && (far < 0 || nearest[i] > nearest[far]) {
+				far = i
+			}
+		}
+		if far < 0 {
+			break
+		}
+		next = nodes[far]
+	}
+	return l
+}
+
+// add adds u to the landmarks of l.
+func (l *LandmarkSearcher) add(u graph.Node, nodes []graph.Node) {
+	fwd := newShortestFrom(u, nodes)
+	dijkstraFrom(u, l.g, l.weight, &fwd)
+	bwd := fwd
+	if d, ok := l.g.(graph.Directed); ok {
+		bwd = newShortestFrom(u, nodes)
+		dijkstraFrom(u, reversedGraph{d}, reversedWeight(l.weight), &bwd)
+	}
+	l.landmarks = append(l.landmarks, u)
+	l.from = append(l.from, fwd)
+	l.to = append(l.to, bwd)
+}
+
+// Landmarks returns the landmark nodes of l.
+func (l *LandmarkSearcher) Landmarks() []graph.Node {
+	return append([]graph.Node(nil), l.landmarks...)
+}
+
+// HeuristicCost returns the ALT lower bound on the cost of the shortest path from x
+// to y. For each landmark L, the triangle inequality bounds the cost below by both
+// d(x, L) - d(y, L) and d(L, y) - d(L, x), and the largest bound is returned. Bounds
+// involving unreachable nodes are ignored. HeuristicCost is admissible and
+// consistent.
+func (l *LandmarkSearcher) HeuristicCost(x, y graph.Node) float64 {
+	xid, yid := x.ID(), y.ID()
+	var h float64
+	for i := range l.landmarks {
+		if dx, dy := l.to[i].WeightTo(xid), l.to[i].WeightTo(yid); !math.IsInf(dx, 1) && !math.IsInf(dy, 1) {
+			h = math.Max(h, dx-dy)
+		}
+		if dx, dy := l.from[i].WeightTo(xid), l.from[i].WeightTo(yid); !math.IsInf(dx, 1) && !math.IsInf(dy, 1) {
+			h = math.Max(h, dy-dx)
+		}
+	}
+	return h
+}
+
+// Search returns the A*-shortest path from s to t and its cost.
+func (l *LandmarkSearcher) Search(s, t graph.Node) ([]graph.Node, float64) {
+	if l.g.Node(s.ID()) == nil || l.g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	pt, _ := aStar(s, t, l.g, l.weight, l.HeuristicCost)
+	return pt.To(t.ID())
+}
@@ -0,0 +1,95 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestLandmarkSearcher(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		g    graph.Weighted
+	}{
+		{name: "grid", g: randomWeightedGrid(10, randomIntWeight(rand.NewSource(1)))},
+		{name: "undirected", g: randomWeightedGraph(60, 2, 40, false, rand.NewSource(2))},
+		{name: "directed", g: randomWeightedGraph(60, 2, 40, true, rand.NewSource(3))},
+	} {
+		for _, landmarks := range []int{1, 4, 1000} {
+			l := NewLandmarkSearcher(test.g, nil, landmarks)
+			nodes := graph.NodesOf(test.g.Nodes())
+			want := landmarks
+			if want > len(nodes) {
+				want = len(nodes)
+			}
+			if got := len(l.Landmarks()); got != want {
+				t.Errorf("%s: unexpected number of landmarks: got:%d want:%d", test.name, got, want)
+			}
+
+			rnd := rand.New(rand.NewSource(4))
+			for trial := 0; trial < 50; trial++ {
+				s, d := nodes[rnd.Intn(len(nodes))], nodes[rnd.Intn(len(nodes))]
+				_, want := dijkstraCost(s, d, test.g, test.g.Weight)
+				if h := l.HeuristicCost(s, d); h > want {
+					t.Errorf("%s landmarks=%d: inadmissible heuristic for %d->%d: got:%v cost:%v",
+						test.name, landmarks, s.ID(), d.ID(), h, want)
+				}
+				p, cost := l.Search(s, d)
+				if cost != want {
+					t.Errorf("%s landmarks=%d: unexpected cost for %d->%d: got:%v want:%v",
+						test.name, landmarks, s.ID(), d.ID(), cost, want)
+				}
+				if math.IsInf(want, 1) {
+					if p != nil {
+						t.Errorf("%s landmarks=%d: unexpected path for unreachable %d->%d: %v",
+							test.name, landmarks, s.ID(), d.ID(), ids(p))
+					}
+					continue
+				}
+				if !topo.IsPathIn(test.g, p) || p[0].ID() != s.ID() || p[len(p)-1].ID() != d.ID() || weightOf(p, test.g.Weight) != cost {
+					t.Errorf("%s landmarks=%d: invalid path for %d->%d: %v", test.name, landmarks, s.ID(), d.ID(), ids(p))
+				}
+			}
+		}
+	}
+
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	g.AddNode(simple.Node(2))
+	l := NewLandmarkSearcher(g, nil, 2)
+	if got := ids(l.Landmarks()); len(got) != 2 || got[0] != 0 || got[1] != 2 {
+		t.Errorf("unexpected landmarks: got:%v want:[0 2]", got)
+	}
+	if p, cost := l.Search(simple.Node(1), simple.Node(0)); p != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected path to unreachable node: got:%v cost:%v", ids(p), cost)
+	}
+	if p, cost := l.Search(simple.Node(0), simple.Node(3)); p != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected path to absent node: got:%v cost:%v", ids(p), cost)
+	}
+}
+
+func BenchmarkLandmarkSearcher(b *testing.B) {
+	l := NewLandmarkSearcher(chBenchGraph, nil, 8)
+	benchmarkSearcher(b, l)
+}
+
+func BenchmarkLandmarkSearcherAStar(b *testing.B) {
+	benchmarkSearcher(b, AStarSearcher{Graph: chBenchGraph})
+}
+
+func benchmarkSearcher(b *testing.B, s Searcher) {
+	rnd := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Search(simple.Node(rnd.Intn(chBenchSize*chBenchSize)), simple.Node(rnd.Intn(chBenchSize*chBenchSize)))
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import "gonum.org/v1/gonum/graph"
+
+// CongestionCost returns a Weighting that adds a congestion penalty to the edge
+// costs given by weight. The cost of an edge is increased by penalty times the
+// load of the node it enters, as held in load. The load map is consulted on each
+// call, so changes to it are reflected in subsequent searches using the returned
+// Weighting.
+func CongestionCost(weight Weighting, load map[int64]float64, penalty float64) Weighting {
+	return func(xid, yid int64) (w float64, ok bool) {
+		w, ok = weight(xid, yid)
+		if !ok || xid == yid {
+			return w, ok
+		}
+		return w + penalty*load[yid], true
+	}
+}
+
+// LoadBalancedRouting returns paths for the {from, to} node pairs in demands through
+// g chosen to reduce the maximum number of paths passing through any single node.
+// Each demand is first routed along its A*-shortest path. Then, in each of the given
+// number of iterations, each demand is rerouted in turn with edge costs penalized by
+// CongestionCost using the load of the other paths, with a penalty that increases
+// with each iteration. The routing with the lowest peak node load found, breaking
+// ties by total path cost, is returned. The end points of a path count toward the
+// load of their nodes.
+//
+// The returned paths correspond to the demands; the path for a demand without a
+// path is nil. LoadBalancedRouting is a heuristic traffic assignment; the returned
+// routing is not guaranteed to minimize the peak load, but its peak load is never
+// greater than that of independent shortest path routing.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. The HeuristicCost method of g is used to guide the
+// searches if g implements HeuristicCoster. LoadBalancedRouting will panic if g has
+// an A*-reachable negative edge weight.
+func LoadBalancedRouting(demands [][2]graph.Node, g graph.Graph, weight Weighting, iterations int) [][]graph.Node {
+	weight = weightFor(g, weight)
+	h := heuristicFor(g, nil)
+
+	load := make(map[int64]float64)
+	addLoad := func(p []graph.Node, delta float64) {
+		for _, n := range p {
+			load[n.ID()] += delta
+		}
+	}
+	route := func(d [2]graph.Node, weight Weighting) []graph.Node {
+		s, t := d[0], d[1]
+		if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+			return nil
+		}
+		pt, _ := aStar(s, t, g, weight, h)
+		p, _ := pt.To(t.ID())
+		return p
+	}
+
+	paths := make([][]graph.Node, len(demands))
+	var edges int
+	var total float64
+	for i, d := range demands {
+		paths[i] = route(d, weight)
+		addLoad(paths[i], 1)
+		if len(paths[i]) > 1 {
+			edges += len(paths[i]) - 1
+			total += weightOf(paths[i], weight)
+		}
+	}
+	best := append([][]graph.Node(nil), paths...)
+	bestPeak, bestCost := routingLoad(paths, weight)
+
+	// Scale the penalty to the mean edge cost so
+	// that it is comparable to the path costs.
+	scale := 1.0
+	if edges != 0 && total != 0 {
+		scale = total / float64(edges)
+	}
+	for it := 1; it <= iterations; it++ {
+		congested := CongestionCost(weight, load, float64(it)*scale)
+		for i, d := range demands {
+			if paths[i] == nil {
+				continue
+			}
+			addLoad(paths[i], -1)
+			paths[i] = route(d, congested)
+			addLoad(paths[i], 1)
+		}
+		peak, cost := routingLoad(paths, weight)
+		if peak < bestPeak || (peak == bestPeak && cost < bestCost) {
+			copy(best, paths)
+			bestPeak, bestCost = peak, cost
+		}
+	}
+	return best
+}
+
+// routingLoad returns the maximum number of paths visiting any
+// node in paths and the total cost of the paths.
+func routingLoad(paths [][]graph.Node, weight Weighting) (peak int, cost float64) {
+	load := make(map[int64]int)
+	for _, p := range paths {
+		for _, n := range p {
+			id := n.ID()
+			load[id]++
+			if load[id] > peak {
+				peak = load[id]
+			}
+		}
+		cost += weightOf(p, weight)
+	}
+	return peak, cost
+}
@@ -0,0 +1,111 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestCongestionCost(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 2})
+	load := map[int64]float64{1: 3}
+	w := CongestionCost(g.Weight, load, 0.5)
+	if got, ok := w(0, 1); !ok || got != 3.5 {
+		t.Errorf("unexpected congested cost: got:%v,%t want:3.5,true", got, ok)
+	}
+	if got, ok := w(1, 1); !ok || got != 0 {
+		t.Errorf("unexpected self cost: got:%v,%t want:0,true", got, ok)
+	}
+	if _, ok := w(1, 0); ok {
+		t.Error("unexpected cost for absent edge")
+	}
+	load[1] = 0
+	if got, _ := w(0, 1); got != 2 {
+		t.Errorf("load change not reflected in cost: got:%v want:2", got)
+	}
+}
+
+func TestLoadBalancedRouting(t *testing.T) {
+	// Each demand from node i to node 10+i can pass through
+	// the shared hub 100 at cost 2 or a private node 20+i
+	// at cost 3.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	var demands [][2]graph.Node
+	for i := 0; i < 5; i++ {
+		s, t, a := simple.Node(i), simple.Node(10+i), simple.Node(20+i)
+		g.SetWeightedEdge(simple.WeightedEdge{F: s, T: simple.Node(100), W: 1})
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(100), T: t, W: 1})
+		g.SetWeightedEdge(simple.WeightedEdge{F: s, T: a, W: 1.5})
+		g.SetWeightedEdge(simple.WeightedEdge{F: a, T: t, W: 1.5})
+		demands = append(demands, [2]graph.Node{s, t})
+	}
+
+	independent := LoadBalancedRouting(demands, g, nil, 0)
+	peak, _ := routingLoad(independent, g.Weight)
+	if peak != len(demands) {
+		t.Fatalf("unexpected independent peak load: got:%d want:%d", peak, len(demands))
+	}
+	balanced := LoadBalancedRouting(demands, g, nil, 5)
+	checkRouting(t, demands, balanced, g)
+	if peak, _ := routingLoad(balanced, g.Weight); peak != 1 {
+		t.Errorf("unexpected balanced peak load: got:%d want:1", peak)
+	}
+}
+
+func TestLoadBalancedRoutingGrid(t *testing.T) {
+	const n = 10
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			id := int64(r*n + c)
+			if c+1 < n {
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(id), T: simple.Node(id + 1), W: 1})
+			}
+			if r+1 < n {
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(id), T: simple.Node(id + n), W: 1})
+			}
+		}
+	}
+	rnd := rand.New(rand.NewSource(1))
+	var demands [][2]graph.Node
+	for i := 0; i < 30; i++ {
+		demands = append(demands, [2]graph.Node{
+			simple.Node(rnd.Intn(n) * n),
+			simple.Node(rnd.Intn(n)*n + n - 1),
+		})
+	}
+
+	independent, _ := routingLoad(LoadBalancedRouting(demands, g, nil, 0), g.Weight)
+	paths := LoadBalancedRouting(demands, g, nil, 10)
+	checkRouting(t, demands, paths, g)
+	if balanced, _ := routingLoad(paths, g.Weight); balanced >= independent {
+		t.Errorf("peak load not reduced: got:%d independent:%d", balanced, independent)
+	}
+}
+
+func checkRouting(t *testing.T, demands [][2]graph.Node, paths [][]graph.Node, g graph.Graph) {
+	t.Helper()
+	if len(paths) != len(demands) {
+		t.Fatalf("unexpected number of paths: got:%d want:%d", len(paths), len(demands))
+	}
+	for i, p := range paths {
+		if len(p) == 0 || p[0].ID() != demands[i][0].ID() || p[len(p)-1].ID() != demands[i][1].ID() {
+			t.Errorf("path %d does not join demand end points: %v", i, ids(p))
+			continue
+		}
+		for j := 1; j < len(p); j++ {
+			if !g.HasEdgeBetween(p[j-1].ID(), p[j].ID()) {
+				t.Errorf("path %d uses absent edge %d-%d", i, p[j-1].ID(), p[j].ID())
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// AStarMaxStraightRun finds the A*-shortest path from s to t in g that does not
+// contain more than maxRun consecutive edges sharing a direction. The direction of
+// an edge from u to v is given by directionOf(u, v). The path and its cost are
+// returned. If no path satisfies the constraint, the path is nil and the cost is
+// +Inf.
+//
+// The search is performed over (node, last direction, run length) states, so
+// each node may be expanded up to maxRun times for each direction.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted,
+// falling back to UniformCost otherwise. If h is nil, AStarMaxStraightRun will
+// use the g.HeuristicCost method if g implements HeuristicCoster, falling back
+// to NullHeuristic otherwise. AStarMaxStraightRun will panic if g has an
+// A*-reachable negative edge weight.
+func AStarMaxStraightRun(s, t graph.Node, maxRun int, g graph.Graph, weight Weighting, directionOf func(from, to graph.Node) int, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil || maxRun < 1 {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+
+	// straightRun is the search state of a
+	// label. The start label has run zero.
+	type straightRun struct {
+		dir int
+		run int
+	}
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		cur := u.state.(straightRun)
+		uid := u.node.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			w, ok := weight(uid, v.ID())
+			if !ok {
+				panic("A*: unexpected invalid weight")
+			}
+			next := straightRun{dir: directionOf(u.node, v), run: 1}
+			if cur.run != 0 && next.dir == cur.dir {
+				next.run = cur.run + 1
+			}
+			if next.run > maxRun {
+				continue
+			}
+			yield(v, next, w)
+		}
+	}
+	l, _ := stateAStar(s, straightRun{}, t, heuristicFor(g, h), expand, isNode(t))
+	if l == nil {
+		return nil, math.Inf(1)
+	}
+	return l.path(), l.gscore
+}
@@ -0,0 +1,85 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// gridDirection returns a direction function for the grid g that
+// encodes the row and column step of an edge.
+func gridDirection(g *testgraphs.Grid) func(from, to graph.Node) int {
+	return func(from, to graph.Node) int {
+		fr, fc := g.RowCol(from.ID())
+		tr, tc := g.RowCol(to.ID())
+		return 3*(tr-fr+1) + (tc - fc + 1)
+	}
+}
+
+// longestRun returns the longest run of consecutive edges in path
+// sharing a direction.
+func longestRun(path []graph.Node, dir func(from, to graph.Node) int) int {
+	var longest, run int
+	for i := 1; i < len(path); i++ {
+		if i > 1 && dir(path[i-1], path[i]) == dir(path[i-2], path[i-1]) {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
+
+func TestAStarMaxStraightRun(t *testing.T) {
+	g := testgraphs.NewGrid(5, 8, true)
+	g.UnitEdgeWeight = true
+	dir := gridDirection(g)
+	manhattan := func(x, y graph.Node) float64 {
+		xr, xc := g.RowCol(x.ID())
+		yr, yc := g.RowCol(y.ID())
+		return math.Abs(float64(xr-yr)) + math.Abs(float64(xc-yc))
+	}
+	s, goal := g.NodeAt(2, 0), g.NodeAt(2, 7)
+
+	for _, test := range []struct {
+		maxRun   int
+		wantCost float64
+	}{
+		{maxRun: 7, wantCost: 7},
+		{maxRun: 3, wantCost: 9},
+		{maxRun: 1, wantCost: 13},
+	} {
+		p, cost := AStarMaxStraightRun(s, goal, test.maxRun, g, nil, dir, manhattan)
+		if p == nil {
+			t.Errorf("maxRun=%d: unexpected nil path", test.maxRun)
+			continue
+		}
+		if !topo.IsPathIn(g, p) || p[0].ID() != s.ID() || p[len(p)-1].ID() != goal.ID() {
+			t.Errorf("maxRun=%d: invalid path: %v", test.maxRun, p)
+		}
+		if cost != test.wantCost {
+			t.Errorf("maxRun=%d: unexpected cost: got:%v want:%v", test.maxRun, cost, test.wantCost)
+		}
+		if run := longestRun(p, dir); run > test.maxRun {
+			t.Errorf("maxRun=%d: path has straight run of %d", test.maxRun, run)
+		}
+	}
+
+	// A single row grid cannot be traversed beyond the
+	// first step without consecutive edges in one direction.
+	line := testgraphs.NewGrid(1, 4, true)
+	p, cost := AStarMaxStraightRun(line.NodeAt(0, 0), line.NodeAt(0, 3), 1, line, nil, gridDirection(line), nil)
+	if p != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected path for infeasible run limit: got:%v cost:%v", p, cost)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// MeetingPoint returns the node in g that minimizes the maximum of the shortest
+// path distances from a and from b, and that distance. Ties are broken by choosing
+// the node with the lowest ID. If no node is reachable from both a and b, the
+// returned node is nil and the distance is +Inf.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted,
+// falling back to UniformCost otherwise. MeetingPoint will panic if g has an a- or
+// b-reachable negative edge weight.
+func MeetingPoint(a, b graph.Node, g graph.Graph, weight Weighting) (graph.Node, float64) {
+	if g.Node(a.ID()) == nil || g.Node(b.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+
+	nodes := graph.NodesOf(g.Nodes())
+	fromA := newShortestFrom(a, nodes)
+	dijkstraFrom(a, g, weight, &fromA)
+	fromB := newShortestFrom(b, nodes)
+	dijkstraFrom(b, g, weight, &fromB)
+
+	var (
+		meet graph.Node
+		best = math.Inf(1)
+	)
+	for _, n := range nodes {
+		d := math.Max(fromA.WeightTo(n.ID()), fromB.WeightTo(n.ID()))
+		if d < best || (d == best && meet != nil && n.ID() < meet.ID()) {
+			meet = n
+			best = d
+		}
+	}
+	return meet, best
+}
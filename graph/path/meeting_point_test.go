@@ -0,0 +1,83 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var meetingPointTests = []struct {
+	name  string
+	edges []simple.WeightedEdge
+	nodes int64
+
+	a, b     int64
+	want     int64
+	wantDist float64
+}{
+	{
+		name: "odd path",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+			{F: simple.Node(3), T: simple.Node(4), W: 1},
+		},
+		a: 0, b: 4,
+		want: 2, wantDist: 2,
+	},
+	{
+		name: "even path",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+		},
+		a: 3, b: 0,
+		want: 1, wantDist: 2,
+	},
+	{
+		name: "weighted path",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 4},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+		},
+		a: 0, b: 3,
+		want: 1, wantDist: 4,
+	},
+	{
+		name: "disconnected",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+		},
+		a: 0, b: 3,
+		want: -1, wantDist: math.Inf(1),
+	},
+}
+
+func TestMeetingPoint(t *testing.T) {
+	for _, test := range meetingPointTests {
+		g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		for _, e := range test.edges {
+			g.SetWeightedEdge(e)
+		}
+		got, dist := MeetingPoint(simple.Node(test.a), simple.Node(test.b), g, nil)
+		gotID := int64(-1)
+		if got != nil {
+			gotID = got.ID()
+		}
+		if gotID != test.want {
+			t.Errorf("%q: unexpected meeting point: got:%d want:%d", test.name, gotID, test.want)
+		}
+		if dist != test.wantDist {
+			t.Errorf("%q: unexpected meeting distance: got:%v want:%v", test.name, dist, test.wantDist)
+		}
+	}
+}
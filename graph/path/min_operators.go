@@ -0,0 +1,90 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// MinOperatorsAStar finds a path from s to t in g that uses the fewest distinct
+// edge operators, where the operator of each edge is given by operatorOf. Among
+// the paths with the fewest operators, the A*-shortest is returned. The path, the
+// number of distinct operators along it and its cost are returned. If t is not
+// reachable from s, the path is nil, the count is zero and the cost is +Inf.
+//
+// The search is performed over (node, set of operators used) states for
+// increasing limits on the size of the set. The number of states is exponential
+// in the number of distinct operators in g in the worst case, so MinOperatorsAStar
+// is only suitable for graphs with a small number of operators.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, MinOperatorsAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. MinOperatorsAStar will panic if g has an A*-reachable
+// negative edge weight.
+func MinOperatorsAStar(s, t graph.Node, g graph.Directed, operatorOf func(graph.Edge) string, weight Weighting, h Heuristic) (path []graph.Node, operators int, cost float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, 0, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+	h = heuristicFor(g, h)
+
+	// Index the operators of g so that operator
+	// sets can be held as comparable keys.
+	index := make(map[string]int)
+	nodes := g.Nodes()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		to := g.From(uid)
+		for to.Next() {
+			op := operatorOf(g.Edge(uid, to.Node().ID()))
+			if _, ok := index[op]; !ok {
+				index[op] = len(index)
+			}
+		}
+	}
+
+	// The state of a label is the set of indices of
+	// the operators used, encoded by encodeIDSet.
+	with := func(state string, op int64) string {
+		used := decodeIDSet(state)
+		i := sort.Search(len(used), func(i int) bool { return used[i] >= op })
+		if i < len(used) && used[i] == op {
+			return state
+		}
+		used = append(used, 0)
+		copy(used[i+1:], used[i:])
+		used[i] = op
+		return encodeIDSet(used)
+	}
+	for limit := 0; limit <= len(index); limit++ {
+		expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+			used := u.state.(string)
+			uid := u.node.ID()
+			to := g.From(uid)
+			for to.Next() {
+				v := to.Node()
+				vid := v.ID()
+				next := with(used, int64(index[operatorOf(g.Edge(uid, vid))]))
+				if len(next)/8 > limit {
+					continue
+				}
+				w, ok := weight(uid, vid)
+				if !ok {
+					panic("A*: unexpected invalid weight")
+				}
+				yield(v, next, w)
+			}
+		}
+		l, _ := stateAStar(s, "", t, h, expand, isNode(t))
+		if l != nil {
+			return l.path(), len(l.state.(string)) / 8, l.gscore
+		}
+	}
+	return nil, 0, math.Inf(1)
+}
@@ -0,0 +1,66 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestMinOperatorsAStar(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	operator := make(map[[2]int64]string)
+	for _, e := range []struct {
+		from, to int64
+		op       string
+		w        float64
+	}{
+		// A cheap route changing operator at 1.
+		{from: 0, to: 1, op: "A", w: 1},
+		{from: 1, to: 3, op: "B", w: 1},
+
+		// Two single operator routes.
+		{from: 0, to: 2, op: "A", w: 2},
+		{from: 2, to: 3, op: "A", w: 2},
+		{from: 0, to: 4, op: "A", w: 3},
+		{from: 4, to: 3, op: "A", w: 3},
+
+		{from: 5, to: 0, op: "C", w: 1},
+	} {
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(e.from), T: simple.Node(e.to), W: e.w})
+		operator[[2]int64{e.from, e.to}] = e.op
+	}
+	operatorOf := func(e graph.Edge) string {
+		return operator[[2]int64{e.From().ID(), e.To().ID()}]
+	}
+
+	for _, test := range []struct {
+		s, t      int64
+		want      []int64
+		operators int
+		cost      float64
+	}{
+		{s: 0, t: 3, want: []int64{0, 2, 3}, operators: 1, cost: 4},
+		{s: 1, t: 3, want: []int64{1, 3}, operators: 1, cost: 1},
+		{s: 5, t: 3, want: []int64{5, 0, 2, 3}, operators: 2, cost: 5},
+		{s: 3, t: 3, want: []int64{3}, operators: 0, cost: 0},
+		{s: 3, t: 0, want: nil, operators: 0, cost: math.Inf(1)},
+	} {
+		p, n, cost := MinOperatorsAStar(simple.Node(test.s), simple.Node(test.t), g, operatorOf, nil, nil)
+		if got := ids(p); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected path from %d to %d: got:%v want:%v", test.s, test.t, got, test.want)
+		}
+		if n != test.operators {
+			t.Errorf("unexpected operator count from %d to %d: got:%d want:%d", test.s, test.t, n, test.operators)
+		}
+		if cost != test.cost {
+			t.Errorf("unexpected cost from %d to %d: got:%v want:%v", test.s, test.t, cost, test.cost)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"gonum.org/v1/gonum/graph"
+)
+
+// MovingGoalSearcher steps an agent along shortest paths towards a goal that may
+// change between steps, as in pursuit of a moving target. The most recently planned
+// path is retained, and since every suffix of a shortest path is itself a shortest
+// path, a step from a node on that path towards the same goal is taken without
+// searching. A new A* search is performed when the goal changes or the agent has
+// left the planned path.
+//
+// MovingGoalSearcher assumes that the graph and edge weights do not change; if they
+// do, a new MovingGoalSearcher must be created. A MovingGoalSearcher is not safe for
+// concurrent use.
+type MovingGoalSearcher struct {
+	g      graph.Graph
+	weight Weighting
+	h      Heuristic
+
+	// path is the most recently planned path
+	// towards goal and index holds the position
+	// of each node in path.
+	goal  graph.Node
+	path  []graph.Node
+	index map[int64]int
+}
+
+// NewMovingGoalSearcher returns a MovingGoalSearcher for paths in g.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, the g.HeuristicCost method is used if g
+// implements HeuristicCoster, falling back to NullHeuristic otherwise.
+func NewMovingGoalSearcher(g graph.Graph, weight Weighting, h Heuristic) *MovingGoalSearcher {
+	return &MovingGoalSearcher{
+		g:      g,
+		weight: weightFor(g, weight),
+		h:      heuristicFor(g, h),
+	}
+}
+
+// Step returns the node following current on a shortest path from current to goal.
+// If current is goal, goal is returned. If goal is not reachable from current, Step
+// returns nil. Step will panic if g has an A*-reachable negative edge weight.
+func (m *MovingGoalSearcher) Step(current, goal graph.Node) graph.Node {
+	cid, gid := current.ID(), goal.ID()
+	if cid == gid {
+		return goal
+	}
+	if m.goal == nil || m.goal.ID() != gid || !m.onPath(cid) {
+		m.plan(current, goal)
+	}
+	i, ok := m.index[cid]
+	if !ok {
+		return nil
+	}
+	return m.path[i+1]
+}
+
+// onPath returns whether the node with the given ID is on the planned
+// path and is not its final node.
+func (m *MovingGoalSearcher) onPath(id int64) bool {
+	i, ok := m.index[id]
+	return ok && i < len(m.path)-1
+}
+
+// plan finds and retains the A*-shortest path from s to t.
+func (m *MovingGoalSearcher) plan(s, t graph.Node) {
+	m.goal = t
+	m.path = nil
+	m.index = nil
+	if m.g.Node(s.ID()) == nil || m.g.Node(t.ID()) == nil {
+		return
+	}
+	pt, _ := aStar(s, t, m.g, m.weight, m.h)
+	m.path, _ = pt.To(t.ID())
+	m.index = make(map[int64]int, len(m.path))
+	for i, n := range m.path {
+		m.index[n.ID()] = i
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestMovingGoalSearcher(t *testing.T) {
+	const n = 8
+	g := randomWeightedGrid(n, randomIntWeight(rand.NewSource(1)))
+	var calls int
+	h := func(x, y graph.Node) float64 {
+		calls++
+		return 0
+	}
+	m := NewMovingGoalSearcher(g, nil, h)
+
+	// Repeated steps towards a fixed goal follow
+	// the shortest path found by a single search.
+	start, goal := simple.Node(0), simple.Node(n*n-1)
+	want, _ := DijkstraFrom(start, g).To(goal.ID())
+	got := []graph.Node{start}
+	var searched int
+	for cur := graph.Node(start); cur.ID() != goal.ID(); {
+		cur = m.Step(cur, goal)
+		if cur == nil {
+			t.Fatalf("unexpected nil step after %v", ids(got))
+		}
+		got = append(got, cur)
+		if len(got) == 2 {
+			searched = calls
+		} else if calls != searched {
+			t.Fatalf("unexpected search at step %d towards unchanged goal", len(got)-1)
+		}
+		if len(got) > n*n {
+			t.Fatalf("path does not reach goal: %v", ids(got))
+		}
+	}
+	if !reflect.DeepEqual(ids(got), ids(want)) {
+		t.Errorf("unexpected trace: got:%v want:%v", ids(got), ids(want))
+	}
+	if next := m.Step(goal, goal); next.ID() != goal.ID() {
+		t.Errorf("unexpected step at goal: got:%d want:%d", next.ID(), goal.ID())
+	}
+
+	// Changing the goal causes a new search, and the
+	// step follows the shortest path to the new goal.
+	cur := want[len(want)/2]
+	for _, goal := range []graph.Node{simple.Node(n - 1), simple.Node(n * (n - 1)), simple.Node(0)} {
+		before := calls
+		next := m.Step(cur, goal)
+		if calls == before {
+			t.Errorf("no search after change of goal to %d", goal.ID())
+		}
+		p, _ := DijkstraFrom(cur, g).To(goal.ID())
+		if next == nil || next.ID() != p[1].ID() {
+			t.Errorf("unexpected step from %d towards %d: got:%v want:%d", cur.ID(), goal.ID(), next, p[1].ID())
+		}
+	}
+
+	// Leaving the planned path causes a new search.
+	before := calls
+	if next := m.Step(simple.Node(n*n-1), simple.Node(0)); next == nil {
+		t.Error("unexpected nil step from off the planned path")
+	}
+	if calls == before {
+		t.Error("no search after leaving the planned path")
+	}
+
+	// Unreachable goals give no step.
+	g.(*simple.WeightedUndirectedGraph).AddNode(simple.Node(n * n))
+	if next := m.Step(simple.Node(0), simple.Node(n*n)); next != nil {
+		t.Errorf("unexpected step towards unreachable goal: got:%d", next.ID())
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import "gonum.org/v1/gonum/graph"
+
+// MultiCommoditySequential greedily routes the {from, to} node pairs in demands
+// through g one at a time in order, accounting for node capacity consumed by the
+// earlier routes. The capacity of each node is given by nodeCapacity and each
+// routed path consumes one unit of capacity at every node it visits, including its
+// end points. A demand is routed along the A*-shortest path through nodes with at
+// least one unit of residual capacity.
+//
+// The returned paths correspond to the demands; the path for a demand that could
+// not be routed is nil and consumes no capacity. The returned boolean reports
+// whether all the demands were routed. Being greedy, MultiCommoditySequential
+// may fail to route demands that could be satisfied by a different choice of
+// earlier routes.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. The HeuristicCost method of g is used to guide the
+// searches if g implements HeuristicCoster. MultiCommoditySequential will panic
+// if g has a reachable negative edge weight.
+func MultiCommoditySequential(demands [][2]graph.Node, nodeCapacity func(graph.Node) float64, g graph.Graph, weight Weighting) (paths [][]graph.Node, ok bool) {
+	weight = weightFor(g, weight)
+	h := heuristicFor(g, nil)
+
+	used := make(map[int64]float64)
+	hasCapacity := func(n graph.Node) bool {
+		return nodeCapacity(n)-used[n.ID()] >= 1
+	}
+	view := filteredGraph{Graph: g, canEnter: hasCapacity}
+
+	paths = make([][]graph.Node, len(demands))
+	ok = true
+	for i, d := range demands {
+		s, t := d[0], d[1]
+		if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil || !hasCapacity(s) {
+			ok = false
+			continue
+		}
+		pt, _ := aStar(s, t, view, weight, h)
+		p, _ := pt.To(t.ID())
+		if p == nil {
+			ok = false
+			continue
+		}
+		for _, n := range p {
+			used[n.ID()]++
+		}
+		paths[i] = p
+	}
+	return paths, ok
+}
@@ -0,0 +1,87 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestMultiCommoditySequential(t *testing.T) {
+	// Two sources 0 and 1 and two sinks 5 and 6 joined
+	// through a short hub at 2 and a longer route 3-4.
+	//
+	//  0       5
+	//   \     /
+	//    - 2 -
+	//   / \ / \
+	//  1   3-4 6
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(2), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(5), W: 1},
+		{F: simple.Node(2), T: simple.Node(6), W: 1},
+		{F: simple.Node(0), T: simple.Node(3), W: 2},
+		{F: simple.Node(1), T: simple.Node(3), W: 2},
+		{F: simple.Node(3), T: simple.Node(4), W: 2},
+		{F: simple.Node(4), T: simple.Node(5), W: 2},
+		{F: simple.Node(4), T: simple.Node(6), W: 2},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	demands := [][2]graph.Node{
+		{simple.Node(0), simple.Node(5)},
+		{simple.Node(1), simple.Node(6)},
+	}
+
+	for _, test := range []struct {
+		name     string
+		capacity map[int64]float64
+		want     [][]int64
+		wantOK   bool
+	}{
+		{
+			name:     "ample capacity",
+			capacity: map[int64]float64{2: 2, 3: 2, 4: 2},
+			want:     [][]int64{{0, 2, 5}, {1, 2, 6}},
+			wantOK:   true,
+		},
+		{
+			name:     "saturated hub",
+			capacity: map[int64]float64{2: 1, 3: 1, 4: 1},
+			want:     [][]int64{{0, 2, 5}, {1, 3, 4, 6}},
+			wantOK:   true,
+		},
+		{
+			name:     "no alternative",
+			capacity: map[int64]float64{2: 1, 3: 0, 4: 0},
+			want:     [][]int64{{0, 2, 5}, nil},
+			wantOK:   false,
+		},
+	} {
+		capacity := func(n graph.Node) float64 {
+			if c, ok := test.capacity[n.ID()]; ok {
+				return c
+			}
+			return 1
+		}
+		paths, ok := MultiCommoditySequential(demands, capacity, g, nil)
+		if ok != test.wantOK {
+			t.Errorf("%s: unexpected routing success: got:%t want:%t", test.name, ok, test.wantOK)
+		}
+		var got [][]int64
+		for _, p := range paths {
+			got = append(got, ids(p))
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: unexpected paths: got:%v want:%v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// MultiModalAStar finds the A*-shortest path from s to t in g where each edge belongs
+// to a transport mode given by modeOf, and changing between modes at a node costs
+// transferPenalty(fromMode, toMode) in addition to the edge weights. No penalty is
+// applied to the first edge of the path. If transferPenalty is nil, transfers are
+// free. The path and its cost, including transfer penalties, are returned. If t is not
+// reachable from s, the path is nil and the cost is +Inf.
+//
+// The search is performed over (node, last mode) states, so a node may be expanded
+// once for each mode by which it can be reached.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, MultiModalAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. A heuristic admissible for the edge weights remains so for
+// non-negative transfer penalties. MultiModalAStar will panic if g has an
+// A*-reachable negative edge weight or transfer penalty.
+func MultiModalAStar(s, t graph.Node, g graph.Directed, weight Weighting, modeOf func(graph.Edge) string, transferPenalty func(fromMode, toMode string) float64, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+
+	// The state of a label is the mode of the edge
+	// used to reach it, or nil for the start label.
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		uid := u.node.ID()
+		last, transferring := u.state.(string)
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			w, ok := weight(uid, vid)
+			if !ok {
+				panic("A*: unexpected invalid weight")
+			}
+			mode := modeOf(g.Edge(uid, vid))
+			if transferring && transferPenalty != nil && mode != last {
+				w += transferPenalty(last, mode)
+			}
+			yield(v, mode, w)
+		}
+	}
+	l, _ := stateAStar(s, nil, t, heuristicFor(g, h), expand, isNode(t))
+	if l == nil {
+		return nil, math.Inf(1)
+	}
+	return l.path(), l.gscore
+}
@@ -0,0 +1,66 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestMultiModalAStar(t *testing.T) {
+	// Walking from 0 to 1 and taking the train from 1 to 3
+	// is fastest, but walking all the way avoids a transfer.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	modes := map[[2]int64]string{
+		{0, 1}: "walk",
+		{1, 3}: "train",
+		{1, 2}: "walk",
+		{2, 3}: "walk",
+		{0, 4}: "train",
+		{4, 3}: "train",
+	}
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(3), W: 2},
+		{F: simple.Node(0), T: simple.Node(4), W: 4},
+		{F: simple.Node(4), T: simple.Node(3), W: 4},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	modeOf := func(e graph.Edge) string { return modes[[2]int64{e.From().ID(), e.To().ID()}] }
+	penalty := func(cost float64) func(from, to string) float64 {
+		return func(from, to string) float64 {
+			if from == "walk" && to == "train" {
+				return cost
+			}
+			return 0
+		}
+	}
+
+	for _, test := range []struct {
+		name     string
+		penalty  func(from, to string) float64
+		wantPath []int64
+		wantCost float64
+	}{
+		{name: "no penalty", penalty: nil, wantPath: []int64{0, 1, 3}, wantCost: 2},
+		{name: "small penalty", penalty: penalty(1), wantPath: []int64{0, 1, 3}, wantCost: 3},
+		{name: "large penalty", penalty: penalty(10), wantPath: []int64{0, 1, 2, 3}, wantCost: 5},
+	} {
+		p, cost := MultiModalAStar(simple.Node(0), simple.Node(3), g, nil, modeOf, test.penalty, nil)
+		if got := ids(p); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("%s: unexpected path: got:%v want:%v", test.name, got, test.wantPath)
+		}
+		if cost != test.wantCost {
+			t.Errorf("%s: unexpected cost: got:%v want:%v", test.name, cost, test.wantCost)
+		}
+	}
+}
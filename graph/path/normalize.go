@@ -0,0 +1,64 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// NormalizeCost returns a Weighting that maps the edge costs of g given by weight
+// linearly onto [0, 1], along with the minimum and maximum costs found among the
+// edges of g. Edges with an invalid or infinite cost are excluded from the range
+// and their costs are returned unaltered by the normalized Weighting, as are self
+// connection costs. The normalized cost of each edge is
+//
+//  (w - lo) / (hi - lo),
+//
+// where lo and hi are the returned minimum and maximum. If all edges have the same
+// cost, the normalized cost of every edge is zero. If g has no edges with a finite
+// cost, the returned minimum and maximum are NaN and the returned Weighting is
+// weight.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. The range is found when NormalizeCost is called, so
+// later changes to the costs given by weight may give normalized costs outside
+// [0, 1].
+func NormalizeCost(g graph.Graph, weight Weighting) (normalized Weighting, lo, hi float64) {
+	weight = weightFor(g, weight)
+	lo, hi = math.Inf(1), math.Inf(-1)
+	nodes := g.Nodes()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if uid == vid {
+				continue
+			}
+			w, ok := weight(uid, vid)
+			if !ok || math.IsInf(w, 0) || math.IsNaN(w) {
+				continue
+			}
+			lo = math.Min(lo, w)
+			hi = math.Max(hi, w)
+		}
+	}
+	if lo > hi {
+		return weight, math.NaN(), math.NaN()
+	}
+	scale := 0.0
+	if hi > lo {
+		scale = 1 / (hi - lo)
+	}
+	return func(xid, yid int64) (w float64, ok bool) {
+		w, ok = weight(xid, yid)
+		if !ok || xid == yid || math.IsInf(w, 0) {
+			return w, ok
+		}
+		return (w - lo) * scale, true
+	}, lo, hi
+}
@@ -0,0 +1,65 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestNormalizeCost(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 2},
+		{F: simple.Node(1), T: simple.Node(2), W: 10},
+		{F: simple.Node(2), T: simple.Node(3), W: 4},
+		{F: simple.Node(3), T: simple.Node(0), W: math.Inf(1)},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	normalized, lo, hi := NormalizeCost(g, nil)
+	if lo != 2 || hi != 10 {
+		t.Errorf("unexpected range: got:[%v,%v] want:[2,10]", lo, hi)
+	}
+	for _, test := range []struct {
+		x, y int64
+		want float64
+		ok   bool
+	}{
+		{x: 0, y: 1, want: 0, ok: true},
+		{x: 1, y: 2, want: 1, ok: true},
+		{x: 2, y: 3, want: 0.25, ok: true},
+		{x: 3, y: 0, want: math.Inf(1), ok: true},
+		{x: 1, y: 1, want: 0, ok: true},
+		{x: 1, y: 0, want: math.Inf(1), ok: false},
+	} {
+		w, ok := normalized(test.x, test.y)
+		if w != test.want || ok != test.ok {
+			t.Errorf("unexpected normalized cost for %d->%d: got:%v ok:%t want:%v ok:%t",
+				test.x, test.y, w, ok, test.want, test.ok)
+		}
+	}
+
+	uniform := simple.NewDirectedGraph()
+	uniform.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	uniform.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	normalized, lo, hi = NormalizeCost(uniform, nil)
+	if lo != 1 || hi != 1 {
+		t.Errorf("unexpected range for uniform costs: got:[%v,%v] want:[1,1]", lo, hi)
+	}
+	if w, ok := normalized(0, 1); w != 0 || !ok {
+		t.Errorf("unexpected normalized uniform cost: got:%v ok:%t want:0 ok:true", w, ok)
+	}
+
+	empty := simple.NewDirectedGraph()
+	empty.AddNode(simple.Node(0))
+	_, lo, hi = NormalizeCost(empty, nil)
+	if !math.IsNaN(lo) || !math.IsNaN(hi) {
+		t.Errorf("unexpected range for graph without edges: got:[%v,%v] want:[NaN,NaN]", lo, hi)
+	}
+}
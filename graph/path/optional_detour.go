@@ -0,0 +1,42 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// OptionalDetourAStar returns a path from s to t in g that passes through poi if
+// doing so costs no more than budget over the cost of the shortest path from s to
+// t. The returned path and its cost are those of the shortest path through poi,
+// found as by OrderedVisit, with detoured true if that path is within budget, and
+// those of the A*-shortest path from s to t with detoured false otherwise. If t is
+// not reachable from s, the path is nil, the cost is +Inf and detoured is false.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, OptionalDetourAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. OptionalDetourAStar will panic if g has an A*-reachable
+// negative edge weight.
+func OptionalDetourAStar(s, t, poi graph.Node, budget float64, g graph.Graph, weight Weighting, h Heuristic) (path []graph.Node, cost float64, detoured bool) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1), false
+	}
+	weight = weightFor(g, weight)
+	h = heuristicFor(g, h)
+
+	pt, _ := aStar(s, t, g, weight, h)
+	path, cost = pt.To(t.ID())
+	if path == nil {
+		return nil, math.Inf(1), false
+	}
+	via, viaCost := OrderedVisit([]graph.Node{s, poi, t}, g, weight, h)
+	if via == nil || viaCost-cost > budget {
+		return path, cost, false
+	}
+	return via, viaCost, true
+}
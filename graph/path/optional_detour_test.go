@@ -0,0 +1,61 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestOptionalDetourAStar(t *testing.T) {
+	// The direct route 0-1-2 costs 2 and the
+	// route through the POI 3 costs 5.
+	//
+	// 0 --1-- 1 --1-- 2
+	//  \             /
+	//   2           3
+	//    \         /
+	//     `-- 3 --'
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(0), T: simple.Node(3), W: 2},
+		{F: simple.Node(3), T: simple.Node(2), W: 3},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	g.AddNode(simple.Node(4))
+
+	for _, test := range []struct {
+		name         string
+		poi          int64
+		budget       float64
+		wantPath     []int64
+		wantCost     float64
+		wantDetoured bool
+	}{
+		{name: "within budget", poi: 3, budget: 4, wantPath: []int64{0, 3, 2}, wantCost: 5, wantDetoured: true},
+		{name: "at budget", poi: 3, budget: 3, wantPath: []int64{0, 3, 2}, wantCost: 5, wantDetoured: true},
+		{name: "over budget", poi: 3, budget: 2.5, wantPath: []int64{0, 1, 2}, wantCost: 2, wantDetoured: false},
+		{name: "poi on route", poi: 1, budget: 0, wantPath: []int64{0, 1, 2}, wantCost: 2, wantDetoured: true},
+		{name: "unreachable poi", poi: 4, budget: math.Inf(1), wantPath: []int64{0, 1, 2}, wantCost: 2, wantDetoured: false},
+		{name: "absent poi", poi: 5, budget: math.Inf(1), wantPath: []int64{0, 1, 2}, wantCost: 2, wantDetoured: false},
+	} {
+		path, cost, detoured := OptionalDetourAStar(simple.Node(0), simple.Node(2), simple.Node(test.poi), test.budget, g, nil, nil)
+		if !reflect.DeepEqual(ids(path), test.wantPath) || cost != test.wantCost || detoured != test.wantDetoured {
+			t.Errorf("%s: unexpected result: got:%v cost:%v detoured:%t want:%v cost:%v detoured:%t",
+				test.name, ids(path), cost, detoured, test.wantPath, test.wantCost, test.wantDetoured)
+		}
+	}
+
+	path, cost, detoured := OptionalDetourAStar(simple.Node(0), simple.Node(4), simple.Node(3), math.Inf(1), g, nil, nil)
+	if path != nil || !math.IsInf(cost, 1) || detoured {
+		t.Errorf("unexpected result for unreachable goal: got:%v cost:%v detoured:%t", ids(path), cost, detoured)
+	}
+}
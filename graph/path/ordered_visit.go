@@ -0,0 +1,49 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// OrderedVisit returns the shortest path through g that visits the nodes of
+// sequence in the given order, and its cost. The path is formed by joining the
+// A*-shortest paths between consecutive nodes of sequence, with each shared node
+// appearing once at the seam. Nodes of sequence may also appear elsewhere on
+// the path. If any leg has no path, or sequence is empty, the returned path is
+// nil and the cost is +Inf.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, OrderedVisit will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. OrderedVisit will panic if g has an A*-reachable
+// negative edge weight.
+func OrderedVisit(sequence []graph.Node, g graph.Graph, weight Weighting, h Heuristic) (path []graph.Node, cost float64) {
+	if len(sequence) == 0 {
+		return nil, math.Inf(1)
+	}
+	for _, n := range sequence {
+		if g.Node(n.ID()) == nil {
+			return nil, math.Inf(1)
+		}
+	}
+	weight = weightFor(g, weight)
+	h = heuristicFor(g, h)
+
+	path = []graph.Node{g.Node(sequence[0].ID())}
+	for i := 1; i < len(sequence); i++ {
+		s, t := sequence[i-1], sequence[i]
+		pt, _ := aStar(s, t, g, weight, h)
+		leg, c := pt.To(t.ID())
+		if leg == nil {
+			return nil, math.Inf(1)
+		}
+		path = append(path, leg[1:]...)
+		cost += c
+	}
+	return path, cost
+}
@@ -0,0 +1,75 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestOrderedVisit(t *testing.T) {
+	g := randomWeightedGraph(100, 4, 100, false, rand.NewSource(1))
+	sequence := []graph.Node{simple.Node(5), simple.Node(80), simple.Node(17), simple.Node(60), simple.Node(5)}
+
+	path, cost := OrderedVisit(sequence, g, nil, nil)
+	if path == nil {
+		t.Fatal("unexpected nil path")
+	}
+	// The sequence nodes must appear as a subsequence
+	// of the path in the given order.
+	next := 0
+	for _, n := range path {
+		if next < len(sequence) && n.ID() == sequence[next].ID() {
+			next++
+		}
+	}
+	if next != len(sequence) {
+		t.Errorf("path does not visit sequence in order: path:%v", ids(path))
+	}
+	if path[0].ID() != 5 || path[len(path)-1].ID() != 5 {
+		t.Errorf("unexpected path end points: %v", ids(path))
+	}
+
+	var want float64
+	for i := 1; i < len(sequence); i++ {
+		pt, _ := AStar(sequence[i-1], sequence[i], g, nil)
+		_, c := pt.To(sequence[i].ID())
+		want += c
+	}
+	if cost != want {
+		t.Errorf("unexpected cost: got:%v want:%v", cost, want)
+	}
+	if got := weightOf(path, g.Weight); got != cost {
+		t.Errorf("returned cost does not match path: got:%v want:%v", cost, got)
+	}
+}
+
+func TestOrderedVisitTrivial(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 2})
+	g.AddNode(simple.Node(2))
+
+	path, cost := OrderedVisit([]graph.Node{simple.Node(1)}, g, nil, nil)
+	if got := ids(path); !reflect.DeepEqual(got, []int64{1}) || cost != 0 {
+		t.Errorf("unexpected result for single node: got:%v,%v want:[1],0", got, cost)
+	}
+	for _, seq := range [][]graph.Node{
+		nil,
+		{simple.Node(1), simple.Node(0)},
+		{simple.Node(0), simple.Node(1), simple.Node(2)},
+		{simple.Node(0), simple.Node(3)},
+	} {
+		path, cost := OrderedVisit(seq, g, nil, nil)
+		if path != nil || !math.IsInf(cost, 1) {
+			t.Errorf("unexpected result for %v: got:%v,%v want:nil,+Inf", ids(seq), ids(path), cost)
+		}
+	}
+}
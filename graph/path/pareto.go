@@ -0,0 +1,126 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ParetoPaths returns the Pareto-optimal paths from s to t in g under the two
+// objectives given by cost1 and cost2, and the objective vector of each path. A
+// path is Pareto-optimal if no other path from s to t is at least as cheap in both
+// objectives and strictly cheaper in one. Where several paths share an objective
+// vector only one is returned. The paths are ordered by increasing cost1, and so
+// by decreasing cost2. If t is not reachable from s, ParetoPaths returns nil.
+//
+// ParetoPaths uses Martins' label-setting algorithm, keeping the set of
+// non-dominated labels at each node. The number of Pareto-optimal paths, and so
+// the number of labels, may grow exponentially with the size of g, so ParetoPaths
+// is only suitable for graphs where the objectives are strongly correlated or for
+// small graphs.
+//
+// If cost1 or cost2 is nil, the Weight method of g is used in its place if g
+// implements Weighted, falling back to UniformCost otherwise. ParetoPaths will
+// panic if g has an s-reachable negative edge cost in either objective.
+func ParetoPaths(s, t graph.Node, g graph.Graph, cost1, cost2 Weighting) (paths [][]graph.Node, costs [][2]float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, nil
+	}
+	cost1 = weightFor(g, cost1)
+	cost2 = weightFor(g, cost2)
+	tid := t.ID()
+
+	// Labels are settled in lexicographic order of
+	// their costs, so a label is dominated by a settled
+	// label at its node exactly when its second cost
+	// is no lower than the least settled second cost.
+	minCost2 := make(map[int64]float64)
+	dominated := func(id int64, c float64) bool {
+		m, ok := minCost2[id]
+		return ok && c >= m
+	}
+
+	queue := paretoQueue{{node: s}}
+	for queue.Len() != 0 {
+		l := heap.Pop(&queue).(*paretoLabel)
+		uid := l.node.ID()
+		if dominated(uid, l.cost[1]) {
+			continue
+		}
+		minCost2[uid] = l.cost[1]
+		if uid == tid {
+			paths = append(paths, l.path())
+			costs = append(costs, l.cost)
+			continue
+		}
+
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			w1, ok := cost1(uid, vid)
+			if !ok {
+				panic("path: unexpected invalid weight")
+			}
+			w2, ok := cost2(uid, vid)
+			if !ok {
+				panic("path: unexpected invalid weight")
+			}
+			if w1 < 0 || w2 < 0 {
+				panic("path: negative edge weight")
+			}
+			c := [2]float64{l.cost[0] + w1, l.cost[1] + w2}
+			// A label dominated at t cannot be
+			// extended to a Pareto-optimal path.
+			if dominated(vid, c[1]) || dominated(tid, c[1]) {
+				continue
+			}
+			heap.Push(&queue, &paretoLabel{node: v, cost: c, prev: l})
+		}
+	}
+	return paths, costs
+}
+
+// paretoLabel is a label of a multi-objective search, representing
+// a path to node with the given costs.
+type paretoLabel struct {
+	node graph.Node
+	cost [2]float64
+	prev *paretoLabel
+}
+
+// path returns the path represented by the label.
+func (l *paretoLabel) path() []graph.Node {
+	var n int
+	for p := l; p != nil; p = p.prev {
+		n++
+	}
+	path := make([]graph.Node, n)
+	for p := l; p != nil; p = p.prev {
+		n--
+		path[n] = p.node
+	}
+	return path
+}
+
+// paretoQueue implements a no-dec priority queue of labels
+// ordered lexicographically by cost.
+type paretoQueue []*paretoLabel
+
+func (q paretoQueue) Len() int { return len(q) }
+func (q paretoQueue) Less(i, j int) bool {
+	a, b := q[i].cost, q[j].cost
+	return a[0] < b[0] || (a[0] == b[0] && a[1] < b[1])
+}
+func (q paretoQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *paretoQueue) Push(n interface{}) { *q = append(*q, n.(*paretoLabel)) }
+func (q *paretoQueue) Pop() interface{} {
+	t := *q
+	var n interface{}
+	n, *q = t[len(t)-1], t[:len(t)-1]
+	return n
+}
@@ -0,0 +1,116 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestParetoPaths(t *testing.T) {
+	// The path 0-1-3 is cheaper in the first objective, the path
+	// 0-2-3 is cheaper in the second objective and the path 0-4-3
+	// is dominated by 0-1-3.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	second := make(map[[2]int64]float64)
+	for _, e := range []struct {
+		u, v   int64
+		c1, c2 float64
+	}{
+		{u: 0, v: 1, c1: 1, c2: 4},
+		{u: 1, v: 3, c1: 1, c2: 4},
+		{u: 0, v: 2, c1: 3, c2: 1},
+		{u: 2, v: 3, c1: 3, c2: 1},
+		{u: 0, v: 4, c1: 2, c2: 4},
+		{u: 4, v: 3, c1: 2, c2: 4},
+	} {
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(e.u), T: simple.Node(e.v), W: e.c1})
+		second[[2]int64{e.u, e.v}] = e.c2
+	}
+	cost2 := func(xid, yid int64) (float64, bool) {
+		w, ok := second[[2]int64{xid, yid}]
+		return w, ok
+	}
+
+	paths, costs := ParetoPaths(simple.Node(0), simple.Node(3), g, nil, cost2)
+	var got [][]int64
+	for _, p := range paths {
+		got = append(got, ids(p))
+	}
+	if want := [][]int64{{0, 1, 3}, {0, 2, 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected Pareto paths: got:%v want:%v", got, want)
+	}
+	if want := [][2]float64{{2, 8}, {6, 2}}; !reflect.DeepEqual(costs, want) {
+		t.Errorf("unexpected Pareto costs: got:%v want:%v", costs, want)
+	}
+
+	g.AddNode(simple.Node(5))
+	paths, costs = ParetoPaths(simple.Node(0), simple.Node(5), g, nil, cost2)
+	if paths != nil || costs != nil {
+		t.Errorf("unexpected Pareto paths to unreachable node: got:%v,%v", paths, costs)
+	}
+}
+
+func TestParetoPathsRandom(t *testing.T) {
+	const n = 40
+	src := rand.NewSource(1)
+	for i := 0; i < 5; i++ {
+		g := randomWeightedGraph(n, 3, 1, true, src)
+		rnd := rand.New(src)
+		second := make(map[[2]int64]float64)
+		cost2 := func(xid, yid int64) (float64, bool) {
+			if xid == yid {
+				return 0, true
+			}
+			if !g.HasEdgeBetween(xid, yid) {
+				return math.Inf(1), false
+			}
+			k := [2]int64{xid, yid}
+			w, ok := second[k]
+			if !ok {
+				w = float64(rnd.Intn(10) + 1)
+				second[k] = w
+			}
+			return w, true
+		}
+		s, tgt := simple.Node(0), simple.Node(n-1)
+		paths, costs := ParetoPaths(s, tgt, g, nil, cost2)
+
+		// The extreme points of the frontier are the
+		// shortest paths under each objective.
+		_, min1 := dijkstraCost(s, tgt, g, g.Weight)
+		_, min2 := dijkstraCost(s, tgt, g, cost2)
+		if len(paths) == 0 {
+			if !math.IsInf(min1, 1) {
+				t.Errorf("missing Pareto paths for reachable target")
+			}
+			continue
+		}
+		if costs[0][0] != min1 || costs[len(costs)-1][1] != min2 {
+			t.Errorf("unexpected frontier extremes: got:%v,%v want:%v,%v",
+				costs[0][0], costs[len(costs)-1][1], min1, min2)
+		}
+		for j, p := range paths {
+			if got := [2]float64{weightOf(p, g.Weight), weightOf(p, cost2)}; got != costs[j] {
+				t.Errorf("path cost mismatch: got:%v want:%v", got, costs[j])
+			}
+			if j > 0 && !(costs[j][0] > costs[j-1][0] && costs[j][1] < costs[j-1][1]) {
+				t.Errorf("frontier not strictly non-dominated: %v then %v", costs[j-1], costs[j])
+			}
+		}
+	}
+}
+
+func dijkstraCost(s, t graph.Node, g graph.Graph, weight Weighting) ([]graph.Node, float64) {
+	pt := newShortestFrom(s, graph.NodesOf(g.Nodes()))
+	dijkstraFrom(s, g, weight, &pt)
+	return pt.To(t.ID())
+}
@@ -0,0 +1,316 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// ShortestPathCount returns the number of distinct simple shortest paths from s to t
+// in g. If t is not reachable from s, ShortestPathCount returns zero, and if s and t
+// are the same node in g, it returns one. Path costs are compared exactly, so ties that
+// differ by floating point error are not counted as alternative shortest paths. The
+// number of shortest paths may grow exponentially with the size of g, and the count
+// overflows int for graphs with more shortest paths than can be represented.
+//
+// Zero weight edges are permitted. Shortest paths may pass through cycles of zero
+// weight edges, including undirected zero weight edges, but each path visits a node
+// at most once. Counting the paths through a set of nodes joined by a cycle of zero
+// weight edges takes time exponential in the size of the set. Self edges are ignored.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. ShortestPathCount will panic if g has an
+// s-reachable negative edge weight.
+func ShortestPathCount(s, t graph.Node, g graph.Graph, weight Weighting) int {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return 0
+	}
+	op := newOptimalPredecessors(s, g, weightFor(g, weight))
+	return op.count(nil)[t.ID()]
+}
+
+// PathRobustness returns a shortest path from s to t in g along with a description of
+// how forced the choice of that path is. The number of alternative simple shortest
+// paths, excluding the returned path, is returned in alternatives, and the number of
+// edges that are common to all shortest paths from s to t is returned in forced. If t
+// is not reachable from s, the path is nil and both counts are zero.
+//
+// Where there are alternatives, the returned path is constructed by choosing the
+// predecessor with the lowest ID at each step back from t that leaves the path able
+// to reach s. Path costs are compared exactly and zero weight edges are handled as
+// for ShortestPathCount.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. PathRobustness will panic if g has an s-reachable
+// negative edge weight.
+func PathRobustness(s, t graph.Node, g graph.Graph, weight Weighting) (path []graph.Node, alternatives, forced int) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, 0, 0
+	}
+	op := newOptimalPredecessors(s, g, weightFor(g, weight))
+	from := op.count(nil)
+	tid := t.ID()
+	total := from[tid]
+	if total == 0 {
+		return nil, 0, 0
+	}
+	var to map[int64]int
+	if !op.cyclic {
+		to = op.countTo(tid)
+	}
+
+	path = []graph.Node{t}
+	onPath := map[int64]bool{tid: true}
+	avoidPath := func(uid, vid int64) bool { return !onPath[uid] && !onPath[vid] }
+	for vid := tid; vid != s.ID(); {
+		pred := append([]int64(nil), op.pred[vid]...)
+		sort.Slice(pred, func(i, j int) bool { return pred[i] < pred[j] })
+		var uid int64
+		for _, uid = range pred {
+			// Within a component joined by zero weight
+			// edges, a predecessor may only be reachable
+			// through nodes already on the path.
+			if !onPath[uid] && (op.component[uid] != op.component[vid] || op.count(avoidPath)[uid] != 0) {
+				break
+			}
+		}
+
+		// An edge is on every shortest path if no
+		// shortest path avoids it.
+		if op.cyclic {
+			if op.count(func(x, y int64) bool { return x != uid || y != vid })[tid] == 0 {
+				forced++
+			}
+		} else if from[uid]*to[vid] == total {
+			forced++
+		}
+		path = append(path, g.Node(uid))
+		onPath[uid] = true
+		vid = uid
+	}
+	ordered.Reverse(path)
+	return path, total - 1, forced
+}
+
+// optimalPredecessors holds all the optimal predecessors of the nodes
+// reachable from a source node.
+type optimalPredecessors struct {
+	source int64
+	// order holds the reachable node IDs grouped
+	// by component, with each component after all
+	// the components holding its predecessors.
+	order []int64
+	// pred holds the optimal predecessors of each
+	// node, including those at the same distance
+	// joined to it by a zero weight edge.
+	pred map[int64][]int64
+	// component holds the index of the strongly
+	// connected component of the predecessor graph
+	// that holds each node, and cyclic is whether
+	// any component holds more than one node.
+	component map[int64]int
+	cyclic    bool
+	// within holds the optimal successors of each
+	// node in components with more than one node.
+	within map[int64][]int64
+}
+
+// newOptimalPredecessors returns the optimal predecessors of all nodes
+// in g reachable from s, found by Dijkstra's algorithm.
+func newOptimalPredecessors(s graph.Node, g graph.Graph, weight Weighting) optimalPredecessors {
+	op := optimalPredecessors{source: s.ID(), pred: make(map[int64][]int64)}
+	dist := map[int64]float64{s.ID(): 0}
+	settled := make(map[int64]bool)
+	var reached []int64
+	Q := priorityQueue{{node: s, dist: 0}}
+	for Q.Len() != 0 {
+		mid := heap.Pop(&Q).(distanceNode)
+		mnid := mid.node.ID()
+		if settled[mnid] || mid.dist > dist[mnid] {
+			continue
+		}
+		settled[mnid] = true
+		reached = append(reached, mnid)
+		to := g.From(mnid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if vid == mnid {
+				continue
+			}
+			w, ok := weight(mnid, vid)
+			if !ok {
+				panic("dijkstra: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("dijkstra: negative edge weight")
+			}
+			if math.IsInf(w, 1) {
+				continue
+			}
+			joint := mid.dist + w
+			d, seen := dist[vid]
+			switch {
+			case !seen || joint < d:
+				dist[vid] = joint
+				op.pred[vid] = append(op.pred[vid][:0], mnid)
+				heap.Push(&Q, distanceNode{node: v, dist: joint})
+			case joint == d:
+				// A settled node may be tied by a
+				// path ending in a zero weight edge.
+				op.pred[vid] = append(op.pred[vid], mnid)
+			}
+		}
+	}
+
+	// Zero weight edges may join nodes into cycles of
+	// predecessors, so group the nodes into strongly
+	// connected components in topological order.
+	succ := make(map[int64][]int64)
+	for vid, pred := range op.pred {
+		for _, uid := range pred {
+			succ[uid] = append(succ[uid], vid)
+		}
+	}
+	op.component = make(map[int64]int, len(reached))
+	var (
+		index   = make(map[int64]int, len(reached))
+		lowLink = make(map[int64]int, len(reached))
+		onStack = make(map[int64]bool)
+		stack   []int64
+		comps   [][]int64
+	)
+	var strongConnect func(uid int64)
+	strongConnect = func(uid int64) {
+		index[uid] = len(index)
+		lowLink[uid] = index[uid]
+		stack = append(stack, uid)
+		onStack[uid] = true
+		for _, vid := range succ[uid] {
+			if _, ok := index[vid]; !ok {
+				strongConnect(vid)
+				if lowLink[vid] < lowLink[uid] {
+					lowLink[uid] = lowLink[vid]
+				}
+			} else if onStack[vid] && index[vid] < lowLink[uid] {
+				lowLink[uid] = index[vid]
+			}
+		}
+		if lowLink[uid] != index[uid] {
+			return
+		}
+		var c []int64
+		for {
+			vid := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[vid] = false
+			c = append(c, vid)
+			if vid == uid {
+				break
+			}
+		}
+		comps = append(comps, c)
+	}
+	strongConnect(op.source)
+
+	// Components are found in reverse topological order.
+	op.order = make([]int64, 0, len(reached))
+	for i := len(comps) - 1; i >= 0; i-- {
+		c := comps[i]
+		op.cyclic = op.cyclic || len(c) > 1
+		for _, vid := range c {
+			op.component[vid] = len(comps) - 1 - i
+			op.order = append(op.order, vid)
+		}
+	}
+	if op.cyclic {
+		op.within = make(map[int64][]int64)
+		for uid, to := range succ {
+			for _, vid := range to {
+				if op.component[uid] == op.component[vid] {
+					op.within[uid] = append(op.within[uid], vid)
+				}
+			}
+		}
+	}
+	return op
+}
+
+// count returns the number of simple shortest paths from the source to each
+// reachable node that only use edges for which usable returns true. If usable
+// is nil, all edges are used. Since a path cannot return to a component once
+// it has left it, the paths are counted by summing over each component's
+// entry nodes the paths reaching that node from outside the component, scaled
+// by the number of simple paths within the component from that node.
+func (op optimalPredecessors) count(usable func(uid, vid int64) bool) map[int64]int {
+	if usable == nil {
+		usable = func(_, _ int64) bool { return true }
+	}
+	n := make(map[int64]int, len(op.order))
+	onStack := make(map[int64]bool)
+	var within func(uid int64, paths int)
+	within = func(uid int64, paths int) {
+		n[uid] += paths
+		onStack[uid] = true
+		for _, vid := range op.within[uid] {
+			if !onStack[vid] && usable(uid, vid) {
+				within(vid, paths)
+			}
+		}
+		onStack[uid] = false
+	}
+	for i := 0; i < len(op.order); {
+		c := op.component[op.order[i]]
+		j := i + 1
+		for j < len(op.order) && op.component[op.order[j]] == c {
+			j++
+		}
+		members := op.order[i:j]
+		entry := make([]int, len(members))
+		for k, vid := range members {
+			if vid == op.source {
+				entry[k] = 1
+			}
+			for _, uid := range op.pred[vid] {
+				if op.component[uid] != c && usable(uid, vid) {
+					entry[k] += n[uid]
+				}
+			}
+		}
+		if len(members) == 1 {
+			n[members[0]] = entry[0]
+		} else {
+			for k, vid := range members {
+				if entry[k] != 0 {
+					within(vid, entry[k])
+				}
+			}
+		}
+		i = j
+	}
+	return n
+}
+
+// countTo returns the number of shortest paths from each reachable node
+// to t along optimal predecessor edges. The counts are only of simple
+// paths if no component of the predecessors holds more than one node.
+func (op optimalPredecessors) countTo(t int64) map[int64]int {
+	n := map[int64]int{t: 1}
+	for i := len(op.order) - 1; i >= 0; i-- {
+		vid := op.order[i]
+		if n[vid] == 0 {
+			continue
+		}
+		for _, uid := range op.pred[vid] {
+			n[uid] += n[vid]
+		}
+	}
+	return n
+}
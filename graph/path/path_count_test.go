@@ -0,0 +1,257 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestShortestPathCount(t *testing.T) {
+	// Check counts against exhaustive enumeration of the
+	// shortest paths held by DijkstraAllPaths.
+	for _, directed := range []bool{false, true} {
+		g := randomWeightedGraph(30, 1, 40, directed, rand.NewSource(1))
+		all := DijkstraAllPaths(g)
+		for s := int64(0); s < 30; s++ {
+			for tid := int64(0); tid < 30; tid++ {
+				paths, w := all.AllBetween(s, tid)
+				want := len(paths)
+				if math.IsInf(w, 1) {
+					want = 0
+				}
+				if got := ShortestPathCount(simple.Node(s), simple.Node(tid), g, nil); got != want {
+					t.Errorf("directed=%t: unexpected number of shortest paths from %d to %d: got:%d want:%d",
+						directed, s, tid, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestShortestPathCountZeroWeight(t *testing.T) {
+	// Two routes from 0 to 4 that tie at node 1, one
+	// ending in a zero weight edge from node 3 which
+	// is settled no earlier than node 1.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 0.5},
+		{F: simple.Node(2), T: simple.Node(3), W: 0.5},
+		{F: simple.Node(3), T: simple.Node(1), W: 0},
+		{F: simple.Node(1), T: simple.Node(4), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	if got := ShortestPathCount(simple.Node(0), simple.Node(4), g, nil); got != 2 {
+		t.Errorf("unexpected number of shortest paths: got:%d want:2", got)
+	}
+	_, alternatives, forced := PathRobustness(simple.Node(0), simple.Node(4), g, nil)
+	if alternatives != 1 || forced != 1 {
+		t.Errorf("unexpected robustness: got alternatives=%d forced=%d want alternatives=1 forced=1", alternatives, forced)
+	}
+
+	// An undirected zero weight edge does not add
+	// paths since each path visits a node once.
+	u := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	u.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	u.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: 0})
+	for _, tid := range []int64{1, 2} {
+		if got := ShortestPathCount(simple.Node(0), simple.Node(tid), u, nil); got != 1 {
+			t.Errorf("unexpected number of shortest paths from 0 to %d with zero weight edge: got:%d want:1", tid, got)
+		}
+	}
+
+	// A triangle with a zero weight edge has two shortest
+	// paths from 0 to each of the other nodes.
+	u.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(2), W: 1})
+	for _, tid := range []int64{1, 2} {
+		if got := ShortestPathCount(simple.Node(0), simple.Node(tid), u, nil); got != 2 {
+			t.Errorf("unexpected number of shortest paths from 0 to %d in zero weight triangle: got:%d want:2", tid, got)
+		}
+	}
+	p, alternatives, forced := PathRobustness(simple.Node(0), simple.Node(2), u, nil)
+	if got := ids(p); !reflect.DeepEqual(got, []int64{0, 2}) || alternatives != 1 || forced != 0 {
+		t.Errorf("unexpected robustness in zero weight triangle: got path=%v alternatives=%d forced=%d want path=[0 2] alternatives=1 forced=0",
+			got, alternatives, forced)
+	}
+}
+
+func TestShortestPathCountZeroWeightRandom(t *testing.T) {
+	// Check counts against exhaustive enumeration of the
+	// simple paths in small graphs with many zero weight
+	// edges.
+	const n = 8
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		var g interface {
+			graph.Weighted
+			SetWeightedEdge(graph.WeightedEdge)
+		}
+		if trial%2 == 0 {
+			g = simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		} else {
+			g = simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		}
+		for i := 0; i < n; i++ {
+			g.(graph.NodeAdder).AddNode(simple.Node(i))
+		}
+		for k := 0; k < 2*n; k++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u != v {
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(3))})
+			}
+		}
+
+		for tid := int64(0); tid < n; tid++ {
+			paths := simpleShortestPaths(g, 0, tid)
+			if got := ShortestPathCount(simple.Node(0), simple.Node(tid), g, nil); got != len(paths) {
+				t.Errorf("trial %d: unexpected number of shortest paths from 0 to %d: got:%d want:%d",
+					trial, tid, got, len(paths))
+			}
+
+			p, alternatives, forced := PathRobustness(simple.Node(0), simple.Node(tid), g, nil)
+			if len(paths) == 0 {
+				if p != nil {
+					t.Errorf("trial %d: unexpected path from 0 to %d: %v", trial, tid, ids(p))
+				}
+				continue
+			}
+			var found bool
+			for _, q := range paths {
+				if reflect.DeepEqual(ids(p), q) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("trial %d: returned path from 0 to %d is not a simple shortest path: %v", trial, tid, ids(p))
+			}
+			if alternatives != len(paths)-1 {
+				t.Errorf("trial %d: unexpected number of alternatives from 0 to %d: got:%d want:%d",
+					trial, tid, alternatives, len(paths)-1)
+			}
+			var wantForced int
+			for i := 1; i < len(p); i++ {
+				e := [2]int64{p[i-1].ID(), p[i].ID()}
+				all := true
+				for _, q := range paths {
+					if !hasStep(q, e) {
+						all = false
+						break
+					}
+				}
+				if all {
+					wantForced++
+				}
+			}
+			if forced != wantForced {
+				t.Errorf("trial %d: unexpected number of forced edges from 0 to %d: got:%d want:%d",
+					trial, tid, forced, wantForced)
+			}
+		}
+	}
+}
+
+// simpleShortestPaths returns the node IDs of all the simple paths from
+// sid to tid in g with the least total weight.
+func simpleShortestPaths(g graph.Weighted, sid, tid int64) [][]int64 {
+	var (
+		paths [][]int64
+		best  = math.Inf(1)
+		path  = []int64{sid}
+		on    = map[int64]bool{sid: true}
+	)
+	var walk func(uid int64, cost float64)
+	walk = func(uid int64, cost float64) {
+		if uid == tid {
+			switch {
+			case cost < best:
+				best = cost
+				paths = [][]int64{append([]int64(nil), path...)}
+			case cost == best:
+				paths = append(paths, append([]int64(nil), path...))
+			}
+			return
+		}
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if on[vid] {
+				continue
+			}
+			w, _ := g.Weight(uid, vid)
+			on[vid] = true
+			path = append(path, vid)
+			walk(vid, cost+w)
+			path = path[:len(path)-1]
+			on[vid] = false
+		}
+	}
+	walk(sid, 0)
+	return paths
+}
+
+// hasStep returns whether the path p steps along the edge e.
+func hasStep(p []int64, e [2]int64) bool {
+	for i := 1; i < len(p); i++ {
+		if p[i-1] == e[0] && p[i] == e[1] {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPathRobustness(t *testing.T) {
+	// Two optimal routes from 0 to 5, 0-1-2-4-5 and
+	// 0-1-3-4-5, sharing the edges 0-1 and 4-5, and
+	// a more expensive route via 6.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(2), T: simple.Node(4), W: 1},
+		{F: simple.Node(3), T: simple.Node(4), W: 1},
+		{F: simple.Node(4), T: simple.Node(5), W: 1},
+		{F: simple.Node(0), T: simple.Node(6), W: 2},
+		{F: simple.Node(6), T: simple.Node(5), W: 3},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	for _, test := range []struct {
+		s, t             int64
+		wantPath         []int64
+		wantAlternatives int
+		wantForced       int
+	}{
+		{s: 0, t: 5, wantPath: []int64{0, 1, 2, 4, 5}, wantAlternatives: 1, wantForced: 2},
+		{s: 0, t: 4, wantPath: []int64{0, 1, 2, 4}, wantAlternatives: 1, wantForced: 1},
+		{s: 2, t: 3, wantPath: []int64{2, 1, 3}, wantAlternatives: 1, wantForced: 0},
+		{s: 0, t: 2, wantPath: []int64{0, 1, 2}, wantAlternatives: 0, wantForced: 2},
+		{s: 0, t: 0, wantPath: []int64{0}, wantAlternatives: 0, wantForced: 0},
+		{s: 0, t: 7, wantPath: nil, wantAlternatives: 0, wantForced: 0},
+	} {
+		p, alternatives, forced := PathRobustness(simple.Node(test.s), simple.Node(test.t), g, nil)
+		if got := ids(p); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("unexpected path from %d to %d: got:%v want:%v", test.s, test.t, got, test.wantPath)
+		}
+		if alternatives != test.wantAlternatives {
+			t.Errorf("unexpected number of alternatives from %d to %d: got:%d want:%d",
+				test.s, test.t, alternatives, test.wantAlternatives)
+		}
+		if forced != test.wantForced {
+			t.Errorf("unexpected number of forced edges from %d to %d: got:%d want:%d",
+				test.s, test.t, forced, test.wantForced)
+		}
+	}
+}
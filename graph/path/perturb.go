@@ -0,0 +1,154 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// PerturbForUniqueness returns a Weighting that adds a small deterministic amount to
+// the weight of each edge of g given by weight so that ties between shortest paths
+// are broken. The amount added to an edge depends only on the IDs of its end points,
+// and is the same for both directions of an edge if g is undirected.
+//
+// The perturbation of each edge is less than q/(2n), where n is the number of nodes
+// in g and q is the largest value of which every positive edge weight is, to within
+// floating point tolerance, an integer multiple. Since the costs of distinct paths
+// then differ by at least q and any simple path has fewer than n edges, a path that
+// is strictly shorter than another remains so after perturbation. Paths that tie
+// under weight are separated by the different perturbations of their edges, so
+// the shortest path under the returned Weighting is unique except in the unlikely
+// event that the perturbations of two tied paths have equal sums.
+//
+// The edge weights of g are examined when PerturbForUniqueness is called, so the
+// returned Weighting is only valid while g and weight are unchanged. If weight is
+// nil, the Weight method of g is used if g implements Weighted, falling back to
+// UniformCost otherwise. PerturbForUniqueness will panic if g has a negative edge
+// weight.
+func PerturbForUniqueness(g graph.Graph, weight Weighting) Weighting {
+	weight = weightFor(g, weight)
+	_, undirected := g.(graph.Undirected)
+
+	var (
+		q, max float64
+		n      int
+		ws     []float64
+	)
+	nodes := g.Nodes()
+	for nodes.Next() {
+		n++
+		uid := nodes.Node().ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if uid == vid {
+				continue
+			}
+			w, ok := weight(uid, vid)
+			if !ok {
+				panic("path: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("path: negative edge weight")
+			}
+			if w == 0 || math.IsInf(w, 1) {
+				continue
+			}
+			ws = append(ws, w)
+			max = math.Max(max, w)
+		}
+	}
+	tol := 1e-9 * max
+	for _, w := range ws {
+		if q == 0 {
+			q = w
+			continue
+		}
+		q = floatGCD(q, w, tol)
+	}
+	if q == 0 {
+		q = 1
+	}
+	delta := q / float64(2*(n+1))
+
+	return func(xid, yid int64) (w float64, ok bool) {
+		w, ok = weight(xid, yid)
+		if !ok || xid == yid {
+			return w, ok
+		}
+		if undirected && yid < xid {
+			xid, yid = yid, xid
+		}
+		return w + delta*edgeFraction(xid, yid), true
+	}
+}
+
+// RandomizeWeights returns a Weighting that multiplies the weight of each edge given
+// by weight by a random factor in [1-jitter, 1+jitter]. The factor for an edge is
+// drawn once for the IDs of its end points, so repeated queries of the returned
+// Weighting are consistent and both directions of an edge share the same factor.
+// Searching with Weightings returned for different random states samples diverse
+// near-optimal paths, for example to restart an iterated local search.
+//
+// If src is nil, the factors are derived from the global random number source.
+// RandomizeWeights will panic if jitter is not in [0, 1].
+func RandomizeWeights(weight Weighting, jitter float64, src rand.Source) Weighting {
+	if !(0 <= jitter && jitter <= 1) {
+		panic("path: invalid jitter")
+	}
+	var seed uint64
+	if src == nil {
+		seed = rand.Uint64()
+	} else {
+		seed = rand.New(src).Uint64()
+	}
+
+	return func(xid, yid int64) (w float64, ok bool) {
+		w, ok = weight(xid, yid)
+		if !ok || xid == yid || jitter == 0 {
+			return w, ok
+		}
+		if yid < xid {
+			xid, yid = yid, xid
+		}
+		u := float64(mix64(seed^uint64(xid)*0x9e3779b97f4a7c15^uint64(yid))>>11) / (1 << 53)
+		return w * (1 + jitter*(2*u-1)), true
+	}
+}
+
+// floatGCD returns the greatest common divisor of a and b, treating
+// remainders smaller than tol as zero.
+func floatGCD(a, b, tol float64) float64 {
+	if a < b {
+		a, b = b, a
+	}
+	for b > tol {
+		a, b = b, math.Mod(a, b)
+		if a-b <= tol {
+			// The remainder is within tolerance
+			// of the divisor.
+			b = 0
+		}
+	}
+	return a
+}
+
+// edgeFraction returns a deterministic pseudo-random value in [0.5, 1)
+// for the edge between the nodes with IDs uid and vid.
+func edgeFraction(uid, vid int64) float64 {
+	z := mix64(uint64(uid)*0x9e3779b97f4a7c15 ^ uint64(vid))
+	return 0.5 + float64(z>>11)/(1<<54)
+}
+
+// mix64 returns the SplitMix64 finalizer of z.
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
@@ -0,0 +1,132 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestPerturbForUniqueness(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		g    graph.Weighted
+	}{
+		{name: "grid", g: randomWeightedGrid(5, func() float64 { return 1 })},
+		{name: "fractional grid", g: randomWeightedGrid(5, func() float64 { return 0.25 })},
+		{name: "random undirected", g: randomWeightedGraph(40, 3, 20, false, rand.NewSource(1))},
+		{name: "random directed", g: randomWeightedGraph(40, 3, 20, true, rand.NewSource(1))},
+	} {
+		perturbed := PerturbForUniqueness(test.g, nil)
+		nodes := graph.NodesOf(test.g.Nodes())
+		var tied int
+		for _, s := range nodes {
+			for _, u := range nodes {
+				if ShortestPathCount(s, u, test.g, nil) < 2 {
+					continue
+				}
+				tied++
+				if n := ShortestPathCount(s, u, test.g, perturbed); n != 1 {
+					t.Errorf("%s: unexpected number of shortest paths after perturbation from %d to %d: got:%d want:1",
+						test.name, s.ID(), u.ID(), n)
+				}
+
+				pt, _ := AStar(s, u, test.g, NullHeuristic)
+				_, want := pt.To(u.ID())
+				pt, _ = AStar(s, u, weightedGraph{Graph: test.g, weight: perturbed}, NullHeuristic)
+				path, _ := pt.To(u.ID())
+				if got := weightOf(path, test.g.Weight); math.Abs(got-want) > 1e-9 {
+					t.Errorf("%s: perturbed shortest path from %d to %d is not optimal: got cost:%v want:%v",
+						test.name, s.ID(), u.ID(), got, want)
+				}
+			}
+		}
+		if tied == 0 {
+			t.Errorf("%s: no tied node pairs in test graph", test.name)
+		}
+	}
+}
+
+func TestPerturbForUniquenessSymmetric(t *testing.T) {
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: 3})
+	w := PerturbForUniqueness(g, nil)
+	a, _ := w(1, 2)
+	b, _ := w(2, 1)
+	if a != b {
+		t.Errorf("asymmetric perturbation for undirected edge: got:%v and %v", a, b)
+	}
+	if a <= 3 || a >= 3+3.0/4 {
+		t.Errorf("unexpected perturbed weight: got:%v want in (3, 3.75)", a)
+	}
+	if self, ok := w(1, 1); !ok || self != 0 {
+		t.Errorf("unexpected self weight: got:%v,%t want:0,true", self, ok)
+	}
+}
+
+// weightedGraph is a graph.Graph with its weights given by weight.
+type weightedGraph struct {
+	graph.Graph
+	weight Weighting
+}
+
+func (g weightedGraph) Weight(xid, yid int64) (float64, bool) { return g.weight(xid, yid) }
+
+func TestRandomizeWeights(t *testing.T) {
+	const n = 8
+	g := randomWeightedGrid(n, randomIntWeight(rand.NewSource(1))).(*simple.WeightedUndirectedGraph)
+	s, d := simple.Node(0), simple.Node(n*n-1)
+	pt, _ := AStar(s, d, g, nil)
+	want := pt.WeightTo(d.ID())
+
+	const jitter = 0.2
+	unchanged := RandomizeWeights(g.Weight, 0, rand.NewSource(1))
+	jittered := RandomizeWeights(g.Weight, jitter, rand.NewSource(1))
+	repeated := RandomizeWeights(g.Weight, jitter, rand.NewSource(1))
+	edges := g.WeightedEdges()
+	for edges.Next() {
+		e := edges.WeightedEdge()
+		uid, vid := e.From().ID(), e.To().ID()
+		if w, ok := unchanged(uid, vid); !ok || w != e.Weight() {
+			t.Errorf("unexpected weight for edge %d--%d with zero jitter: got:%v want:%v", uid, vid, w, e.Weight())
+		}
+		w, ok := jittered(uid, vid)
+		if !ok || w < (1-jitter)*e.Weight() || (1+jitter)*e.Weight() < w {
+			t.Errorf("unexpected weight for edge %d--%d: got:%v want in [%v, %v]", uid, vid, w, (1-jitter)*e.Weight(), (1+jitter)*e.Weight())
+		}
+		if r, _ := jittered(vid, uid); r != w {
+			t.Errorf("asymmetric weight for edge %d--%d: got:%v and %v", uid, vid, w, r)
+		}
+		if r, _ := repeated(uid, vid); r != w {
+			t.Errorf("inconsistent weight for edge %d--%d from same random state: got:%v and %v", uid, vid, w, r)
+		}
+	}
+
+	pt, _ = AStar(s, d, weightedGraph{Graph: g, weight: unchanged}, nil)
+	if got := pt.WeightTo(d.ID()); got != want {
+		t.Errorf("unexpected cost with zero jitter: got:%v want:%v", got, want)
+	}
+
+	paths := make(map[string]bool)
+	for seed := uint64(1); seed <= 10; seed++ {
+		w := RandomizeWeights(g.Weight, jitter, rand.NewSource(seed))
+		pt, _ := AStar(s, d, weightedGraph{Graph: g, weight: w}, nil)
+		p, _ := pt.To(d.ID())
+		cost := weightOf(p, g.Weight)
+		if cost > want*(1+jitter)/(1-jitter) {
+			t.Errorf("unexpected cost for seed %d: got:%v want at most %v", seed, cost, want*(1+jitter)/(1-jitter))
+		}
+		paths[fmt.Sprint(ids(p))] = true
+	}
+	if len(paths) < 2 {
+		t.Errorf("expected paths to vary with random state: got:%v", paths)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// PrecedenceConstrainedPath finds the shortest path from s to t in g that respects
+// the visiting order given by precedence. An entry precedence[a] = []int64{b, c}
+// states that if the path visits a and either of b or c, the first visit to a must
+// come before the first visit to b or c. A path that visits b without visiting a is
+// permitted. The path and its cost are returned. If no such path exists, the path
+// is nil and the cost is +Inf. The returned path may revisit nodes if that is
+// required to satisfy the constraints.
+//
+// The search is performed over (node, visited constrained nodes) states, where the
+// constrained nodes are those appearing in precedence, so the number of states may
+// grow as 2^k times the number of nodes for k constrained nodes.
+// PrecedenceConstrainedPath is intended for a small number of constraints.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. PrecedenceConstrainedPath will panic if g has a
+// reachable negative edge weight.
+func PrecedenceConstrainedPath(s, t graph.Node, precedence map[int64][]int64, g graph.Directed, weight Weighting) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+
+	constrained := make(map[int64]bool)
+	for a, after := range precedence {
+		constrained[a] = true
+		for _, b := range after {
+			constrained[b] = true
+		}
+	}
+
+	// The state of a label is the set of constrained
+	// nodes visited, encoded by encodeIDSet. visit returns
+	// the state after visiting n and whether the visit
+	// is permitted by the constraints.
+	visit := func(state string, n graph.Node) (string, bool) {
+		id := n.ID()
+		if !constrained[id] {
+			return state, true
+		}
+		visited := decodeIDSet(state)
+		has := func(id int64) bool {
+			i := sort.Search(len(visited), func(i int) bool { return visited[i] >= id })
+			return i < len(visited) && visited[i] == id
+		}
+		if has(id) {
+			return state, true
+		}
+		for _, b := range precedence[id] {
+			if b != id && has(b) {
+				return "", false
+			}
+		}
+		i := sort.Search(len(visited), func(i int) bool { return visited[i] >= id })
+		visited = append(visited, 0)
+		copy(visited[i+1:], visited[i:])
+		visited[i] = id
+		return encodeIDSet(visited), true
+	}
+	start, _ := visit("", s)
+
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		uid := u.node.ID()
+		state := u.state.(string)
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			next, ok := visit(state, v)
+			if !ok {
+				continue
+			}
+			w, ok := weight(uid, v.ID())
+			if !ok {
+				panic("path: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("path: negative edge weight")
+			}
+			yield(v, next, w)
+		}
+	}
+	l, _ := stateAStar(s, start, t, NullHeuristic, expand, isNode(t))
+	if l == nil {
+		return nil, math.Inf(1)
+	}
+	return l.path(), l.gscore
+}
@@ -0,0 +1,90 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestPrecedenceConstrainedPath(t *testing.T) {
+	// All routes from 0 to 3 pass through node 2. The
+	// cheapest route continues through node 1 and the
+	// alternative continues through node 4.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(2), T: simple.Node(4), W: 2},
+		{F: simple.Node(4), T: simple.Node(3), W: 2},
+		{F: simple.Node(5), T: simple.Node(0), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	for _, test := range []struct {
+		name       string
+		precedence map[int64][]int64
+		wantPath   []int64
+		wantCost   float64
+	}{
+		{
+			name:     "unconstrained",
+			wantPath: []int64{0, 2, 1, 3},
+			wantCost: 3,
+		},
+		{
+			name:       "2 before 1",
+			precedence: map[int64][]int64{2: {1}},
+			wantPath:   []int64{0, 2, 1, 3},
+			wantCost:   3,
+		},
+		{
+			// Node 1 cannot be visited after node 2,
+			// so the route through node 4 is taken.
+			name:       "1 before 2",
+			precedence: map[int64][]int64{1: {2}},
+			wantPath:   []int64{0, 2, 4, 3},
+			wantCost:   5,
+		},
+		{
+			// Node 4 must not be visited after
+			// node 1, which the cheapest route
+			// already satisfies.
+			name:       "4 before 1 and 3",
+			precedence: map[int64][]int64{4: {1, 3}},
+			wantPath:   []int64{0, 2, 1, 3},
+			wantCost:   3,
+		},
+		{
+			// Node 5 is never visited, so
+			// the constraint does not apply.
+			name:       "absent predecessor",
+			precedence: map[int64][]int64{5: {1, 2}},
+			wantPath:   []int64{0, 2, 1, 3},
+			wantCost:   3,
+		},
+	} {
+		p, cost := PrecedenceConstrainedPath(simple.Node(0), simple.Node(3), test.precedence, g, nil)
+		if !reflect.DeepEqual(ids(p), test.wantPath) {
+			t.Errorf("unexpected path for %s: got:%v want:%v", test.name, ids(p), test.wantPath)
+		}
+		if cost != test.wantCost {
+			t.Errorf("unexpected cost for %s: got:%v want:%v", test.name, cost, test.wantCost)
+		}
+	}
+
+	// Without the 2-4 edge, every route visits
+	// node 1 after node 2.
+	g.RemoveEdge(2, 4)
+	p, cost := PrecedenceConstrainedPath(simple.Node(0), simple.Node(3), map[int64][]int64{1: {2}}, g, nil)
+	if p != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected result for unsatisfiable constraint: got:%v %v want:[] +Inf", ids(p), cost)
+	}
+}
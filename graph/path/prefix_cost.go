@@ -0,0 +1,66 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"strconv"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// PrefixCostAStar finds the A*-shortest simple path from s to t in g where the cost
+// of extending a path depends on the whole path so far. The cost of extending path,
+// which starts at s, by the node next is given by prefixCost(path, next). The path
+// passed to prefixCost must not be retained or modified. The path and its cost are
+// returned. If t is not reachable from s, the path is nil and the cost is +Inf.
+//
+// Since the cost of an extension depends on the full prefix, the search must
+// distinguish every partial path, and so in the worst case explores every simple
+// path in g, which is exponential in the size of g. PrefixCostAStar should only be
+// used on small graphs or with an informative heuristic; where the cost depends
+// only on a bounded summary of the history, such as the last few nodes or an
+// accumulated quantity, a search over that summary is far more efficient.
+//
+// If h is nil, PrefixCostAStar will use the g.HeuristicCost method if g implements
+// HeuristicCoster, falling back to NullHeuristic otherwise. The heuristic must be a
+// lower bound on the cost of any completion of a path. PrefixCostAStar will panic if
+// prefixCost returns a negative cost.
+func PrefixCostAStar(s, t graph.Node, g graph.Graph, prefixCost func(path []graph.Node, next graph.Node) float64, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+
+	// The state of a label is the sequence of node
+	// IDs on its path, which makes every distinct
+	// path a distinct search state.
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		prefix := u.path()
+		key := u.state.(string)
+		to := g.From(u.node.ID())
+		for to.Next() {
+			v := to.Node()
+			if containsID(prefix, v.ID()) {
+				continue
+			}
+			yield(v, key+","+strconv.FormatInt(v.ID(), 10), prefixCost(prefix, v))
+		}
+	}
+	l, _ := stateAStar(s, strconv.FormatInt(s.ID(), 10), t, heuristicFor(g, h), expand, isNode(t))
+	if l == nil {
+		return nil, math.Inf(1)
+	}
+	return l.path(), l.gscore
+}
+
+// containsID returns whether path contains a node with the given ID.
+func containsID(path []graph.Node, id int64) bool {
+	for _, n := range path {
+		if n.ID() == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestPrefixCostAStar(t *testing.T) {
+	// A route 0-1-2-3-4 of four short edges and a
+	// route 0-5-4 of two long edges.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+		{F: simple.Node(3), T: simple.Node(4), W: 1},
+		{F: simple.Node(0), T: simple.Node(5), W: 2.5},
+		{F: simple.Node(5), T: simple.Node(4), W: 2.5},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	g.AddNode(simple.Node(6))
+
+	plain := func(path []graph.Node, next graph.Node) float64 {
+		w, _ := g.Weight(path[len(path)-1].ID(), next.ID())
+		return w
+	}
+	// With fatigue, each step costs its weight plus
+	// the number of steps already taken.
+	fatigue := func(path []graph.Node, next graph.Node) float64 {
+		return plain(path, next) + float64(len(path)-1)
+	}
+
+	for _, test := range []struct {
+		name     string
+		t        int64
+		cost     func([]graph.Node, graph.Node) float64
+		wantPath []int64
+		wantCost float64
+	}{
+		{name: "plain", t: 4, cost: plain, wantPath: []int64{0, 1, 2, 3, 4}, wantCost: 4},
+		{name: "fatigue", t: 4, cost: fatigue, wantPath: []int64{0, 5, 4}, wantCost: 6},
+		{name: "unreachable", t: 6, cost: fatigue, wantPath: nil, wantCost: math.Inf(1)},
+	} {
+		p, cost := PrefixCostAStar(simple.Node(0), simple.Node(test.t), g, test.cost, nil)
+		if got := ids(p); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("%s: unexpected path: got:%v want:%v", test.name, got, test.wantPath)
+		}
+		if cost != test.wantCost {
+			t.Errorf("%s: unexpected cost: got:%v want:%v", test.name, cost, test.wantCost)
+		}
+	}
+}
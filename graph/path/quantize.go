@@ -0,0 +1,32 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import "math"
+
+// QuantizeCost returns a Weighting that rounds the edge costs given by weight to
+// the nearest multiple of bucket, with halfway costs rounded away from zero. Cost
+// functions that differ by small fluctuations then give the same quantized costs,
+// and so the same search results, which makes cached results reusable across
+// nearly identical queries. Costs that differ by less than bucket/2 are only
+// quantized to the same value if they do not straddle a rounding boundary.
+//
+// Quantization changes the costs that paths are compared by, so the optimal path
+// under the returned Weighting may differ from the optimal path under weight when
+// costs cross bucket boundaries, and paths with distinct costs may tie. Costs less
+// than bucket/2 are rounded to zero. QuantizeCost will panic if bucket is not
+// positive.
+func QuantizeCost(weight Weighting, bucket float64) Weighting {
+	if !(bucket > 0) {
+		panic("path: invalid quantization bucket")
+	}
+	return func(xid, yid int64) (w float64, ok bool) {
+		w, ok = weight(xid, yid)
+		if !ok || xid == yid || math.IsInf(w, 0) {
+			return w, ok
+		}
+		return math.Round(w/bucket) * bucket, true
+	}
+}
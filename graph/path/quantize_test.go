@@ -0,0 +1,101 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestQuantizeCost(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1.26},
+		{F: simple.Node(1), T: simple.Node(2), W: 1.24},
+		{F: simple.Node(2), T: simple.Node(3), W: 0.1},
+		{F: simple.Node(3), T: simple.Node(4), W: math.Inf(1)},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	w := QuantizeCost(g.Weight, 0.5)
+	for _, test := range []struct {
+		x, y   int64
+		want   float64
+		wantOK bool
+	}{
+		{x: 0, y: 1, want: 1.5, wantOK: true},
+		{x: 1, y: 2, want: 1, wantOK: true},
+		{x: 2, y: 3, want: 0, wantOK: true},
+		{x: 3, y: 4, want: math.Inf(1), wantOK: true},
+		{x: 1, y: 1, want: 0, wantOK: true},
+		{x: 1, y: 0, want: math.Inf(1), wantOK: false},
+	} {
+		got, ok := w(test.x, test.y)
+		if got != test.want || ok != test.wantOK {
+			t.Errorf("unexpected quantized cost for %d->%d: got:%v,%t want:%v,%t",
+				test.x, test.y, got, ok, test.want, test.wantOK)
+		}
+	}
+}
+
+func TestQuantizeCostStable(t *testing.T) {
+	const (
+		n      = 20
+		bucket = 0.5
+	)
+	rnd := rand.New(rand.NewSource(1))
+	// Costs on bucket multiples, and fluctuations of those
+	// costs by less than bucket/2.
+	g := randomWeightedGrid(n, func() float64 { return bucket * float64(1+rnd.Intn(20)) })
+	noise := make(map[[2]int64]float64)
+	noisy := func(xid, yid int64) (float64, bool) {
+		w, ok := g.Weight(xid, yid)
+		if !ok || xid == yid {
+			return w, ok
+		}
+		if xid > yid {
+			xid, yid = yid, xid
+		}
+		k := [2]int64{xid, yid}
+		d, ok := noise[k]
+		if !ok {
+			d = (rnd.Float64() - 0.5) * 0.99 * bucket
+			noise[k] = d
+		}
+		return w + d, true
+	}
+
+	qa := QuantizeCost(g.Weight, bucket)
+	qb := QuantizeCost(noisy, bucket)
+	for _, u := range graph.NodesOf(g.Nodes()) {
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			a, _ := qa(u.ID(), v.ID())
+			b, _ := qb(u.ID(), v.ID())
+			if a != b {
+				t.Errorf("unexpected difference in quantized costs for %d-%d: %v != %v", u.ID(), v.ID(), a, b)
+			}
+		}
+	}
+
+	// Break ties in the same way for both searches.
+	pa := PerturbForUniqueness(g, qa)
+	pb := PerturbForUniqueness(g, qb)
+	for i := 0; i < 10; i++ {
+		s, tgt := simple.Node(rnd.Intn(n*n)), simple.Node(rnd.Intn(n*n))
+		ptA, _ := AStar(s, tgt, weightedGraph{Graph: g, weight: pa}, nil)
+		ptB, _ := AStar(s, tgt, weightedGraph{Graph: g, weight: pb}, nil)
+		a, _ := ptA.To(tgt.ID())
+		b, _ := ptB.To(tgt.ID())
+		if !reflect.DeepEqual(ids(a), ids(b)) {
+			t.Errorf("unexpected difference in quantized paths from %d to %d: %v != %v", s.ID(), tgt.ID(), ids(a), ids(b))
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// RefuelAStar finds the A*-shortest path from s to t in g for an agent that can travel
+// at most capacity in edge weight before refuelling. The agent starts at s with a full
+// tank, consumes fuel equal to the weight of each edge it traverses and is refuelled to
+// capacity at each node for which isCharger returns true. Edges that would exhaust the
+// remaining fuel are not traversed. The path and its cost are returned. If no feasible
+// path exists, the path is nil and the cost is +Inf.
+//
+// The search is performed over (node, remaining fuel) states. The number of distinct
+// fuel levels at a node is bounded by the number of distinct path weights since the
+// last charger, so RefuelAStar may be expensive on graphs with long charger-free
+// stretches and many distinct edge weights.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, RefuelAStar will use the g.HeuristicCost
+// method if g implements HeuristicCoster, falling back to NullHeuristic otherwise.
+// RefuelAStar will panic if g has an A*-reachable negative edge weight.
+func RefuelAStar(s, t graph.Node, capacity float64, isCharger func(graph.Node) bool, g graph.Graph, weight Weighting, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		fuel := u.state.(float64)
+		uid := u.node.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			w, ok := weight(uid, v.ID())
+			if !ok {
+				panic("A*: unexpected invalid weight")
+			}
+			left := fuel - w
+			if left < 0 {
+				continue
+			}
+			if isCharger(v) {
+				left = capacity
+			}
+			yield(v, left, w)
+		}
+	}
+	l, _ := stateAStar(s, capacity, t, heuristicFor(g, h), expand, isNode(t))
+	if l == nil {
+		return nil, math.Inf(1)
+	}
+	return l.path(), l.gscore
+}
@@ -0,0 +1,47 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestRefuelAStar(t *testing.T) {
+	// A direct route 0-1-3 of cost 4 and a detour
+	// 0-2-3 of cost 5 through the charger at 2.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 2},
+		{F: simple.Node(1), T: simple.Node(3), W: 2},
+		{F: simple.Node(0), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(3), W: 3},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	isCharger := func(n graph.Node) bool { return n.ID() == 2 }
+
+	for _, test := range []struct {
+		capacity float64
+		wantPath []int64
+		wantCost float64
+	}{
+		{capacity: 5, wantPath: []int64{0, 1, 3}, wantCost: 4},
+		{capacity: 3, wantPath: []int64{0, 2, 3}, wantCost: 5},
+		{capacity: 1, wantPath: nil, wantCost: math.Inf(1)},
+	} {
+		p, cost := RefuelAStar(simple.Node(0), simple.Node(3), test.capacity, isCharger, g, nil, nil)
+		if got := ids(p); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("capacity=%v: unexpected path: got:%v want:%v", test.capacity, got, test.wantPath)
+		}
+		if cost != test.wantCost {
+			t.Errorf("capacity=%v: unexpected cost: got:%v want:%v", test.capacity, cost, test.wantCost)
+		}
+	}
+}
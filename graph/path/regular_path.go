@@ -0,0 +1,145 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"encoding/binary"
+	"regexp/syntax"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// RegularPathQuery returns a path from s to t in g whose sequence of edge labels,
+// given by labelOf, matches pattern. The path with the fewest edges is returned, and
+// the returned boolean is false if no such path exists. The returned path may
+// revisit nodes if that is required for its labels to match.
+//
+// The pattern uses the syntax of the regexp package and must match the complete label
+// sequence, so the pattern "a*b" matches paths with labels "b", "ab", "aab" and so on.
+// Each edge label is a single rune. Anchors and word boundary assertions are not
+// supported. RegularPathQuery will panic if pattern is not a valid regular expression
+// or uses an unsupported assertion.
+//
+// The search is a breadth first search of the product of g and the automaton
+// compiled from pattern.
+func RegularPathQuery(s, t graph.Node, g graph.Directed, labelOf func(graph.Edge) rune, pattern string) ([]graph.Node, bool) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		panic("path: invalid pattern: " + err.Error())
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		panic("path: invalid pattern: " + err.Error())
+	}
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, false
+	}
+	a := labelAutomaton{prog: prog}
+
+	// The state of a label is the set of automaton
+	// instructions that may consume the next edge label
+	// or accept, encoded by labelAutomaton.closure.
+	start := a.closure([]uint32{uint32(prog.Start)})
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		uid := u.node.ID()
+		state := u.state.(string)
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			next := a.step(state, labelOf(g.Edge(uid, v.ID())))
+			if next == "" {
+				continue
+			}
+			yield(v, next, 1)
+		}
+	}
+	tid := t.ID()
+	isGoal := func(l *stateLabel) bool {
+		return l.node.ID() == tid && a.accepts(l.state.(string))
+	}
+	l, _ := stateAStar(s, start, t, NullHeuristic, expand, isGoal)
+	if l == nil {
+		return nil, false
+	}
+	return l.path(), true
+}
+
+// labelAutomaton simulates a compiled regular expression over
+// sets of instructions, as in the subset construction of a DFA.
+// Sets are encoded as strings of sorted instruction indices so
+// that they are comparable.
+type labelAutomaton struct {
+	prog *syntax.Prog
+}
+
+// closure returns the encoded set of rune and match instructions
+// reachable from the instructions in pcs without consuming a rune.
+func (a labelAutomaton) closure(pcs []uint32) string {
+	seen := make(map[uint32]bool)
+	var set []uint32
+	for len(pcs) != 0 {
+		pc := pcs[len(pcs)-1]
+		pcs = pcs[:len(pcs)-1]
+		if seen[pc] {
+			continue
+		}
+		seen[pc] = true
+		inst := &a.prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			pcs = append(pcs, inst.Out, inst.Arg)
+		case syntax.InstCapture, syntax.InstNop:
+			pcs = append(pcs, inst.Out)
+		case syntax.InstEmptyWidth:
+			panic("path: unsupported pattern assertion")
+		case syntax.InstFail:
+		default:
+			set = append(set, pc)
+		}
+	}
+	sort.Slice(set, func(i, j int) bool { return set[i] < set[j] })
+	b := make([]byte, 4*len(set))
+	for i, pc := range set {
+		binary.BigEndian.PutUint32(b[4*i:], pc)
+	}
+	return string(b)
+}
+
+// step returns the encoded set of instructions reached from the
+// encoded set state by consuming r. The empty string is returned
+// if r cannot be consumed.
+func (a labelAutomaton) step(state string, r rune) string {
+	var next []uint32
+	for i := 0; i < len(state); i += 4 {
+		inst := &a.prog.Inst[binary.BigEndian.Uint32([]byte(state[i:i+4]))]
+		var ok bool
+		switch inst.Op {
+		case syntax.InstRune, syntax.InstRune1:
+			ok = inst.MatchRune(r)
+		case syntax.InstRuneAny:
+			ok = true
+		case syntax.InstRuneAnyNotNL:
+			ok = r != '\n'
+		}
+		if ok {
+			next = append(next, inst.Out)
+		}
+	}
+	if next == nil {
+		return ""
+	}
+	return a.closure(next)
+}
+
+// accepts returns whether the encoded set state holds a match instruction.
+func (a labelAutomaton) accepts(state string) bool {
+	for i := 0; i < len(state); i += 4 {
+		if a.prog.Inst[binary.BigEndian.Uint32([]byte(state[i:i+4]))].Op == syntax.InstMatch {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var regularPathQueryTests = []struct {
+	pattern string
+	s, t    int64
+	want    []int64
+	wantOK  bool
+}{
+	{pattern: "a*b", s: 0, t: 3, want: []int64{0, 1, 3}, wantOK: true},
+	{pattern: "aab", s: 0, t: 3, want: []int64{0, 1, 2, 3}, wantOK: true},
+	{pattern: "ba", s: 0, t: 3, want: []int64{0, 4, 3}, wantOK: true},
+	{pattern: "a{4}b", s: 0, t: 3, want: []int64{0, 1, 2, 1, 2, 3}, wantOK: true},
+	{pattern: "[^a]+", s: 0, t: 3, want: nil, wantOK: false},
+	{pattern: "b", s: 0, t: 3, want: nil, wantOK: false},
+	{pattern: "a*", s: 0, t: 0, want: []int64{0}, wantOK: true},
+	{pattern: "a+", s: 0, t: 0, want: nil, wantOK: false},
+	{pattern: "(a|b)a", s: 0, t: 2, want: []int64{0, 1, 2}, wantOK: true},
+	{pattern: "(?i)AA", s: 1, t: 1, want: []int64{1, 2, 1}, wantOK: true},
+	{pattern: "a", s: 0, t: 5, want: nil, wantOK: false},
+}
+
+func TestRegularPathQuery(t *testing.T) {
+	// Edges are labeled as shown, with the
+	// edge from 2 to 1 labeled 'a'.
+	//
+	// 0 -a-> 1 -a-> 2 -b-> 3
+	// |      |             ^ ^
+	// |      '------b------' |
+	// '-b-> 4 ------a--------'
+	g := simple.NewDirectedGraph()
+	labels := make(map[[2]int64]rune)
+	for _, e := range []struct {
+		u, v  int64
+		label rune
+	}{
+		{0, 1, 'a'},
+		{1, 2, 'a'},
+		{2, 1, 'a'},
+		{2, 3, 'b'},
+		{1, 3, 'b'},
+		{0, 4, 'b'},
+		{4, 3, 'a'},
+	} {
+		g.SetEdge(simple.Edge{F: simple.Node(e.u), T: simple.Node(e.v)})
+		labels[[2]int64{e.u, e.v}] = e.label
+	}
+	labelOf := func(e graph.Edge) rune {
+		return labels[[2]int64{e.From().ID(), e.To().ID()}]
+	}
+
+	for _, test := range regularPathQueryTests {
+		path, ok := RegularPathQuery(simple.Node(test.s), simple.Node(test.t), g, labelOf, test.pattern)
+		var got []int64
+		if path != nil {
+			got = ids(path)
+		}
+		if ok != test.wantOK || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected result for %q from %d to %d: got:%v ok:%t want:%v ok:%t",
+				test.pattern, test.s, test.t, got, ok, test.want, test.wantOK)
+		}
+		if !ok {
+			continue
+		}
+		var labelSeq []rune
+		for i := 1; i < len(path); i++ {
+			labelSeq = append(labelSeq, labels[[2]int64{path[i-1].ID(), path[i].ID()}])
+		}
+		if re := regexp.MustCompile("^(?:" + test.pattern + ")$"); !re.MatchString(string(labelSeq)) {
+			t.Errorf("path labels %q do not match %q", string(labelSeq), test.pattern)
+		}
+	}
+
+	for _, pattern := range []string{"(", "^a"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for pattern %q", pattern)
+				}
+			}()
+			RegularPathQuery(simple.Node(0), simple.Node(3), g, labelOf, pattern)
+		}()
+	}
+}
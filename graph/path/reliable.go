@@ -0,0 +1,58 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// MostReliablePath returns the path from s to t in g that is most likely to be fully
+// available when each edge of g is available independently, and the probability
+// that it is available. The probability that the edge from u to v is available is
+// given by the value returned by reliability for the IDs of u and v, which must be
+// in [0, 1]. The path maximizing the product of its edge probabilities is found by
+// a Dijkstra search minimizing the sum of the negative logarithms of the edge
+// probabilities. Edges with zero probability are treated as absent. If no path
+// with a positive probability exists, the path is nil and the probability is zero.
+//
+// MostReliablePath will panic if reliability returns a value outside [0, 1] or an
+// invalid weight for an s-reachable edge.
+func MostReliablePath(s, t graph.Node, g graph.Directed, reliability Weighting) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, 0
+	}
+	logCost := func(xid, yid int64) (float64, bool) {
+		if xid == yid {
+			return 0, true
+		}
+		p, ok := reliability(xid, yid)
+		if !ok {
+			return math.Inf(1), false
+		}
+		if !(0 <= p && p <= 1) {
+			panic("path: invalid reliability")
+		}
+		return -math.Log(p), true
+	}
+
+	pt := newShortestFrom(s, graph.NodesOf(g.Nodes()))
+	dijkstraFrom(s, g, logCost, &pt)
+	path, _ := pt.To(t.ID())
+	if path == nil {
+		return nil, 0
+	}
+
+	// The probability is recomputed as the product of
+	// the reliabilities to avoid the rounding error of
+	// exponentiating the sum of logarithms.
+	prob := 1.0
+	for i := 1; i < len(path); i++ {
+		p, _ := reliability(path[i-1].ID(), path[i].ID())
+		prob *= p
+	}
+	return path, prob
+}
@@ -0,0 +1,73 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestMostReliablePath(t *testing.T) {
+	// The direct edge from 0 to 3 is unreliable, while
+	// the longer route via 1 and 2 is reliable.
+	reliability := map[[2]int64]float64{
+		{0, 3}: 0.5,
+		{0, 1}: 0.9,
+		{1, 2}: 0.9,
+		{2, 3}: 0.9,
+		{3, 4}: 0,
+		{1, 5}: 1,
+		{5, 3}: 0.7,
+	}
+	g := simple.NewDirectedGraph()
+	for e := range reliability {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	prob := func(xid, yid int64) (float64, bool) {
+		p, ok := reliability[[2]int64{xid, yid}]
+		return p, ok
+	}
+
+	for _, test := range []struct {
+		s, t     int64
+		wantPath []int64
+		wantProb float64
+	}{
+		{s: 0, t: 3, wantPath: []int64{0, 1, 2, 3}, wantProb: 0.9 * 0.9 * 0.9},
+		{s: 1, t: 3, wantPath: []int64{1, 2, 3}, wantProb: 0.9 * 0.9},
+		{s: 0, t: 5, wantPath: []int64{0, 1, 5}, wantProb: 0.9},
+		{s: 0, t: 0, wantPath: []int64{0}, wantProb: 1},
+		{s: 0, t: 4, wantPath: nil, wantProb: 0},
+		{s: 3, t: 0, wantPath: nil, wantProb: 0},
+	} {
+		p, got := MostReliablePath(simple.Node(test.s), simple.Node(test.t), g, prob)
+		if !reflect.DeepEqual(ids(p), test.wantPath) {
+			t.Errorf("unexpected path from %d to %d: got:%v want:%v", test.s, test.t, ids(p), test.wantPath)
+		}
+		if math.Abs(got-test.wantProb) > 1e-12 {
+			t.Errorf("unexpected probability from %d to %d: got:%v want:%v", test.s, test.t, got, test.wantProb)
+		}
+	}
+
+	// Making the direct edge more reliable than
+	// the route via 1 and 2 makes it preferred.
+	reliability[[2]int64{0, 3}] = 0.75
+	if p, got := MostReliablePath(simple.Node(0), simple.Node(3), g, prob); !reflect.DeepEqual(ids(p), []int64{0, 3}) || got != 0.75 {
+		t.Errorf("unexpected path from 0 to 3 with reliable edge: got:%v p=%v want:[0 3] p=0.75", ids(p), got)
+	}
+
+	reliability[[2]int64{0, 3}] = 1.5
+	panicked := func() (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		MostReliablePath(simple.Node(0), simple.Node(3), g, prob)
+		return false
+	}()
+	if !panicked {
+		t.Error("expected panic for invalid reliability")
+	}
+}
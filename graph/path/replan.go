@@ -0,0 +1,36 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ReplanOnBlock returns the A*-shortest path from current to goal in g that does not
+// enter any of the nodes marked in blocked, and its cost. It is intended to be called
+// from the current position of an agent traversing g each time further blocked nodes
+// are discovered. The current node is not itself checked against blocked. If goal is
+// blocked or not reachable from current, the path is nil and the cost is +Inf.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, ReplanOnBlock will use the g.HeuristicCost
+// method if g implements HeuristicCoster, falling back to NullHeuristic otherwise.
+// ReplanOnBlock will panic if g has a reachable negative edge weight.
+func ReplanOnBlock(current, goal graph.Node, blocked map[int64]bool, g graph.Graph, weight Weighting, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(current.ID()) == nil || g.Node(goal.ID()) == nil || blocked[goal.ID()] {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+	h = heuristicFor(g, h)
+
+	view := filteredGraph{
+		Graph:    g,
+		canEnter: func(n graph.Node) bool { return !blocked[n.ID()] },
+	}
+	pt, _ := aStar(current, goal, view, weight, h)
+	return pt.To(goal.ID())
+}
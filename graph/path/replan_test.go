@@ -0,0 +1,72 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestReplanOnBlock(t *testing.T) {
+	// 0 - 1 - 2
+	// |   |   |
+	// 3 - 4 - 5
+	// |   |   |
+	// 6 - 7 - 8
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(3), T: simple.Node(4), W: 1},
+		{F: simple.Node(4), T: simple.Node(5), W: 1},
+		{F: simple.Node(6), T: simple.Node(7), W: 1},
+		{F: simple.Node(7), T: simple.Node(8), W: 1},
+		{F: simple.Node(0), T: simple.Node(3), W: 1},
+		{F: simple.Node(3), T: simple.Node(6), W: 1},
+		{F: simple.Node(1), T: simple.Node(4), W: 0.5},
+		{F: simple.Node(4), T: simple.Node(7), W: 0.5},
+		{F: simple.Node(2), T: simple.Node(5), W: 1},
+		{F: simple.Node(5), T: simple.Node(8), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	goal := simple.Node(8)
+
+	blocked := make(map[int64]bool)
+	path, cost := ReplanOnBlock(simple.Node(0), goal, blocked, g, nil, nil)
+	if want := []int64{0, 1, 4, 7, 8}; !reflect.DeepEqual(ids(path), want) || cost != 3 {
+		t.Fatalf("unexpected initial route: got:%v cost:%v want:%v cost:3", ids(path), cost, want)
+	}
+
+	// The agent moves to node 1 and discovers that node 4 is blocked.
+	blocked[4] = true
+	path, cost = ReplanOnBlock(simple.Node(1), goal, blocked, g, nil, nil)
+	if path == nil {
+		t.Fatal("no replanned route found")
+	}
+	for _, n := range path {
+		if blocked[n.ID()] {
+			t.Errorf("replanned route %v enters blocked node %d", ids(path), n.ID())
+		}
+	}
+	if path[0].ID() != 1 || path[len(path)-1].ID() != goal.ID() || !topo.IsPathIn(g, path) {
+		t.Errorf("invalid replanned route: %v", ids(path))
+	}
+	if want := []int64{1, 2, 5, 8}; !reflect.DeepEqual(ids(path), want) || cost != 3 {
+		t.Errorf("unexpected replanned route: got:%v cost:%v want:%v cost:3", ids(path), cost, want)
+	}
+
+	// Blocking the remaining route leaves the goal unreachable.
+	blocked[5] = true
+	blocked[7] = true
+	path, cost = ReplanOnBlock(simple.Node(1), goal, blocked, g, nil, nil)
+	if path != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected route with goal cut off: got:%v cost:%v want:nil cost:+Inf", ids(path), cost)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ResourceLowerBound returns the minimum total resource consumption over all paths
+// from s to t in g, where the consumption of each edge is given by resource. The
+// bound is found by an unconstrained shortest path search on the resource metric,
+// so if it exceeds a budget, no path from s to t is within the budget and a more
+// expensive resource-constrained search can be skipped. If t is not reachable from
+// s, ResourceLowerBound returns +Inf.
+//
+// If resource is nil, the Weight method of g is used if g implements Weighted,
+// falling back to UniformCost otherwise. ResourceLowerBound will panic if g has a
+// reachable negative resource consumption.
+func ResourceLowerBound(s, t graph.Node, g graph.Graph, resource Weighting) float64 {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return math.Inf(1)
+	}
+	pt, _ := aStar(s, t, g, weightFor(g, resource), NullHeuristic)
+	return pt.WeightTo(t.ID())
+}
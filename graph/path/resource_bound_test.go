@@ -0,0 +1,75 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestResourceLowerBound(t *testing.T) {
+	g := randomWeightedGraph(12, 0, 30, true, rand.NewSource(1))
+	// The resource consumption differs from the edge weights.
+	resource := func(xid, yid int64) (float64, bool) {
+		if xid == yid {
+			return 0, true
+		}
+		if g.Edge(xid, yid) == nil {
+			return math.Inf(1), false
+		}
+		return float64((xid*7+yid*3)%5 + 1), true
+	}
+
+	for s := int64(0); s < 12; s++ {
+		for tid := int64(0); tid < 12; tid++ {
+			if g.Node(s) == nil || g.Node(tid) == nil {
+				continue
+			}
+			got := ResourceLowerBound(simple.Node(s), simple.Node(tid), g, resource)
+			want := minSimplePathCost(g, s, tid, resource)
+			if got != want {
+				t.Errorf("unexpected lower bound from %d to %d: got:%v want:%v", s, tid, got, want)
+			}
+		}
+	}
+
+	if got := ResourceLowerBound(simple.Node(0), simple.Node(-1), g, resource); !math.IsInf(got, 1) {
+		t.Errorf("unexpected lower bound for missing node: got:%v want:+Inf", got)
+	}
+}
+
+// minSimplePathCost returns the minimum cost of a simple path from s to t in g
+// found by exhaustive enumeration.
+func minSimplePathCost(g graph.Graph, s, t int64, weight Weighting) float64 {
+	best := math.Inf(1)
+	onPath := map[int64]bool{s: true}
+	var walk func(u int64, cost float64)
+	walk = func(u int64, cost float64) {
+		if u == t {
+			if cost < best {
+				best = cost
+			}
+			return
+		}
+		to := g.From(u)
+		for to.Next() {
+			v := to.Node().ID()
+			if onPath[v] {
+				continue
+			}
+			w, _ := weight(u, v)
+			onPath[v] = true
+			walk(v, cost+w)
+			onPath[v] = false
+		}
+	}
+	walk(s, 0)
+	return best
+}
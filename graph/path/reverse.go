@@ -0,0 +1,34 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import "gonum.org/v1/gonum/graph"
+
+// reversedGraph is a view of a directed graph with the direction
+// of all its edges reversed.
+type reversedGraph struct {
+	graph.Directed
+}
+
+func (g reversedGraph) From(id int64) graph.Nodes { return g.Directed.To(id) }
+func (g reversedGraph) To(id int64) graph.Nodes   { return g.Directed.From(id) }
+
+func (g reversedGraph) HasEdgeFromTo(uid, vid int64) bool {
+	return g.Directed.HasEdgeFromTo(vid, uid)
+}
+
+func (g reversedGraph) Edge(uid, vid int64) graph.Edge {
+	e := g.Directed.Edge(vid, uid)
+	if e == nil {
+		return nil
+	}
+	return e.ReversedEdge()
+}
+
+// reversedWeight returns a Weighting for a reversedGraph
+// view of the graph weighted by weight.
+func reversedWeight(weight Weighting) Weighting {
+	return func(xid, yid int64) (float64, bool) { return weight(yid, xid) }
+}
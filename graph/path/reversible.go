@@ -0,0 +1,63 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ReversibleEdgeAStar finds the A*-shortest path from s to t in g where each edge
+// may also be traversed against its direction. Traversing the edge from u to v in
+// its direction costs forward(u, v) and traversing it from v to u costs reverse(u, v),
+// so reverse is evaluated with the end points in the edge's direction. The search
+// is effectively over an undirected view of g with asymmetric weights, and if edges
+// join u and v in both directions the cheaper traversal is used. The path and its
+// cost are returned. If no path exists, the path is nil and the cost is +Inf.
+//
+// If forward is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If reverse is nil, edges are traversed against their
+// direction at their forward cost. If h is nil, ReversibleEdgeAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. The heuristic must be admissible for traversal in both
+// directions. ReversibleEdgeAStar will panic if g has an A*-reachable negative edge
+// weight.
+func ReversibleEdgeAStar(s, t graph.Node, g graph.Directed, forward, reverse Weighting, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	forward = weightFor(g, forward)
+	if reverse == nil {
+		reverse = forward
+	}
+
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		uid := u.node.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			w, ok := forward(uid, v.ID())
+			if !ok {
+				panic("A*: unexpected invalid weight")
+			}
+			yield(v, nil, w)
+		}
+		from := g.To(uid)
+		for from.Next() {
+			v := from.Node()
+			w, ok := reverse(v.ID(), uid)
+			if !ok {
+				panic("A*: unexpected invalid weight")
+			}
+			yield(v, nil, w)
+		}
+	}
+	l, _ := stateAStar(s, nil, t, heuristicFor(g, h), expand, isNode(t))
+	if l == nil {
+		return nil, math.Inf(1)
+	}
+	return l.path(), l.gscore
+}
@@ -0,0 +1,66 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestReversibleEdgeAStar(t *testing.T) {
+	// The short route from 0 to 3 runs against the
+	// direction of the edges 1->0 and 3->1, and the
+	// long route 0-2-4-3 follows edge directions.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(1), T: simple.Node(0), W: 1},
+		{F: simple.Node(3), T: simple.Node(1), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(4), W: 2},
+		{F: simple.Node(4), T: simple.Node(3), W: 2},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	premium := func(p float64) Weighting {
+		return func(xid, yid int64) (float64, bool) {
+			w, ok := g.Weight(xid, yid)
+			return w + p, ok
+		}
+	}
+
+	for _, test := range []struct {
+		name     string
+		reverse  Weighting
+		wantPath []int64
+		wantCost float64
+	}{
+		{name: "free", reverse: nil, wantPath: []int64{0, 1, 3}, wantCost: 2},
+		{name: "cheap", reverse: premium(1), wantPath: []int64{0, 1, 3}, wantCost: 4},
+		{name: "expensive", reverse: premium(100), wantPath: []int64{0, 2, 4, 3}, wantCost: 6},
+		{name: "forbidden", reverse: premium(math.Inf(1)), wantPath: []int64{0, 2, 4, 3}, wantCost: 6},
+	} {
+		p, cost := ReversibleEdgeAStar(simple.Node(0), simple.Node(3), g, nil, test.reverse, nil)
+		if got := ids(p); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("%s: unexpected path: got:%v want:%v", test.name, got, test.wantPath)
+		}
+		if cost != test.wantCost {
+			t.Errorf("%s: unexpected cost: got:%v want:%v", test.name, cost, test.wantCost)
+		}
+	}
+
+	// With infinite reversal cost, only edge directions
+	// are followed, around the cycle 2-4-3-1-0.
+	p, cost := ReversibleEdgeAStar(simple.Node(2), simple.Node(0), g, nil, premium(math.Inf(1)), nil)
+	if want := []int64{2, 4, 3, 1, 0}; !reflect.DeepEqual(ids(p), want) || cost != 6 {
+		t.Errorf("unexpected forward path: got:%v %v want:%v 6", ids(p), cost, want)
+	}
+	p, cost = ReversibleEdgeAStar(simple.Node(2), simple.Node(0), g, nil, premium(0.5), nil)
+	if want := []int64{2, 0}; !reflect.DeepEqual(ids(p), want) || cost != 2.5 {
+		t.Errorf("unexpected reversed path: got:%v %v want:%v 2.5", ids(p), cost, want)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// RiskAwareAStar finds the A*-shortest path from s to t in g minimizing the sum of
+// the edge weights plus riskWeight times the sum of the risks of the nodes entered
+// along the path. The path is returned with its travel cost, the sum of its edge
+// weights, and its accumulated risk, the sum of risk over all nodes of the path
+// including s. If no path exists, the path is nil and the cost and risk are +Inf.
+// With riskWeight zero, RiskAwareAStar is equivalent to AStar and risk is only used
+// to find the accumulated risk. If risk is nil, all nodes have zero risk.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, RiskAwareAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. A heuristic that is admissible for the edge weights
+// remains admissible for non-negative risks and riskWeight. RiskAwareAStar will
+// panic if a risk-adjusted edge weight is negative.
+func RiskAwareAStar(s, t graph.Node, g graph.Graph, weight Weighting, risk func(graph.Node) float64, riskWeight float64, h Heuristic) (path []graph.Node, cost, totalRisk float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1), math.Inf(1)
+	}
+	if risk == nil {
+		risk = func(graph.Node) float64 { return 0 }
+	}
+	weight = weightFor(g, weight)
+	search := weight
+	if riskWeight != 0 {
+		search = func(xid, yid int64) (float64, bool) {
+			w, ok := weight(xid, yid)
+			if !ok || xid == yid {
+				return w, ok
+			}
+			return w + riskWeight*risk(g.Node(yid)), true
+		}
+	}
+	pt, _ := aStar(s, t, g, search, heuristicFor(g, h))
+	path, _ = pt.To(t.ID())
+	if path == nil {
+		return nil, math.Inf(1), math.Inf(1)
+	}
+	for _, n := range path {
+		totalRisk += risk(n)
+	}
+	return path, weightOf(path, weight), totalRisk
+}
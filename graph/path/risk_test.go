@@ -0,0 +1,76 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestRiskAwareAStar(t *testing.T) {
+	// A short route through the risky node 1 and
+	// a longer route through the safe nodes 2 and 3.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(4), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+		{F: simple.Node(3), T: simple.Node(4), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	risk := func(n graph.Node) float64 {
+		if n.ID() == 1 {
+			return 5
+		}
+		return 0.5
+	}
+
+	for _, test := range []struct {
+		riskWeight float64
+		wantPath   []int64
+		wantCost   float64
+		wantRisk   float64
+	}{
+		{riskWeight: 0, wantPath: []int64{0, 1, 4}, wantCost: 2, wantRisk: 6},
+		{riskWeight: 0.1, wantPath: []int64{0, 1, 4}, wantCost: 2, wantRisk: 6},
+		{riskWeight: 1, wantPath: []int64{0, 2, 3, 4}, wantCost: 3, wantRisk: 2},
+	} {
+		p, cost, r := RiskAwareAStar(simple.Node(0), simple.Node(4), g, nil, risk, test.riskWeight, nil)
+		if got := ids(p); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("riskWeight=%v: unexpected path: got:%v want:%v", test.riskWeight, got, test.wantPath)
+		}
+		if cost != test.wantCost {
+			t.Errorf("riskWeight=%v: unexpected cost: got:%v want:%v", test.riskWeight, cost, test.wantCost)
+		}
+		if r != test.wantRisk {
+			t.Errorf("riskWeight=%v: unexpected risk: got:%v want:%v", test.riskWeight, r, test.wantRisk)
+		}
+	}
+}
+
+func TestRiskAwareAStarNilRisk(t *testing.T) {
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 3},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	// A nil risk is zero risk for all nodes,
+	// whether or not risk is weighted.
+	for _, riskWeight := range []float64{0, 1} {
+		p, cost, r := RiskAwareAStar(simple.Node(0), simple.Node(2), g, nil, nil, riskWeight, nil)
+		if got := ids(p); !reflect.DeepEqual(got, []int64{0, 1, 2}) || cost != 2 || r != 0 {
+			t.Errorf("riskWeight=%v: unexpected result with nil risk: got:%v,%v,%v want:[0 1 2],2,0", riskWeight, got, cost, r)
+		}
+	}
+}
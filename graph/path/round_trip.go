@@ -0,0 +1,70 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+)
+
+// roundTripTol is the absolute and relative tolerance
+// within which RoundTripAsymmetry considers the costs
+// of the two directions equal.
+const roundTripTol = 1e-9
+
+// ShortestRoundTrip returns the shortest closed walk in g that starts and ends at s
+// and passes through via, and its cost. The walk is the concatenation of the
+// shortest path from s to via and the shortest path from via back to s; in a
+// directed graph the two legs may differ. If either leg does not exist, the
+// returned walk is nil and the cost is +Inf.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. The HeuristicCost method of g is used to guide the
+// searches if g implements HeuristicCoster. ShortestRoundTrip will panic if g has a
+// reachable negative edge weight.
+func ShortestRoundTrip(s, via graph.Node, g graph.Directed, weight Weighting) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(via.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+	h := heuristicFor(g, nil)
+
+	out, _ := aStar(s, via, g, weight, h)
+	there, outCost := out.To(via.ID())
+	if there == nil {
+		return nil, math.Inf(1)
+	}
+	back, _ := aStar(via, s, g, weight, h)
+	home, backCost := back.To(s.ID())
+	if home == nil {
+		return nil, math.Inf(1)
+	}
+	return append(there, home[1:]...), outCost + backCost
+}
+
+// RoundTripAsymmetry returns the costs of the shortest paths from a to b and from b to
+// a in g, and whether the two costs are equal to within an absolute or relative
+// tolerance of 1e-9. Asymmetric costs arise from one-way edges and from edges with
+// different weights in each direction. If a direction has no path, its cost is +Inf;
+// a pair of nodes with no path in either direction is reported as symmetric.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. The HeuristicCost method of g is used to guide the
+// searches if g implements HeuristicCoster. RoundTripAsymmetry will panic if g has a
+// reachable negative edge weight.
+func RoundTripAsymmetry(a, b graph.Node, g graph.Directed, weight Weighting) (outCost, backCost float64, symmetric bool) {
+	outCost, backCost = math.Inf(1), math.Inf(1)
+	if g.Node(a.ID()) != nil && g.Node(b.ID()) != nil {
+		weight = weightFor(g, weight)
+		h := heuristicFor(g, nil)
+		out, _ := aStar(a, b, g, weight, h)
+		outCost = out.WeightTo(b.ID())
+		back, _ := aStar(b, a, g, weight, h)
+		backCost = back.WeightTo(a.ID())
+	}
+	return outCost, backCost, floats.EqualWithinAbsOrRel(outCost, backCost, roundTripTol, roundTripTol)
+}
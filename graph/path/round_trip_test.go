@@ -0,0 +1,105 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestShortestRoundTrip(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 5},
+		{F: simple.Node(2), T: simple.Node(3), W: 2},
+		{F: simple.Node(3), T: simple.Node(0), W: 2},
+		{F: simple.Node(2), T: simple.Node(0), W: 7},
+		{F: simple.Node(4), T: simple.Node(0), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	for _, test := range []struct {
+		s, via int64
+		want   []int64
+		cost   float64
+	}{
+		{s: 0, via: 2, want: []int64{0, 1, 2, 3, 0}, cost: 6},
+		{s: 1, via: 3, want: []int64{1, 2, 3, 0, 1}, cost: 6},
+		{s: 0, via: 0, want: []int64{0}, cost: 0},
+		{s: 0, via: 4, want: nil, cost: math.Inf(1)},
+		{s: 4, via: 0, want: nil, cost: math.Inf(1)},
+	} {
+		p, cost := ShortestRoundTrip(simple.Node(test.s), simple.Node(test.via), g, nil)
+		if got := ids(p); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected round trip from %d via %d: got:%v want:%v", test.s, test.via, got, test.want)
+		}
+		if cost != test.cost {
+			t.Errorf("unexpected round trip cost from %d via %d: got:%v want:%v", test.s, test.via, cost, test.cost)
+		}
+		if p == nil {
+			continue
+		}
+
+		// The cost is the sum of the one way shortest path costs.
+		pt := DijkstraAllPaths(g)
+		if sum := pt.Weight(test.s, test.via) + pt.Weight(test.via, test.s); cost != sum {
+			t.Errorf("round trip cost from %d via %d does not match one way costs: got:%v want:%v", test.s, test.via, cost, sum)
+		}
+	}
+}
+
+func TestRoundTripAsymmetry(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		// Two-way streets.
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(0), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(1), W: 1},
+
+		// One-way streets.
+		{F: simple.Node(0), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+
+		// A cycle with rounding in one direction.
+		{F: simple.Node(4), T: simple.Node(5), W: 0.1},
+		{F: simple.Node(5), T: simple.Node(6), W: 0.2},
+		{F: simple.Node(6), T: simple.Node(4), W: 0.3},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	g.AddNode(simple.Node(7))
+	rounded := 0.1
+	rounded += 0.2
+
+	for _, test := range []struct {
+		a, b          int64
+		out, back     float64
+		wantSymmetric bool
+	}{
+		{a: 0, b: 1, out: 1, back: 1, wantSymmetric: true},
+		{a: 0, b: 2, out: 1, back: 2, wantSymmetric: false},
+		{a: 2, b: 0, out: 2, back: 1, wantSymmetric: false},
+		{a: 0, b: 3, out: 2, back: math.Inf(1), wantSymmetric: false},
+		{a: 3, b: 0, out: math.Inf(1), back: 2, wantSymmetric: false},
+		{a: 4, b: 6, out: rounded, back: 0.3, wantSymmetric: true},
+		{a: 0, b: 7, out: math.Inf(1), back: math.Inf(1), wantSymmetric: true},
+		{a: 1, b: 1, out: 0, back: 0, wantSymmetric: true},
+	} {
+		out, back, symmetric := RoundTripAsymmetry(simple.Node(test.a), simple.Node(test.b), g, nil)
+		if out != test.out || back != test.back {
+			t.Errorf("unexpected costs between %d and %d: got:(%v, %v) want:(%v, %v)", test.a, test.b, out, back, test.out, test.back)
+		}
+		if symmetric != test.wantSymmetric {
+			t.Errorf("unexpected symmetry between %d and %d: got:%t want:%t", test.a, test.b, symmetric, test.wantSymmetric)
+		}
+	}
+}
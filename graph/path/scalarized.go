@@ -0,0 +1,69 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ScalarizedAStar finds the A*-shortest path from s to t in g under the scalarized
+// edge cost alpha*distance + (1-alpha)*discomfort, trading the distance objective
+// against the discomfort objective. The path is returned with its total distance and
+// total discomfort. Sweeping alpha over [0, 1] traces the supported points of the
+// Pareto frontier of the two objectives. If no path exists, the path is nil and the
+// distance and discomfort are +Inf.
+//
+// The heuristic h estimates the remaining distance to t and is scaled by alpha, so
+// it remains admissible when h is admissible for distance and discomfort is not
+// negative. If distance is nil, the Weight method of g is used if g implements
+// Weighted, falling back to UniformCost otherwise. If h is nil, ScalarizedAStar
+// will use the g.HeuristicCost method if g implements HeuristicCoster, falling back
+// to NullHeuristic otherwise. The discomfort function is required; ScalarizedAStar
+// will panic if discomfort is nil, if alpha is not in [0, 1] or if g has an
+// A*-reachable negative scalarized edge cost.
+func ScalarizedAStar(s, t graph.Node, g graph.Graph, distance, discomfort Weighting, alpha float64, h Heuristic) (path []graph.Node, totalDistance, totalDiscomfort float64) {
+	if discomfort == nil {
+		panic("path: nil discomfort")
+	}
+	if alpha < 0 || 1 < alpha {
+		panic("A*: alpha out of range")
+	}
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1), math.Inf(1)
+	}
+	distance = weightFor(g, distance)
+	h = heuristicFor(g, h)
+
+	weight := func(xid, yid int64) (float64, bool) {
+		var w float64
+		// Terms with a zero coefficient are not evaluated
+		// so that infinite costs do not give NaN weights.
+		if alpha != 0 {
+			d, ok := distance(xid, yid)
+			if !ok {
+				return 0, false
+			}
+			w += alpha * d
+		}
+		if alpha != 1 {
+			c, ok := discomfort(xid, yid)
+			if !ok {
+				return 0, false
+			}
+			w += (1 - alpha) * c
+		}
+		return w, true
+	}
+	scaled := func(x, y graph.Node) float64 { return alpha * h(x, y) }
+
+	pt, _ := aStar(s, t, g, weight, scaled)
+	path, _ = pt.To(t.ID())
+	if path == nil {
+		return nil, math.Inf(1), math.Inf(1)
+	}
+	return path, weightOf(path, distance), weightOf(path, discomfort)
+}
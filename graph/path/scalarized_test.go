@@ -0,0 +1,86 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestScalarizedAStar(t *testing.T) {
+	// The road 0-1-3 is short but uncomfortable, the
+	// cycle path 0-2-3 is long but comfortable and the
+	// route 0-4-3 is a compromise.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	discomfort := map[[2]int64]float64{}
+	for _, e := range []struct {
+		u, v       int64
+		dist, disc float64
+	}{
+		{u: 0, v: 1, dist: 1, disc: 10},
+		{u: 1, v: 3, dist: 1, disc: 10},
+		{u: 0, v: 2, dist: 5, disc: 1},
+		{u: 2, v: 3, dist: 5, disc: 1},
+		{u: 0, v: 4, dist: 2.5, disc: 2.5},
+		{u: 4, v: 3, dist: 2.5, disc: 2.5},
+	} {
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(e.u), T: simple.Node(e.v), W: e.dist})
+		discomfort[[2]int64{e.u, e.v}] = e.disc
+		discomfort[[2]int64{e.v, e.u}] = e.disc
+	}
+	disc := func(xid, yid int64) (float64, bool) {
+		if xid == yid {
+			return 0, true
+		}
+		w, ok := discomfort[[2]int64{xid, yid}]
+		return w, ok
+	}
+
+	for _, test := range []struct {
+		alpha    float64
+		want     []int64
+		wantDist float64
+		wantDisc float64
+	}{
+		{alpha: 1, want: []int64{0, 1, 3}, wantDist: 2, wantDisc: 20},
+		{alpha: 0, want: []int64{0, 2, 3}, wantDist: 10, wantDisc: 2},
+		{alpha: 0.5, want: []int64{0, 4, 3}, wantDist: 5, wantDisc: 5},
+	} {
+		path, dist, discTotal := ScalarizedAStar(simple.Node(0), simple.Node(3), g, nil, disc, test.alpha, nil)
+		if !reflect.DeepEqual(ids(path), test.want) {
+			t.Errorf("unexpected path for alpha=%v: got:%v want:%v", test.alpha, ids(path), test.want)
+		}
+		if dist != test.wantDist || discTotal != test.wantDisc {
+			t.Errorf("unexpected totals for alpha=%v: got:%v,%v want:%v,%v",
+				test.alpha, dist, discTotal, test.wantDist, test.wantDisc)
+		}
+	}
+
+	g.AddNode(simple.Node(5))
+	path, dist, discTotal := ScalarizedAStar(simple.Node(0), simple.Node(5), g, nil, disc, 0.5, nil)
+	if path != nil || !math.IsInf(dist, 1) || !math.IsInf(discTotal, 1) {
+		t.Errorf("unexpected result for unreachable goal: got:%v,%v,%v", ids(path), dist, discTotal)
+	}
+}
+
+func TestScalarizedAStarNilDiscomfort(t *testing.T) {
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	// The panic must not depend on alpha putting
+	// no weight on discomfort.
+	for _, alpha := range []float64{0, 0.5, 1} {
+		panicked := func() (panicked bool) {
+			defer func() { panicked = recover() != nil }()
+			ScalarizedAStar(simple.Node(0), simple.Node(1), g, nil, nil, alpha, nil)
+			return false
+		}()
+		if !panicked {
+			t.Errorf("expected panic for nil discomfort with alpha=%v", alpha)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ScenicAStar finds a path from s to t in g that favors edges for which isScenic
+// returns true while staying close to the cost of the shortest path. The search is
+// an A* search in which the weight of each scenic edge is reduced by bonus, with
+// reduced weights clamped at zero. If the true cost of the resulting path exceeds
+// the cost of the A*-shortest path from s to t by more than maxExtra, the shortest
+// path is returned instead. The returned path is given with its true cost and the
+// number of scenic edges it uses. If t is not reachable from s, the path is nil,
+// the cost is +Inf and the scenic edge count is zero.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, ScenicAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. The heuristic is used for both searches; a heuristic
+// that is admissible for the edge weights may not be admissible for the reduced
+// weights, in which case the scenic path may not minimize the reduced cost.
+// ScenicAStar will panic if g has an A*-reachable negative edge weight.
+func ScenicAStar(s, t graph.Node, g graph.Graph, weight Weighting, isScenic func(graph.Edge) bool, bonus, maxExtra float64, h Heuristic) (path []graph.Node, cost float64, scenic int) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1), 0
+	}
+	weight = weightFor(g, weight)
+	h = heuristicFor(g, h)
+
+	pt, _ := aStar(s, t, g, weight, h)
+	shortest, optimal := pt.To(t.ID())
+	if shortest == nil {
+		return nil, math.Inf(1), 0
+	}
+	countScenic := func(path []graph.Node) int {
+		var n int
+		for i := 1; i < len(path); i++ {
+			if isScenic(g.Edge(path[i-1].ID(), path[i].ID())) {
+				n++
+			}
+		}
+		return n
+	}
+	if bonus == 0 {
+		return shortest, optimal, countScenic(shortest)
+	}
+
+	reduced := func(xid, yid int64) (float64, bool) {
+		w, ok := weight(xid, yid)
+		if !ok || xid == yid {
+			return w, ok
+		}
+		if w < 0 {
+			panic("A*: negative edge weight")
+		}
+		if isScenic(g.Edge(xid, yid)) {
+			w = math.Max(w-bonus, 0)
+		}
+		return w, true
+	}
+	pt, _ = aStar(s, t, g, reduced, h)
+	path, _ = pt.To(t.ID())
+	cost = weightOf(path, weight)
+	if cost > optimal+maxExtra {
+		return shortest, optimal, countScenic(shortest)
+	}
+	return path, cost, countScenic(path)
+}
@@ -0,0 +1,76 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestScenicAStar(t *testing.T) {
+	// The direct route 0-1-2 costs 2 and the scenic
+	// route 0-3-4-2 costs 3 with every edge scenic.
+	// The mixed route 0-3-1-2 costs 3.5 with one
+	// scenic edge.
+	//
+	// 0 --1-- 1 --1-- 2
+	//  \     /       /
+	//   1  1.5      1
+	//    \ /       /
+	//     3 --1-- 4
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(0), T: simple.Node(3), W: 1},
+		{F: simple.Node(3), T: simple.Node(1), W: 1.5},
+		{F: simple.Node(3), T: simple.Node(4), W: 1},
+		{F: simple.Node(4), T: simple.Node(2), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	scenic := map[[2]int64]bool{{0, 3}: true, {3, 4}: true, {2, 4}: true}
+	isScenic := func(e graph.Edge) bool {
+		u, v := e.From().ID(), e.To().ID()
+		if v < u {
+			u, v = v, u
+		}
+		return scenic[[2]int64{u, v}]
+	}
+
+	for _, test := range []struct {
+		name       string
+		bonus      float64
+		maxExtra   float64
+		wantPath   []int64
+		wantCost   float64
+		wantScenic int
+	}{
+		{name: "no bonus", bonus: 0, maxExtra: math.Inf(1), wantPath: []int64{0, 1, 2}, wantCost: 2, wantScenic: 0},
+		{name: "small bonus", bonus: 0.25, maxExtra: math.Inf(1), wantPath: []int64{0, 1, 2}, wantCost: 2, wantScenic: 0},
+		{name: "within cap", bonus: 0.5, maxExtra: 1, wantPath: []int64{0, 3, 4, 2}, wantCost: 3, wantScenic: 3},
+		{name: "large bonus", bonus: 10, maxExtra: 1, wantPath: []int64{0, 3, 4, 2}, wantCost: 3, wantScenic: 3},
+		{name: "over cap", bonus: 0.5, maxExtra: 0.5, wantPath: []int64{0, 1, 2}, wantCost: 2, wantScenic: 0},
+	} {
+		path, cost, n := ScenicAStar(simple.Node(0), simple.Node(2), g, nil, isScenic, test.bonus, test.maxExtra, nil)
+		if !reflect.DeepEqual(ids(path), test.wantPath) || cost != test.wantCost || n != test.wantScenic {
+			t.Errorf("%s: unexpected result: got:%v cost:%v scenic:%d want:%v cost:%v scenic:%d",
+				test.name, ids(path), cost, n, test.wantPath, test.wantCost, test.wantScenic)
+		}
+		if cost > 2+test.maxExtra {
+			t.Errorf("%s: cost %v exceeds cap %v", test.name, cost, 2+test.maxExtra)
+		}
+	}
+
+	g.AddNode(simple.Node(5))
+	path, cost, n := ScenicAStar(simple.Node(0), simple.Node(5), g, nil, isScenic, 1, 1, nil)
+	if path != nil || !math.IsInf(cost, 1) || n != 0 {
+		t.Errorf("unexpected result for unreachable goal: got:%v cost:%v scenic:%d", ids(path), cost, n)
+	}
+}
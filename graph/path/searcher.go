@@ -0,0 +1,115 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/list"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Searcher is a shortest path query engine.
+type Searcher interface {
+	// Search returns a path from s to t and its
+	// cost. If no path exists, the returned path
+	// is nil and the cost is +Inf.
+	Search(s, t graph.Node) ([]graph.Node, float64)
+}
+
+// AStarSearcher is a Searcher that performs an A* search for each query.
+type AStarSearcher struct {
+	// Graph is the graph to search.
+	Graph graph.Graph
+
+	// Weight and Heuristic are the edge weighting
+	// and search heuristic. If Weight is nil, the
+	// Weight method of Graph is used if Graph
+	// implements Weighted, falling back to
+	// UniformCost otherwise. If Heuristic is nil,
+	// the HeuristicCost method of Graph is used
+	// if it implements HeuristicCoster, falling
+	// back to NullHeuristic otherwise.
+	Weight    Weighting
+	Heuristic Heuristic
+}
+
+// Search returns the A*-shortest path from s to t and its cost.
+func (a AStarSearcher) Search(s, t graph.Node) ([]graph.Node, float64) {
+	if a.Graph.Node(s.ID()) == nil || a.Graph.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	pt, _ := aStar(s, t, a.Graph, weightFor(a.Graph, a.Weight), heuristicFor(a.Graph, a.Heuristic))
+	return pt.To(t.ID())
+}
+
+// CachingSearcher is a Searcher that caches the results of an inner Searcher in a
+// least recently used cache keyed by the IDs of the query nodes. CachingSearcher
+// assumes that the graph and edge weights used by the inner Searcher do not change;
+// if they do, a new CachingSearcher must be created.
+//
+// A CachingSearcher is not safe for concurrent use.
+type CachingSearcher struct {
+	inner      Searcher
+	maxEntries int
+
+	// entries holds *cacheEntry values ordered
+	// from most to least recently used.
+	entries *list.List
+	index   map[[2]int64]*list.Element
+}
+
+type cacheEntry struct {
+	key  [2]int64
+	path []graph.Node
+	cost float64
+}
+
+// NewCachingSearcher returns a CachingSearcher wrapping inner that holds at most
+// maxEntries query results. NewCachingSearcher will panic if maxEntries is not
+// positive.
+func NewCachingSearcher(inner Searcher, maxEntries int) *CachingSearcher {
+	if maxEntries <= 0 {
+		panic("path: non-positive cache size")
+	}
+	return &CachingSearcher{
+		inner:      inner,
+		maxEntries: maxEntries,
+		entries:    list.New(),
+		index:      make(map[[2]int64]*list.Element),
+	}
+}
+
+// Search returns a path from s to t and its cost, using a cached result if one is
+// available. The returned path is a copy and may be modified by the caller.
+func (c *CachingSearcher) Search(s, t graph.Node) ([]graph.Node, float64) {
+	key := [2]int64{s.ID(), t.ID()}
+	if e, ok := c.index[key]; ok {
+		c.entries.MoveToFront(e)
+		ent := e.Value.(*cacheEntry)
+		return copyPath(ent.path), ent.cost
+	}
+
+	path, cost := c.inner.Search(s, t)
+	c.index[key] = c.entries.PushFront(&cacheEntry{key: key, path: copyPath(path), cost: cost})
+	if c.entries.Len() > c.maxEntries {
+		oldest := c.entries.Back()
+		c.entries.Remove(oldest)
+		delete(c.index, oldest.Value.(*cacheEntry).key)
+	}
+	return path, cost
+}
+
+// Len returns the number of cached results.
+func (c *CachingSearcher) Len() int {
+	return c.entries.Len()
+}
+
+func copyPath(p []graph.Node) []graph.Node {
+	if p == nil {
+		return nil
+	}
+	return append([]graph.Node(nil), p...)
+}
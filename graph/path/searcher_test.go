@@ -0,0 +1,89 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// countingSearcher is a Searcher that
+// counts calls to its Search method.
+type countingSearcher struct {
+	Searcher
+	calls int
+}
+
+func (s *countingSearcher) Search(u, v graph.Node) ([]graph.Node, float64) {
+	s.calls++
+	return s.Searcher.Search(u, v)
+}
+
+func TestCachingSearcher(t *testing.T) {
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for i := 0; i < 5; i++ {
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i), T: simple.Node(i + 1), W: 1})
+	}
+	g.AddNode(simple.Node(10))
+
+	inner := &countingSearcher{Searcher: AStarSearcher{Graph: g}}
+	c := NewCachingSearcher(inner, 2)
+
+	p, cost := c.Search(simple.Node(0), simple.Node(5))
+	if want := []int64{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(ids(p), want) || cost != 5 {
+		t.Fatalf("unexpected path: got:%v cost:%v want:%v cost:5", ids(p), cost, want)
+	}
+
+	// Mutating a returned path must not alter the cache.
+	p[0] = simple.Node(-1)
+	p, cost = c.Search(simple.Node(0), simple.Node(5))
+	if want := []int64{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(ids(p), want) || cost != 5 {
+		t.Errorf("unexpected cached path: got:%v cost:%v want:%v cost:5", ids(p), cost, want)
+	}
+	if inner.calls != 1 {
+		t.Errorf("unexpected number of inner searches for repeated query: got:%d want:1", inner.calls)
+	}
+	p[1] = simple.Node(-1)
+	p, _ = c.Search(simple.Node(0), simple.Node(5))
+	if want := []int64{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(ids(p), want) {
+		t.Errorf("unexpected cached path after mutating a cache hit: got:%v want:%v", ids(p), want)
+	}
+
+	// Unreachable results are cached.
+	p, cost = c.Search(simple.Node(0), simple.Node(10))
+	if p != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected path to unreachable node: got:%v cost:%v", ids(p), cost)
+	}
+	c.Search(simple.Node(0), simple.Node(10))
+	if inner.calls != 2 {
+		t.Errorf("unexpected number of inner searches: got:%d want:2", inner.calls)
+	}
+
+	// Eviction bounds the cache and discards
+	// the least recently used entry.
+	c.Search(simple.Node(0), simple.Node(5))
+	c.Search(simple.Node(1), simple.Node(3))
+	if c.Len() != 2 {
+		t.Errorf("unexpected cache size: got:%d want:2", c.Len())
+	}
+	if inner.calls != 3 {
+		t.Errorf("unexpected number of inner searches: got:%d want:3", inner.calls)
+	}
+	c.Search(simple.Node(0), simple.Node(5))
+	if inner.calls != 3 {
+		t.Errorf("recently used entry was evicted")
+	}
+	c.Search(simple.Node(0), simple.Node(10))
+	if inner.calls != 4 {
+		t.Errorf("least recently used entry was not evicted")
+	}
+	if c.Len() != 2 {
+		t.Errorf("unexpected cache size: got:%d want:2", c.Len())
+	}
+}
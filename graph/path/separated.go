@@ -0,0 +1,80 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/linear"
+)
+
+// SeparatedAStar finds the A*-shortest path from s to t in g that keeps at least
+// minSeparation hops away from every node of the routes in others. The hop distance
+// of each node from the nearest route node is found by a multi-source breadth-first
+// search from the route nodes, and nodes closer than minSeparation are excluded from
+// the search, including s and t. Route nodes are at distance zero, so a minSeparation
+// of one only excludes the routes themselves, and with minSeparation zero or less
+// SeparatedAStar is equivalent to AStar. If no separated path exists, the path is nil
+// and the cost is +Inf.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, SeparatedAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. SeparatedAStar will panic if g has an A*-reachable
+// negative edge weight.
+func SeparatedAStar(s, t graph.Node, others [][]graph.Node, minSeparation int, g graph.Graph, weight Weighting, h Heuristic) (path []graph.Node, cost float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	near := routeNeighborhood(others, g, minSeparation)
+	if near[s.ID()] || near[t.ID()] {
+		return nil, math.Inf(1)
+	}
+	view := filteredGraph{Graph: g, canEnter: func(n graph.Node) bool { return !near[n.ID()] }}
+	pt, _ := aStar(s, t, view, weightFor(g, weight), heuristicFor(g, h))
+	path, cost = pt.To(t.ID())
+	if path == nil {
+		return nil, math.Inf(1)
+	}
+	return path, cost
+}
+
+// routeNeighborhood returns the set of nodes of g that are fewer than
+// radius hops from a node of any of the routes, found by a breadth-first
+// search seeded with all the route nodes.
+func routeNeighborhood(routes [][]graph.Node, g graph.Graph, radius int) map[int64]bool {
+	near := make(map[int64]bool)
+	if radius <= 0 {
+		return near
+	}
+	var frontier linear.NodeQueue
+	for _, r := range routes {
+		for _, n := range r {
+			if g.Node(n.ID()) == nil || near[n.ID()] {
+				continue
+			}
+			near[n.ID()] = true
+			frontier.Enqueue(n)
+		}
+	}
+	for hops := 1; hops < radius && frontier.Len() != 0; hops++ {
+		var next linear.NodeQueue
+		for frontier.Len() != 0 {
+			u := frontier.Dequeue()
+			to := g.From(u.ID())
+			for to.Next() {
+				v := to.Node()
+				if near[v.ID()] {
+					continue
+				}
+				near[v.ID()] = true
+				next.Enqueue(v)
+			}
+		}
+		frontier = next
+	}
+	return near
+}
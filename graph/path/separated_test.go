@@ -0,0 +1,70 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestSeparatedAStar(t *testing.T) {
+	const n = 7
+	g := randomWeightedGrid(n, func() float64 { return 1 })
+
+	// The other route runs down the middle column
+	// from the top row, blocking the direct route
+	// along the top row from corner to corner.
+	var route []graph.Node
+	for r := 0; r < 4; r++ {
+		route = append(route, simple.Node(r*n+3))
+	}
+	others := [][]graph.Node{route}
+	s, d := simple.Node(0), simple.Node(n-1)
+
+	hops := func(id int64) int {
+		best := math.MaxInt32
+		r, c := int(id)/n, int(id)%n
+		for _, u := range route {
+			ur, uc := int(u.ID())/n, int(u.ID())%n
+			if h := abs(r-ur) + abs(c-uc); h < best {
+				best = h
+			}
+		}
+		return best
+	}
+
+	for _, test := range []struct {
+		minSeparation int
+		wantCost      float64
+	}{
+		{minSeparation: 0, wantCost: 6},
+		{minSeparation: 1, wantCost: 14},
+		{minSeparation: 2, wantCost: 16},
+		{minSeparation: 3, wantCost: 18},
+		{minSeparation: 4, wantCost: math.Inf(1)},
+	} {
+		p, cost := SeparatedAStar(s, d, others, test.minSeparation, g, nil, nil)
+		if cost != test.wantCost {
+			t.Errorf("unexpected cost for separation %d: got:%v want:%v", test.minSeparation, cost, test.wantCost)
+		}
+		if math.IsInf(test.wantCost, 1) {
+			if p != nil {
+				t.Errorf("unexpected path for separation %d: %v", test.minSeparation, ids(p))
+			}
+			continue
+		}
+		if len(p) == 0 || p[0].ID() != s.ID() || p[len(p)-1].ID() != d.ID() {
+			t.Errorf("unexpected path end points for separation %d: %v", test.minSeparation, ids(p))
+		}
+		for _, u := range p {
+			if h := hops(u.ID()); h < test.minSeparation {
+				t.Errorf("path for separation %d passes node %d at %d hops from route", test.minSeparation, u.ID(), h)
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// SmoothAStar finds the A*-shortest path from s to t in g where each turn from prev
+// through curr to next adds anglePenalty*turnAngle(prev, curr, next) to the cost of
+// the path. The turnAngle function is provided by the caller since g holds no
+// geometric information; it should return zero for a straight continuation. The path
+// and its penalised cost are returned. If t is not reachable from s, the path is nil
+// and the cost is +Inf.
+//
+// The search is performed over (node, previous node) states, so a node may be
+// expanded once for each of its neighbors.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, SmoothAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. A heuristic admissible for the unpenalised weights remains
+// so for non-negative turn penalties. SmoothAStar will panic if g has an
+// A*-reachable negative penalised edge weight.
+func SmoothAStar(s, t graph.Node, g graph.Graph, weight Weighting, turnAngle func(prev, curr, next graph.Node) float64, anglePenalty float64, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+
+	// The state of a label is the ID of the previous
+	// node, or nil for the start label.
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		uid := u.node.ID()
+		prev, turning := u.state.(int64)
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			w, ok := weight(uid, vid)
+			if !ok {
+				panic("A*: unexpected invalid weight")
+			}
+			if turning {
+				w += anglePenalty * turnAngle(g.Node(prev), u.node, v)
+			}
+			yield(v, uid, w)
+		}
+	}
+	l, _ := stateAStar(s, nil, t, heuristicFor(g, h), expand, isNode(t))
+	if l == nil {
+		return nil, math.Inf(1)
+	}
+	return l.path(), l.gscore
+}
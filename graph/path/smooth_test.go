@@ -0,0 +1,82 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestSmoothAStar(t *testing.T) {
+	const n = 6
+	id := func(r, c int) int64 { return int64(r*n + c) }
+
+	// A grid where a staircase along the diagonal
+	// is slightly cheaper than any other route.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			if c+1 < n {
+				w := 1.0
+				if r == c {
+					w = 0.95
+				}
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(id(r, c)), T: simple.Node(id(r, c+1)), W: w})
+			}
+			if r+1 < n {
+				w := 1.0
+				if c == r+1 {
+					w = 0.95
+				}
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(id(r, c)), T: simple.Node(id(r+1, c)), W: w})
+			}
+		}
+	}
+
+	angle := func(prev, curr, next graph.Node) float64 {
+		dr1, dc1 := (curr.ID()-prev.ID())/n, (curr.ID()-prev.ID())%n
+		dr2, dc2 := (next.ID()-curr.ID())/n, (next.ID()-curr.ID())%n
+		return math.Acos(float64(dr1*dr2 + dc1*dc2))
+	}
+	turns := func(p []graph.Node) int {
+		var k int
+		for i := 2; i < len(p); i++ {
+			if angle(p[i-2], p[i-1], p[i]) != 0 {
+				k++
+			}
+		}
+		return k
+	}
+
+	s, goal := simple.Node(id(0, 0)), simple.Node(id(n-1, n-1))
+	pt, _ := AStar(s, goal, g, nil)
+	plain, plainCost := pt.To(goal.ID())
+	if got, want := turns(plain), 2*(n-1)-1; got != want {
+		t.Fatalf("unexpected number of turns in plain path: got:%d want:%d", got, want)
+	}
+
+	unpenalised, cost := SmoothAStar(s, goal, g, nil, angle, 0, nil)
+	if !equalPaths(unpenalised, plain) || cost != plainCost {
+		t.Errorf("unexpected path without penalty: got:%v cost=%v want:%v cost=%v",
+			ids(unpenalised), cost, ids(plain), plainCost)
+	}
+
+	smooth, cost := SmoothAStar(s, goal, g, nil, angle, 1, nil)
+	if got := turns(smooth); got != 1 {
+		t.Errorf("unexpected number of turns in smooth path %v: got:%d want:1", ids(smooth), got)
+	}
+	// The best single turn route uses the cheap first and last edges.
+	if want := 2*(n-1) - 0.1 + math.Pi/2; !floats.EqualWithinAbsOrRel(cost, want, 1e-12, 1e-12) {
+		t.Errorf("unexpected smooth path cost: got:%v want:%v", cost, want)
+	}
+
+	if p, cost := SmoothAStar(s, simple.Node(-1), g, nil, angle, 1, nil); p != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected result for missing target: got:%v cost=%v", ids(p), cost)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// SnapToNearest returns the node in nodes that is closest in Euclidean distance to
+// the point (x, y), where the coordinates of each node are given by coordOf. Ties
+// are resolved in favor of the earliest node in nodes. If nodes is empty,
+// SnapToNearest returns nil. SnapToNearest is intended for finding the start and
+// goal nodes of a search from coordinates that are not on the graph.
+//
+// SnapToNearest performs a linear scan of nodes. When many points are snapped to
+// the same large set of nodes, a spatial index over the nodes will be faster.
+func SnapToNearest(x, y float64, nodes []graph.Node, coordOf func(graph.Node) (x, y float64)) graph.Node {
+	var nearest graph.Node
+	best := math.Inf(1)
+	for _, n := range nodes {
+		nx, ny := coordOf(n)
+		dx, dy := nx-x, ny-y
+		if d := dx*dx + dy*dy; d < best {
+			nearest = n
+			best = d
+		}
+	}
+	return nearest
+}
@@ -0,0 +1,53 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestSnapToNearest(t *testing.T) {
+	coords := []struct{ x, y float64 }{
+		{0, 0},
+		{10, 0},
+		{0, 10},
+		{10, 10},
+		{5, 5},
+	}
+	nodes := make([]graph.Node, len(coords))
+	for i := range coords {
+		nodes[i] = simple.Node(i)
+	}
+	coordOf := func(n graph.Node) (float64, float64) {
+		c := coords[n.ID()]
+		return c.x, c.y
+	}
+
+	for _, test := range []struct {
+		x, y float64
+		want int64
+	}{
+		{x: 9, y: 1, want: 1},
+		{x: -3, y: 12, want: 2},
+		{x: 5.5, y: 4, want: 4},
+		{x: 100, y: 100, want: 3},
+		{x: 0, y: 0, want: 0},
+
+		// Equidistant from 0 and 1; the first is chosen.
+		{x: 5, y: -5, want: 0},
+	} {
+		got := SnapToNearest(test.x, test.y, nodes, coordOf)
+		if got == nil || got.ID() != test.want {
+			t.Errorf("unexpected nearest node to (%v, %v): got:%v want:%d", test.x, test.y, got, test.want)
+		}
+	}
+
+	if got := SnapToNearest(0, 0, nil, coordOf); got != nil {
+		t.Errorf("unexpected nearest node for empty node set: %v", got)
+	}
+}
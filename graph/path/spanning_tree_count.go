@@ -0,0 +1,60 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/mat"
+)
+
+// SpanningTreeCount returns the number of spanning trees of the undirected graph
+// g, calculated using Kirchhoff's matrix-tree theorem as the determinant of the
+// graph Laplacian with the row and column of one node removed. If g implements
+// Weighted, the Laplacian is weighted by the edge weights and the returned value
+// is the sum over all spanning trees of the product of their edge weights.
+// Self loops do not contribute.
+//
+// The count is returned as a float64 to allow for the very large values that
+// may arise, and so is subject to floating point error. A disconnected graph has
+// no spanning trees, and the empty graph has none by convention.
+func SpanningTreeCount(g graph.Undirected) float64 {
+	nodes := graph.NodesOf(g.Nodes())
+	switch len(nodes) {
+	case 0:
+		return 0
+	case 1:
+		return 1
+	}
+	weight := weightFor(g, nil)
+
+	// Build the Laplacian minor excluding the last node.
+	n := len(nodes) - 1
+	indexOf := make(map[int64]int, len(nodes))
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+	l := mat.NewSymDense(n, nil)
+	for i, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if vid == uid {
+				continue
+			}
+			w, ok := weight(uid, vid)
+			if !ok {
+				continue
+			}
+			if i < n {
+				l.SetSym(i, i, l.At(i, i)+w)
+			}
+			if j := indexOf[vid]; i < n && j < n && i < j {
+				l.SetSym(i, j, -w)
+			}
+		}
+	}
+	return mat.Det(l)
+}
@@ -0,0 +1,63 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestSpanningTreeCount(t *testing.T) {
+	cycle := func(n int) *simple.WeightedUndirectedGraph {
+		g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		for i := 0; i < n; i++ {
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i), T: simple.Node((i + 1) % n), W: 1})
+		}
+		return g
+	}
+	complete := func(n int) *simple.UndirectedGraph {
+		g := simple.NewUndirectedGraph()
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(j)})
+			}
+		}
+		return g
+	}
+
+	for n := 3; n <= 10; n++ {
+		if got := SpanningTreeCount(cycle(n)); !floats.EqualWithinAbsOrRel(got, float64(n), 1e-9, 1e-9) {
+			t.Errorf("unexpected spanning tree count for C%d: got:%v want:%d", n, got, n)
+		}
+	}
+	for n := 2; n <= 8; n++ {
+		// Cayley's formula.
+		want := math.Pow(float64(n), float64(n-2))
+		if got := SpanningTreeCount(complete(n)); !floats.EqualWithinAbsOrRel(got, want, 1e-9, 1e-9) {
+			t.Errorf("unexpected spanning tree count for K%d: got:%v want:%v", n, got, want)
+		}
+	}
+
+	// The weighted count of a triangle is the sum of
+	// products of pairs of edge weights.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 2})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: 3})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(2), T: simple.Node(0), W: 5})
+	if got, want := SpanningTreeCount(g), 2.0*3+3*5+5*2; !floats.EqualWithinAbsOrRel(got, want, 1e-9, 1e-9) {
+		t.Errorf("unexpected weighted spanning tree count: got:%v want:%v", got, want)
+	}
+
+	// A disconnected graph has no spanning tree.
+	d := simple.NewUndirectedGraph()
+	d.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	d.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(3)})
+	if got := SpanningTreeCount(d); math.Abs(got) > 1e-9 {
+		t.Errorf("unexpected spanning tree count for disconnected graph: got:%v want:0", got)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// StablePathAStar finds an A*-shortest path from s to t in g biased towards reusing
+// the edges of a previously computed path. During the search, overlapBonus is
+// subtracted from the weight of each edge traversed by previous, with the result
+// clamped at zero, so the returned path follows previous where it is no more than
+// overlapBonus per reused edge more expensive than the alternatives. For undirected
+// graphs an edge of previous is reused when traversed in either direction. The path
+// and its true, unbonused cost are returned. If t is not reachable from s, the path
+// is nil and the cost is +Inf.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, StablePathAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. The heuristic should be admissible with respect to the
+// bonused weights for the bias to be applied exactly. StablePathAStar will panic if
+// g has an A*-reachable negative edge weight.
+func StablePathAStar(s, t graph.Node, previous []graph.Node, overlapBonus float64, g graph.Graph, weight Weighting, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+
+	_, undirected := g.(graph.Undirected)
+	reused := make(map[[2]int64]bool)
+	for i := 1; i < len(previous); i++ {
+		u, v := previous[i-1].ID(), previous[i].ID()
+		reused[[2]int64{u, v}] = true
+		if undirected {
+			reused[[2]int64{v, u}] = true
+		}
+	}
+	bonused := func(xid, yid int64) (float64, bool) {
+		w, ok := weight(xid, yid)
+		if !ok || !reused[[2]int64{xid, yid}] {
+			return w, ok
+		}
+		return math.Max(w-overlapBonus, 0), true
+	}
+
+	pt, _ := aStar(s, t, g, bonused, heuristicFor(g, h))
+	p, _ := pt.To(t.ID())
+	if p == nil {
+		return nil, math.Inf(1)
+	}
+	return p, weightOf(p, weight)
+}
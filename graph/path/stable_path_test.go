@@ -0,0 +1,56 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestStablePathAStar(t *testing.T) {
+	// Two nearly tied routes from 0 to 3; the route via 1
+	// is slightly cheaper than the previously used route
+	// via 2.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 1.1},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+		{F: simple.Node(3), T: simple.Node(4), W: 5},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	previous := []graph.Node{simple.Node(3), simple.Node(2), simple.Node(0)}
+
+	for _, test := range []struct {
+		bonus    float64
+		wantPath []int64
+		wantCost float64
+	}{
+		{bonus: 0, wantPath: []int64{0, 1, 3}, wantCost: 2},
+		{bonus: 0.2, wantPath: []int64{0, 2, 3}, wantCost: 2.1},
+		{bonus: 10, wantPath: []int64{0, 2, 3}, wantCost: 2.1},
+	} {
+		p, cost := StablePathAStar(simple.Node(0), simple.Node(3), previous, test.bonus, g, nil, nil)
+		if got := ids(p); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("unexpected path for bonus %v: got:%v want:%v", test.bonus, got, test.wantPath)
+		}
+		if cost != test.wantCost {
+			t.Errorf("unexpected cost for bonus %v: got:%v want:%v", test.bonus, cost, test.wantCost)
+		}
+	}
+
+	// Reused edges are preferred on the way to targets
+	// beyond the end of the previous path.
+	p, cost := StablePathAStar(simple.Node(0), simple.Node(4), previous, 10, g, nil, nil)
+	if got, want := ids(p), []int64{0, 2, 3, 4}; !reflect.DeepEqual(got, want) || cost != 7.1 {
+		t.Errorf("unexpected path to 4: got:%v cost=%v want:%v cost=7.1", got, cost, want)
+	}
+}
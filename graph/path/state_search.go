@@ -0,0 +1,116 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// stateLabel is an A* search label for a node in an augmented
+// state space. Labels are identified by the pair of the node ID
+// and the state value, so a node may be visited more than once
+// in different states.
+type stateLabel struct {
+	node graph.Node
+
+	// state holds the search state associated
+	// with the label. It must be comparable.
+	state interface{}
+
+	gscore float64
+	fscore float64
+
+	// prev is the label this label
+	// was reached from.
+	prev *stateLabel
+}
+
+// path returns the node path leading to the label.
+func (l *stateLabel) path() []graph.Node {
+	var p []graph.Node
+	for ; l != nil; l = l.prev {
+		p = append(p, l.node)
+	}
+	ordered.Reverse(p)
+	return p
+}
+
+// stateKey is the closed set key of a stateLabel.
+type stateKey struct {
+	id    int64
+	state interface{}
+}
+
+// stateExpander calls yield for each successor of the label u with the
+// successor node, its search state and the cost of the transition.
+type stateExpander func(u *stateLabel, yield func(v graph.Node, state interface{}, w float64))
+
+// stateAStar performs an A* search from the node s in the given initial
+// state using the heuristic h towards t. Successors are generated by
+// expand and the search terminates at the first expanded label for which
+// isGoal returns true, returning that label. If no such label is found,
+// the returned label is nil. The number of expanded labels is also
+// returned. Transitions with infinite cost are not followed and
+// stateAStar will panic if a transition has a negative cost.
+func stateAStar(s graph.Node, state interface{}, t graph.Node, h Heuristic, expand stateExpander, isGoal func(*stateLabel) bool) (goal *stateLabel, expanded int) {
+	best := map[stateKey]float64{{id: s.ID(), state: state}: 0}
+	closed := make(map[stateKey]bool)
+	open := stateQueue{{node: s, state: state, fscore: h(s, t)}}
+	for open.Len() != 0 {
+		u := heap.Pop(&open).(*stateLabel)
+		k := stateKey{id: u.node.ID(), state: u.state}
+		if closed[k] {
+			continue
+		}
+		closed[k] = true
+		expanded++
+		if isGoal(u) {
+			return u, expanded
+		}
+		expand(u, func(v graph.Node, state interface{}, w float64) {
+			if w < 0 {
+				panic("A*: negative edge weight")
+			}
+			if math.IsInf(w, 1) {
+				return
+			}
+			k := stateKey{id: v.ID(), state: state}
+			if closed[k] {
+				return
+			}
+			g := u.gscore + w
+			if b, ok := best[k]; ok && g >= b {
+				return
+			}
+			best[k] = g
+			heap.Push(&open, &stateLabel{node: v, state: state, gscore: g, fscore: g + h(v, t), prev: u})
+		})
+	}
+	return nil, expanded
+}
+
+// isNode returns a goal test for stateAStar that accepts any label at t.
+func isNode(t graph.Node) func(*stateLabel) bool {
+	tid := t.ID()
+	return func(l *stateLabel) bool { return l.node.ID() == tid }
+}
+
+// stateQueue implements a no-dec priority queue of stateLabels.
+type stateQueue []*stateLabel
+
+func (q stateQueue) Len() int            { return len(q) }
+func (q stateQueue) Less(i, j int) bool  { return q[i].fscore < q[j].fscore }
+func (q stateQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *stateQueue) Push(n interface{}) { *q = append(*q, n.(*stateLabel)) }
+func (q *stateQueue) Pop() interface{} {
+	t := *q
+	var n interface{}
+	n, *q = t[len(t)-1], t[:len(t)-1]
+	return n
+}
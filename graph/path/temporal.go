@@ -0,0 +1,62 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// TemporalAStar finds the earliest arrival path from s, departing at startTime, to t
+// in g where each edge may only be entered within a time window. The time taken to
+// traverse each edge is given by travelTime and the window of each edge by window.
+// An edge can be entered from its tail at any time in [open, close]; an agent
+// arriving at the tail before open waits until open, and an agent arriving after
+// close cannot use the edge. The path and the arrival time at t are returned. If t
+// cannot be reached, the path is nil and the arrival time is +Inf.
+//
+// Since waiting is allowed, arriving at a node earlier is never worse than arriving
+// later, so the search labels each node only with its earliest arrival time.
+//
+// If travelTime is nil, the Weight method of g is used if g implements Weighted,
+// falling back to UniformCost otherwise. If h is nil, TemporalAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. The heuristic must not overestimate the remaining travel
+// time. TemporalAStar will panic if g has an A*-reachable negative travel time.
+func TemporalAStar(s graph.Node, startTime float64, t graph.Node, g graph.Directed, travelTime Weighting, window func(graph.Edge) (open, close float64), h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	travelTime = weightFor(g, travelTime)
+
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		now := startTime + u.gscore
+		uid := u.node.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			open, close := window(g.Edge(uid, vid))
+			if now > close {
+				continue
+			}
+			var wait float64
+			if now < open {
+				wait = open - now
+			}
+			w, ok := travelTime(uid, vid)
+			if !ok {
+				panic("A*: unexpected invalid weight")
+			}
+			yield(v, nil, wait+w)
+		}
+	}
+	l, _ := stateAStar(s, nil, t, heuristicFor(g, h), expand, isNode(t))
+	if l == nil {
+		return nil, math.Inf(1)
+	}
+	return l.path(), startTime + l.gscore
+}
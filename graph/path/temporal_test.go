@@ -0,0 +1,73 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestTemporalAStar(t *testing.T) {
+	// A fast ferry route 0-1-3 and a slow road route 0-2-3.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 3},
+		{F: simple.Node(2), T: simple.Node(3), W: 3},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	always := func(graph.Edge) (float64, float64) { return math.Inf(-1), math.Inf(1) }
+	ferry := func(open, close float64) func(graph.Edge) (float64, float64) {
+		return func(e graph.Edge) (float64, float64) {
+			if e.From().ID() == 1 && e.To().ID() == 3 {
+				return open, close
+			}
+			return math.Inf(-1), math.Inf(1)
+		}
+	}
+
+	for _, test := range []struct {
+		name      string
+		startTime float64
+		window    func(graph.Edge) (float64, float64)
+		wantPath  []int64
+		wantTime  float64
+	}{
+		{name: "no windows", startTime: 10, window: always, wantPath: []int64{0, 1, 3}, wantTime: 12},
+		{name: "open ferry", startTime: 10, window: ferry(10, 12), wantPath: []int64{0, 1, 3}, wantTime: 12},
+		{name: "short wait", startTime: 10, window: ferry(13, 14), wantPath: []int64{0, 1, 3}, wantTime: 14},
+		{name: "long wait", startTime: 10, window: ferry(20, 21), wantPath: []int64{0, 2, 3}, wantTime: 16},
+		{name: "missed ferry", startTime: 10, window: ferry(0, 10.5), wantPath: []int64{0, 2, 3}, wantTime: 16},
+		{
+			name:      "closed roads",
+			startTime: 10,
+			window: func(e graph.Edge) (float64, float64) {
+				if e.From().ID() == 1 {
+					return 0, 5
+				}
+				if e.From().ID() == 2 {
+					return 0, 12
+				}
+				return math.Inf(-1), math.Inf(1)
+			},
+			wantPath: nil,
+			wantTime: math.Inf(1),
+		},
+	} {
+		p, arrival := TemporalAStar(simple.Node(0), test.startTime, simple.Node(3), g, nil, test.window, nil)
+		if got := ids(p); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("%s: unexpected path: got:%v want:%v", test.name, got, test.wantPath)
+		}
+		if arrival != test.wantTime {
+			t.Errorf("%s: unexpected arrival time: got:%v want:%v", test.name, arrival, test.wantTime)
+		}
+	}
+}
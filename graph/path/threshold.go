@@ -0,0 +1,117 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// ThresholdView returns a view of g that holds all the nodes of g and only the edges
+// of g with a weight in [minWeight, maxWeight]. The view is not a copy; edge weights
+// are evaluated as the view is traversed, so changes to g are reflected in the view.
+// If g is a graph.Undirected or a graph.Directed, the returned graph implements the
+// same interface, allowing, for example, the connected components of the strong
+// edges of an undirected graph to be found with topo.ConnectedComponents.
+//
+// The view implements Weighted, giving the weights of its edges by the weighting used
+// for the threshold, so path functions run on the view with a nil weighting use them.
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise.
+func ThresholdView(g graph.Graph, weight Weighting, minWeight, maxWeight float64) graph.Graph {
+	t := thresholdGraph{
+		weight:    weightFor(g, weight),
+		minWeight: minWeight,
+		maxWeight: maxWeight,
+	}
+	t.filteredGraph = filteredGraph{Graph: g, canWalk: t.allows}
+	switch g := g.(type) {
+	case graph.Undirected:
+		return thresholdUndirected{thresholdGraph: t, u: g}
+	case graph.Directed:
+		return thresholdDirected{thresholdGraph: t, d: g}
+	default:
+		return t
+	}
+}
+
+// thresholdGraph is a view of a graph that hides the edges
+// with weights outside [minWeight, maxWeight].
+type thresholdGraph struct {
+	filteredGraph
+
+	weight               Weighting
+	minWeight, maxWeight float64
+}
+
+func (g thresholdGraph) HasEdgeBetween(xid, yid int64) bool {
+	return g.Edge(xid, yid) != nil || g.Edge(yid, xid) != nil
+}
+
+// Weight returns the weight of the edge from x to y given by the
+// weighting of the view if the edge is in the view. Self connections
+// have the weight given by the weighting.
+func (g thresholdGraph) Weight(xid, yid int64) (w float64, ok bool) {
+	if xid == yid {
+		return g.weight(xid, yid)
+	}
+	if g.Edge(xid, yid) == nil {
+		return math.Inf(1), false
+	}
+	return g.weight(xid, yid)
+}
+
+func (g thresholdGraph) WeightedEdge(uid, vid int64) graph.WeightedEdge {
+	e := g.Edge(uid, vid)
+	if e == nil {
+		return nil
+	}
+	w, _ := g.weight(uid, vid)
+	return simple.WeightedEdge{F: e.From(), T: e.To(), W: w}
+}
+
+// allows returns whether the edge from u to v has a weight
+// within the threshold.
+func (g thresholdGraph) allows(uid, vid int64) bool {
+	w, ok := g.weight(uid, vid)
+	return ok && g.minWeight <= w && w <= g.maxWeight
+}
+
+// thresholdUndirected is a thresholdGraph of an undirected graph.
+type thresholdUndirected struct {
+	thresholdGraph
+	u graph.Undirected
+}
+
+func (g thresholdUndirected) EdgeBetween(xid, yid int64) graph.Edge {
+	e := g.u.EdgeBetween(xid, yid)
+	if e == nil || !g.allows(xid, yid) {
+		return nil
+	}
+	return e
+}
+
+func (g thresholdUndirected) WeightedEdgeBetween(xid, yid int64) graph.WeightedEdge {
+	return g.WeightedEdge(xid, yid)
+}
+
+// thresholdDirected is a thresholdGraph of a directed graph.
+type thresholdDirected struct {
+	thresholdGraph
+	d graph.Directed
+}
+
+func (g thresholdDirected) HasEdgeFromTo(uid, vid int64) bool {
+	return g.Edge(uid, vid) != nil
+}
+
+func (g thresholdDirected) To(id int64) graph.Nodes {
+	return filteredGraph{
+		Graph:   reversedGraph{g.d},
+		canWalk: func(vid, uid int64) bool { return g.allows(uid, vid) },
+	}.From(id)
+}
@@ -0,0 +1,125 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestThresholdView(t *testing.T) {
+	// Two strongly connected triangles, {0, 1, 2} and
+	// {3, 4, 5}, joined by a weak edge from 2 to 3.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 5},
+		{F: simple.Node(1), T: simple.Node(2), W: 6},
+		{F: simple.Node(2), T: simple.Node(0), W: 7},
+		{F: simple.Node(3), T: simple.Node(4), W: 5},
+		{F: simple.Node(4), T: simple.Node(5), W: 6},
+		{F: simple.Node(5), T: simple.Node(3), W: 7},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	for _, test := range []struct {
+		minWeight, maxWeight float64
+		want                 [][]int64
+	}{
+		{minWeight: 0, maxWeight: math.Inf(1), want: [][]int64{{0, 1, 2, 3, 4, 5}}},
+		{minWeight: 2, maxWeight: math.Inf(1), want: [][]int64{{0, 1, 2}, {3, 4, 5}}},
+		{minWeight: 7, maxWeight: math.Inf(1), want: [][]int64{{0, 2}, {1}, {3, 5}, {4}}},
+		{minWeight: 0, maxWeight: 5, want: [][]int64{{0, 1}, {2, 3, 4}, {5}}},
+		{minWeight: 10, maxWeight: 20, want: [][]int64{{0}, {1}, {2}, {3}, {4}, {5}}},
+	} {
+		view, ok := ThresholdView(g, nil, test.minWeight, test.maxWeight).(graph.Undirected)
+		if !ok {
+			t.Fatal("threshold view of undirected graph is not undirected")
+		}
+		var got [][]int64
+		for _, c := range topo.ConnectedComponents(view) {
+			sort.Sort(ordered.ByID(c))
+			got = append(got, ids(c))
+		}
+		sort.Slice(got, func(i, j int) bool { return got[i][0] < got[j][0] })
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected components for [%v, %v]: got:%v want:%v", test.minWeight, test.maxWeight, got, test.want)
+		}
+		if g.Nodes().Len() != view.Nodes().Len() {
+			t.Errorf("unexpected number of nodes for [%v, %v]: got:%d want:%d",
+				test.minWeight, test.maxWeight, view.Nodes().Len(), g.Nodes().Len())
+		}
+	}
+
+	// Directed views filter edges in both directions of traversal.
+	d := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	d.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	d.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(2), T: simple.Node(1), W: 5})
+	view, ok := ThresholdView(d, nil, 2, 10).(graph.Directed)
+	if !ok {
+		t.Fatal("threshold view of directed graph is not directed")
+	}
+	if view.HasEdgeFromTo(0, 1) || view.HasEdgeBetween(1, 0) || view.From(0).Len() != 0 {
+		t.Error("unexpected weak edge from 0 to 1 in directed view")
+	}
+	if !view.HasEdgeFromTo(2, 1) || view.HasEdgeFromTo(1, 2) {
+		t.Error("unexpected edges between 1 and 2 in directed view")
+	}
+	if got := ids(graph.NodesOf(view.To(1))); !reflect.DeepEqual(got, []int64{2}) {
+		t.Errorf("unexpected nodes to 1 in directed view: got:%v want:[2]", got)
+	}
+}
+
+func TestThresholdViewWeighted(t *testing.T) {
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 5},
+		{F: simple.Node(1), T: simple.Node(2), W: 6},
+		{F: simple.Node(2), T: simple.Node(0), W: 12},
+		{F: simple.Node(0), T: simple.Node(3), W: 1},
+		{F: simple.Node(3), T: simple.Node(2), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	view := ThresholdView(g, nil, 2, math.Inf(1))
+	wv, ok := view.(graph.Weighted)
+	if !ok {
+		t.Fatal("threshold view is not weighted")
+	}
+	for _, test := range []struct {
+		uid, vid int64
+		want     float64
+		wantOK   bool
+	}{
+		{uid: 0, vid: 1, want: 5, wantOK: true},
+		{uid: 2, vid: 0, want: 12, wantOK: true},
+		{uid: 0, vid: 3, want: math.Inf(1), wantOK: false},
+		{uid: 1, vid: 3, want: math.Inf(1), wantOK: false},
+		{uid: 1, vid: 1, want: 0, wantOK: true},
+	} {
+		w, ok := wv.Weight(test.uid, test.vid)
+		if w != test.want || ok != test.wantOK {
+			t.Errorf("unexpected weight from %d to %d: got:%v,%t want:%v,%t", test.uid, test.vid, w, ok, test.want, test.wantOK)
+		}
+	}
+
+	// Path functions given a nil weighting use the
+	// weights of the view rather than unit weights.
+	pt := DijkstraFrom(simple.Node(0), view)
+	if got := pt.WeightTo(2); got != 11 {
+		t.Errorf("unexpected weight of path in view: got:%v want:11", got)
+	}
+	if p, _ := pt.To(2); !reflect.DeepEqual(ids(p), []int64{0, 1, 2}) {
+		t.Errorf("unexpected path in view: got:%v want:[0 1 2]", ids(p))
+	}
+}
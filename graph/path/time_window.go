@@ -0,0 +1,65 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// TimeWindowAStar finds the earliest arrival path from s, departing at startTime, to
+// t in g where each node is only available within a time window. The time taken to
+// traverse each edge is given by travelTime and the window of each node by window. A
+// node can be occupied at any time in [open, close]; an agent arriving at a node
+// before open waits there until open, and a node cannot be entered after close. The
+// same rules apply to s at startTime and to t, so the returned arrival time at t is
+// no earlier than the opening of its window. If no feasible schedule reaches t, the
+// path is nil and the arrival time is +Inf.
+//
+// Since waiting is allowed, arriving at a node earlier is never worse than arriving
+// later, so the search labels each node only with its earliest arrival time.
+//
+// If travelTime is nil, the Weight method of g is used if g implements Weighted,
+// falling back to UniformCost otherwise. If h is nil, TimeWindowAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. The heuristic must not overestimate the remaining travel
+// time. TimeWindowAStar will panic if g has an A*-reachable negative travel time.
+func TimeWindowAStar(s graph.Node, startTime float64, t graph.Node, g graph.Directed, travelTime Weighting, window func(graph.Node) (open, close float64), h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	travelTime = weightFor(g, travelTime)
+
+	open, close := window(s)
+	if startTime > close {
+		return nil, math.Inf(1)
+	}
+	start := math.Max(startTime, open)
+
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		now := start + u.gscore
+		uid := u.node.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			w, ok := travelTime(uid, v.ID())
+			if !ok {
+				panic("A*: unexpected invalid weight")
+			}
+			arrival := now + w
+			open, close := window(v)
+			if arrival > close {
+				continue
+			}
+			yield(v, nil, math.Max(arrival, open)-now)
+		}
+	}
+	l, _ := stateAStar(s, nil, t, heuristicFor(g, h), expand, isNode(t))
+	if l == nil {
+		return nil, math.Inf(1)
+	}
+	return l.path(), start + l.gscore
+}
@@ -0,0 +1,61 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestTimeWindowAStar(t *testing.T) {
+	// A fast route through 1 and a slow route through 2.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 3},
+		{F: simple.Node(2), T: simple.Node(3), W: 3},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	windows := func(w map[int64][2]float64) func(graph.Node) (float64, float64) {
+		return func(n graph.Node) (float64, float64) {
+			if w, ok := w[n.ID()]; ok {
+				return w[0], w[1]
+			}
+			return math.Inf(-1), math.Inf(1)
+		}
+	}
+
+	for _, test := range []struct {
+		name      string
+		startTime float64
+		windows   map[int64][2]float64
+		wantPath  []int64
+		wantTime  float64
+	}{
+		{name: "no windows", startTime: 10, wantPath: []int64{0, 1, 3}, wantTime: 12},
+		{name: "open", startTime: 10, windows: map[int64][2]float64{1: {10, 12}}, wantPath: []int64{0, 1, 3}, wantTime: 12},
+		{name: "short wait", startTime: 10, windows: map[int64][2]float64{1: {13, 14}}, wantPath: []int64{0, 1, 3}, wantTime: 14},
+		{name: "long wait", startTime: 10, windows: map[int64][2]float64{1: {20, 21}}, wantPath: []int64{0, 2, 3}, wantTime: 16},
+		{name: "closed on arrival", startTime: 10, windows: map[int64][2]float64{1: {0, 10.5}}, wantPath: []int64{0, 2, 3}, wantTime: 16},
+		{name: "wait at start", startTime: 10, windows: map[int64][2]float64{0: {11, 20}}, wantPath: []int64{0, 1, 3}, wantTime: 13},
+		{name: "wait at goal", startTime: 10, windows: map[int64][2]float64{3: {15, 20}}, wantPath: []int64{0, 1, 3}, wantTime: 15},
+		{name: "closed start", startTime: 10, windows: map[int64][2]float64{0: {0, 5}}, wantTime: math.Inf(1)},
+		{name: "closed goal", startTime: 10, windows: map[int64][2]float64{1: {0, 5}, 3: {0, 15}}, wantTime: math.Inf(1)},
+	} {
+		path, arrival := TimeWindowAStar(simple.Node(0), test.startTime, simple.Node(3), g, nil, windows(test.windows), nil)
+		if !reflect.DeepEqual(ids(path), test.wantPath) {
+			t.Errorf("%s: unexpected path: got:%v want:%v", test.name, ids(path), test.wantPath)
+		}
+		if arrival != test.wantTime {
+			t.Errorf("%s: unexpected arrival time: got:%v want:%v", test.name, arrival, test.wantTime)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// TurnLimitedAStar finds the A*-shortest path from s to t in g that makes at most
+// maxTurns turns. The direction of each edge is given by directionOf, and a turn is
+// counted at each node where the directions of the edges entering and leaving it
+// differ. The path and its cost are returned. If no path from s to t stays within
+// the turn limit, the path is nil and the cost is +Inf.
+//
+// The search is performed over (node, direction, turn count) states, so a node may
+// be expanded once for each direction and number of turns with which it is reached.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, TurnLimitedAStar will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. TurnLimitedAStar will panic if maxTurns is negative or
+// if g has an A*-reachable negative edge weight.
+func TurnLimitedAStar(s, t graph.Node, maxTurns int, g graph.Graph, weight Weighting, directionOf func(from, to graph.Node) int, h Heuristic) ([]graph.Node, float64) {
+	if maxTurns < 0 {
+		panic("A*: negative turn limit")
+	}
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+
+	// turnState is the search state of a label. The
+	// start label has the zero state with moved false.
+	type turnState struct {
+		dir   int
+		turns int
+		moved bool
+	}
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		uid := u.node.ID()
+		st := u.state.(turnState)
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			w, ok := weight(uid, vid)
+			if !ok {
+				panic("A*: unexpected invalid weight")
+			}
+			next := turnState{dir: directionOf(u.node, v), turns: st.turns, moved: true}
+			if st.moved && next.dir != st.dir {
+				next.turns++
+				if next.turns > maxTurns {
+					continue
+				}
+			}
+			yield(v, next, w)
+		}
+	}
+	l, _ := stateAStar(s, turnState{}, t, heuristicFor(g, h), expand, isNode(t))
+	if l == nil {
+		return nil, math.Inf(1)
+	}
+	return l.path(), l.gscore
+}
@@ -0,0 +1,72 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestTurnLimitedAStar(t *testing.T) {
+	const (
+		east = iota
+		north
+	)
+	// The short route 0-1-2-3-4 zig-zags with three
+	// turns and the long route 0-5-6-7-4 turns once.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	dir := make(map[[2]int64]int)
+	for _, e := range []struct {
+		from, to int64
+		weight   float64
+		dir      int
+	}{
+		{from: 0, to: 1, weight: 1, dir: east},
+		{from: 1, to: 2, weight: 1, dir: north},
+		{from: 2, to: 3, weight: 1, dir: east},
+		{from: 3, to: 4, weight: 1, dir: north},
+
+		{from: 0, to: 5, weight: 1.5, dir: east},
+		{from: 5, to: 6, weight: 1.5, dir: east},
+		{from: 6, to: 7, weight: 1.5, dir: north},
+		{from: 7, to: 4, weight: 1.5, dir: north},
+	} {
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(e.from), T: simple.Node(e.to), W: e.weight})
+		dir[[2]int64{e.from, e.to}] = e.dir
+	}
+	directionOf := func(from, to graph.Node) int {
+		return dir[[2]int64{from.ID(), to.ID()}]
+	}
+
+	for _, test := range []struct {
+		maxTurns int
+		wantPath []int64
+		wantCost float64
+	}{
+		{maxTurns: 5, wantPath: []int64{0, 1, 2, 3, 4}, wantCost: 4},
+		{maxTurns: 3, wantPath: []int64{0, 1, 2, 3, 4}, wantCost: 4},
+		{maxTurns: 2, wantPath: []int64{0, 5, 6, 7, 4}, wantCost: 6},
+		{maxTurns: 1, wantPath: []int64{0, 5, 6, 7, 4}, wantCost: 6},
+		{maxTurns: 0, wantPath: nil, wantCost: math.Inf(1)},
+	} {
+		path, cost := TurnLimitedAStar(simple.Node(0), simple.Node(4), test.maxTurns, g, nil, directionOf, nil)
+		if got := ids(path); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("unexpected path for maxTurns=%d: got:%v want:%v", test.maxTurns, got, test.wantPath)
+		}
+		if cost != test.wantCost {
+			t.Errorf("unexpected cost for maxTurns=%d: got:%v want:%v", test.maxTurns, cost, test.wantCost)
+		}
+	}
+
+	// A straight path needs no turns.
+	path, cost := TurnLimitedAStar(simple.Node(0), simple.Node(6), 0, g, nil, directionOf, nil)
+	if got, want := ids(path), []int64{0, 5, 6}; !reflect.DeepEqual(got, want) || cost != 3 {
+		t.Errorf("unexpected straight path: got:%v,%v want:%v,3", got, cost, want)
+	}
+}
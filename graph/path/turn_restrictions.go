@@ -0,0 +1,95 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// AStarWithForbiddenTurns finds the A*-shortest path from s to t in g that makes no
+// forbidden turn. A turn from u through v to w is forbidden if forbidden contains the
+// key {u, v, w} of node IDs. The path and its cost are returned. If no path avoids the
+// forbidden turns, the path is nil and the cost is +Inf.
+//
+// The search is performed over (node, previous node) states, so a node may be
+// expanded once for each of its neighbors; the returned path may revisit a node when
+// that is the only way to avoid a forbidden turn.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted, falling
+// back to UniformCost otherwise. If h is nil, AStarWithForbiddenTurns will use the
+// g.HeuristicCost method if g implements HeuristicCoster, falling back to
+// NullHeuristic otherwise. AStarWithForbiddenTurns will panic if g has an
+// A*-reachable negative edge weight.
+func AStarWithForbiddenTurns(s, t graph.Node, g graph.Graph, weight Weighting, forbidden map[[3]int64]bool, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	weight = weightFor(g, weight)
+
+	// The state of a label is the ID of the previous
+	// node, or nil for the start label.
+	expand := func(u *stateLabel, yield func(graph.Node, interface{}, float64)) {
+		uid := u.node.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if prev, ok := u.state.(int64); ok && forbidden[[3]int64{prev, uid, vid}] {
+				continue
+			}
+			w, ok := weight(uid, vid)
+			if !ok {
+				panic("A*: unexpected invalid weight")
+			}
+			yield(v, uid, w)
+		}
+	}
+	l, _ := stateAStar(s, nil, t, heuristicFor(g, h), expand, isNode(t))
+	if l == nil {
+		return nil, math.Inf(1)
+	}
+	return l.path(), l.gscore
+}
+
+// LoadTurnRestrictions reads a set of forbidden turns for AStarWithForbiddenTurns
+// from r. Each line of the input holds the IDs of the from, via and to nodes of a
+// forbidden turn, separated by white space. Blank lines and lines starting with '#'
+// are ignored. Malformed lines result in an error that reports the line number.
+func LoadTurnRestrictions(r io.Reader) (map[[3]int64]bool, error) {
+	forbidden := make(map[[3]int64]bool)
+	sc := bufio.NewScanner(r)
+	var line int
+	for sc.Scan() {
+		line++
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("path: malformed turn restriction on line %d: %q", line, text)
+		}
+		var turn [3]int64
+		for i, f := range fields {
+			id, err := strconv.ParseInt(f, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("path: malformed turn restriction on line %d: %v", line, err)
+			}
+			turn[i] = id
+		}
+		forbidden[turn] = true
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return forbidden, nil
+}
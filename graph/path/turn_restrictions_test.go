@@ -0,0 +1,102 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+const turnRestrictions = `# from via to
+0 1 2
+0 1 5
+
+3 1 2
+1 4 1
+1 5 1
+`
+
+func TestLoadTurnRestrictions(t *testing.T) {
+	got, err := LoadTurnRestrictions(strings.NewReader(turnRestrictions))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[[3]int64]bool{
+		{0, 1, 2}: true,
+		{0, 1, 5}: true,
+		{3, 1, 2}: true,
+		{1, 4, 1}: true,
+		{1, 5, 1}: true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected restrictions: got:%v want:%v", got, want)
+	}
+
+	for _, bad := range []struct {
+		in   string
+		line string
+	}{
+		{in: "0 1 2\n0 1\n", line: "line 2"},
+		{in: "# comment\n0 1 x\n", line: "line 2"},
+		{in: "0 1 2 3\n", line: "line 1"},
+	} {
+		_, err := LoadTurnRestrictions(strings.NewReader(bad.in))
+		if err == nil {
+			t.Errorf("expected error for %q", bad.in)
+			continue
+		}
+		if !strings.Contains(err.Error(), bad.line) {
+			t.Errorf("error for %q does not report %s: %v", bad.in, bad.line, err)
+		}
+	}
+}
+
+func TestAStarWithForbiddenTurns(t *testing.T) {
+	// A crossroads at 1 with a detour loop 1-4-5-1.
+	//
+	//        2
+	//        |
+	//   0 -- 1 -- 3
+	//       / \
+	//      4 - 5
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(1), T: simple.Node(4), W: 1},
+		{F: simple.Node(4), T: simple.Node(5), W: 1},
+		{F: simple.Node(5), T: simple.Node(1), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	forbidden, err := LoadTurnRestrictions(strings.NewReader(turnRestrictions))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p, cost := AStarWithForbiddenTurns(simple.Node(0), simple.Node(2), g, nil, nil, nil)
+	if got, want := ids(p), []int64{0, 1, 2}; !reflect.DeepEqual(got, want) || cost != 2 {
+		t.Errorf("unexpected unrestricted path: got:%v cost:%v want:%v cost:2", got, cost, want)
+	}
+
+	// The turns 0-1-2 and 0-1-5 and the U-turns at 4 and 5 are
+	// forbidden so the route must go around the loop via 4.
+	p, cost = AStarWithForbiddenTurns(simple.Node(0), simple.Node(2), g, nil, forbidden, nil)
+	if got, want := ids(p), []int64{0, 1, 4, 5, 1, 2}; !reflect.DeepEqual(got, want) || cost != 5 {
+		t.Errorf("unexpected restricted path: got:%v cost:%v want:%v cost:5", got, cost, want)
+	}
+
+	// Routes not involving a forbidden turn are unaffected.
+	p, cost = AStarWithForbiddenTurns(simple.Node(0), simple.Node(3), g, nil, forbidden, nil)
+	if got, want := ids(p), []int64{0, 1, 3}; !reflect.DeepEqual(got, want) || cost != 2 {
+		t.Errorf("unexpected path: got:%v cost:%v want:%v cost:2", got, cost, want)
+	}
+}
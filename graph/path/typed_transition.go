@@ -0,0 +1,43 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// TypedTransitionAStar finds the A*-shortest path from s to t in g where each node
+// has a type given by typeOf and the cost of traversing the edge from u to v is
+// transition(typeOf(u), typeOf(v)), independent of any weights held by g. If
+// transition is nil, every edge has unit cost and the returned path has the fewest
+// edges. The path and its cost are returned. If t is not reachable from s, the path
+// is nil and the cost is +Inf.
+//
+// If h is nil, TypedTransitionAStar will use the g.HeuristicCost method if g
+// implements HeuristicCoster, falling back to NullHeuristic otherwise. The heuristic
+// must be admissible for the transition costs. TypedTransitionAStar will panic if a
+// transition cost reachable by A* is negative.
+func TypedTransitionAStar(s, t graph.Node, g graph.Directed, typeOf func(graph.Node) int, transition func(fromType, toType int) float64, h Heuristic) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+
+	weight := UniformCost(g)
+	if transition != nil {
+		weight = func(xid, yid int64) (w float64, ok bool) {
+			if xid == yid {
+				return 0, true
+			}
+			if g.Edge(xid, yid) == nil {
+				return math.Inf(1), false
+			}
+			return transition(typeOf(g.Node(xid)), typeOf(g.Node(yid))), true
+		}
+	}
+	pt, _ := aStar(s, t, g, weight, heuristicFor(g, h))
+	return pt.To(t.ID())
+}
@@ -0,0 +1,59 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestTypedTransitionAStar(t *testing.T) {
+	// The short route from 0 to 4 passes through the
+	// node 1 of type 1; the long route stays within
+	// type 0.
+	const (
+		a = iota
+		b
+	)
+	types := map[int64]int{0: a, 1: b, 2: a, 3: a, 4: a, 5: b}
+	typeOf := func(n graph.Node) int { return types[n.ID()] }
+	g := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 4}, {0, 2}, {2, 3}, {3, 4}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	g.AddNode(simple.Node(5))
+	matrix := func(m [2][2]float64) func(from, to int) float64 {
+		return func(from, to int) float64 { return m[from][to] }
+	}
+
+	for _, test := range []struct {
+		name       string
+		transition func(from, to int) float64
+		wantPath   []int64
+		wantCost   float64
+	}{
+		{name: "nil", transition: nil, wantPath: []int64{0, 1, 4}, wantCost: 2},
+		{name: "uniform", transition: matrix([2][2]float64{{1, 1}, {1, 1}}), wantPath: []int64{0, 1, 4}, wantCost: 2},
+		{name: "costly a to b", transition: matrix([2][2]float64{{1, 10}, {1, 1}}), wantPath: []int64{0, 2, 3, 4}, wantCost: 3},
+		{name: "cheap a to b", transition: matrix([2][2]float64{{2, 0.5}, {1, 1}}), wantPath: []int64{0, 1, 4}, wantCost: 1.5},
+	} {
+		p, cost := TypedTransitionAStar(simple.Node(0), simple.Node(4), g, typeOf, test.transition, nil)
+		if got := ids(p); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("unexpected path for %s transitions: got:%v want:%v", test.name, got, test.wantPath)
+		}
+		if cost != test.wantCost {
+			t.Errorf("unexpected cost for %s transitions: got:%v want:%v", test.name, cost, test.wantCost)
+		}
+	}
+
+	p, cost := TypedTransitionAStar(simple.Node(0), simple.Node(5), g, typeOf, nil, nil)
+	if p != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected result for unreachable target: got:%v cost=%v want:[] cost=+Inf", ids(p), cost)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// UnweightedShortestPath returns a shortest path from s to t in g, ignoring edge
+// weights, and the number of edges in the path. The search is a breadth first
+// search that terminates as soon as t is reached, so it avoids the priority queue
+// and floating point arithmetic required by AStar with UniformCost. If t is not
+// reachable from s, the path is nil and the hop count is -1.
+func UnweightedShortestPath(s, t graph.Node, g graph.Graph) (path []graph.Node, hops int) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, -1
+	}
+	sid, tid := s.ID(), t.ID()
+
+	// prev holds the predecessor of each visited
+	// node, with s as its own predecessor.
+	prev := map[int64]graph.Node{sid: s}
+	queue := []graph.Node{s}
+	for len(queue) != 0 && prev[tid] == nil {
+		u := queue[0]
+		queue = queue[1:]
+		to := g.From(u.ID())
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if prev[vid] != nil {
+				continue
+			}
+			prev[vid] = u
+			if vid == tid {
+				break
+			}
+			queue = append(queue, v)
+		}
+	}
+	if prev[tid] == nil {
+		return nil, -1
+	}
+
+	path = []graph.Node{g.Node(tid)}
+	for vid := tid; vid != sid; {
+		u := prev[vid]
+		path = append(path, u)
+		vid = u.ID()
+	}
+	ordered.Reverse(path)
+	return path, len(path) - 1
+}
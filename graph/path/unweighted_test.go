@@ -0,0 +1,108 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestUnweightedShortestPath(t *testing.T) {
+	// The shortest path from 0 to 4 is 0-1-4
+	// even though 0-2-3-4 is cheaper by weight.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 10},
+		{F: simple.Node(1), T: simple.Node(4), W: 10},
+		{F: simple.Node(0), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+		{F: simple.Node(3), T: simple.Node(4), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	g.AddNode(simple.Node(5))
+
+	for _, test := range []struct {
+		s, t     int64
+		wantPath []int64
+		wantHops int
+	}{
+		{s: 0, t: 4, wantPath: []int64{0, 1, 4}, wantHops: 2},
+		{s: 0, t: 3, wantPath: []int64{0, 2, 3}, wantHops: 2},
+		{s: 2, t: 2, wantPath: []int64{2}, wantHops: 0},
+		{s: 4, t: 0, wantPath: nil, wantHops: -1},
+		{s: 0, t: 5, wantPath: nil, wantHops: -1},
+		{s: 0, t: 6, wantPath: nil, wantHops: -1},
+	} {
+		path, hops := UnweightedShortestPath(simple.Node(test.s), simple.Node(test.t), g)
+		var got []int64
+		if path != nil {
+			got = ids(path)
+		}
+		if !reflect.DeepEqual(got, test.wantPath) || hops != test.wantHops {
+			t.Errorf("unexpected result for %d->%d: got:%v hops:%d want:%v hops:%d",
+				test.s, test.t, got, hops, test.wantPath, test.wantHops)
+		}
+	}
+}
+
+func TestUnweightedShortestPathMatchesAStar(t *testing.T) {
+	src := rand.NewSource(1)
+	for _, test := range []struct {
+		g      graph.Graph
+		unique bool
+	}{
+		{g: gnpUndirected(100, 0.05)},
+		// Shortest paths in a tree are unique.
+		{g: randomTree(100, src), unique: true},
+	} {
+		g := test.g
+		nodes := graph.NodesOf(g.Nodes())
+		for _, s := range nodes[:10] {
+			for _, d := range nodes {
+				path, hops := UnweightedShortestPath(s, d, g)
+				pt, _ := AStar(s, d, unweightedView{g}, nil)
+				want, cost := pt.To(d.ID())
+				if math.IsInf(cost, 1) {
+					if path != nil || hops != -1 {
+						t.Errorf("unexpected path for unreachable %d->%d: %v", s.ID(), d.ID(), ids(path))
+					}
+					continue
+				}
+				if float64(hops) != cost || len(path)-1 != hops || !topo.IsPathIn(g, path) {
+					t.Errorf("unexpected path for %d->%d: got:%v hops:%d want cost:%v",
+						s.ID(), d.ID(), ids(path), hops, cost)
+				}
+				if test.unique && !reflect.DeepEqual(ids(path), ids(want)) {
+					t.Errorf("path mismatch for %d->%d: got:%v want:%v", s.ID(), d.ID(), ids(path), ids(want))
+				}
+			}
+		}
+	}
+}
+
+// unweightedView hides the Weighted methods of a graph
+// so that AStar falls back to UniformCost.
+type unweightedView struct {
+	graph.Graph
+}
+
+// randomTree returns a random tree with n nodes.
+func randomTree(n int, src rand.Source) graph.Graph {
+	rnd := rand.New(src)
+	g := simple.NewUndirectedGraph()
+	g.AddNode(simple.Node(0))
+	for i := 1; i < n; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(rnd.Intn(i)), T: simple.Node(i)})
+	}
+	return g
+}
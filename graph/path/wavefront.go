@@ -0,0 +1,66 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// DijkstraWavefront returns the nodes of g reachable from s in the order they
+// are settled by Dijkstra's algorithm. The order traces the expanding frontier
+// of the search, so the nodes are in non-decreasing order of their distance
+// from s. If s is not in g, DijkstraWavefront returns nil.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted,
+// falling back to UniformCost otherwise. DijkstraWavefront will panic if g has
+// an s-reachable negative edge weight.
+func DijkstraWavefront(s graph.Node, g graph.Graph, weight Weighting) []graph.Node {
+	if g.Node(s.ID()) == nil {
+		return nil
+	}
+	weight = weightFor(g, weight)
+
+	var order []graph.Node
+	dist := map[int64]float64{s.ID(): 0}
+	settled := make(map[int64]bool)
+	Q := priorityQueue{{node: s, dist: 0}}
+	for Q.Len() != 0 {
+		mid := heap.Pop(&Q).(distanceNode)
+		mnid := mid.node.ID()
+		if settled[mnid] || mid.dist > dist[mnid] {
+			continue
+		}
+		settled[mnid] = true
+		order = append(order, mid.node)
+		to := g.From(mnid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if settled[vid] {
+				continue
+			}
+			w, ok := weight(mnid, vid)
+			if !ok {
+				panic("dijkstra: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("dijkstra: negative edge weight")
+			}
+			joint := mid.dist + w
+			old, seen := dist[vid]
+			if !seen {
+				old = math.Inf(1)
+			}
+			if joint < old {
+				dist[vid] = joint
+				heap.Push(&Q, distanceNode{node: v, dist: joint})
+			}
+		}
+	}
+	return order
+}
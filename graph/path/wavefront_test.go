@@ -0,0 +1,64 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestDijkstraWavefront(t *testing.T) {
+	src := rand.NewSource(1)
+	for _, directed := range []bool{false, true} {
+		for trial := 0; trial < 10; trial++ {
+			g := randomWeightedGraph(50, 0, 80, directed, src)
+			start := simple.Node(trial)
+			if g.Node(start.ID()) == nil {
+				continue
+			}
+			order := DijkstraWavefront(start, g, nil)
+			if len(order) == 0 || order[0].ID() != start.ID() {
+				t.Fatalf("unexpected first settled node for directed=%t trial %d: got:%v want:%d",
+					directed, trial, ids(order), start.ID())
+			}
+
+			pt := DijkstraFrom(start, g)
+			reachable := 0
+			for _, n := range graph.NodesOf(g.Nodes()) {
+				if _, d := pt.To(n.ID()); !math.IsInf(d, 1) {
+					reachable++
+				}
+			}
+			if len(order) != reachable {
+				t.Errorf("unexpected number of settled nodes for directed=%t trial %d: got:%d want:%d",
+					directed, trial, len(order), reachable)
+			}
+
+			seen := make(map[int64]bool)
+			prev := 0.0
+			for _, n := range order {
+				if seen[n.ID()] {
+					t.Errorf("node %d settled more than once for directed=%t trial %d", n.ID(), directed, trial)
+				}
+				seen[n.ID()] = true
+				_, d := pt.To(n.ID())
+				if d < prev {
+					t.Errorf("settle order not by non-decreasing distance for directed=%t trial %d: %v after %v",
+						directed, trial, d, prev)
+				}
+				prev = d
+			}
+		}
+	}
+
+	if got := DijkstraWavefront(simple.Node(-1), simple.NewDirectedGraph(), nil); got != nil {
+		t.Errorf("unexpected result for missing start node: got:%v", ids(got))
+	}
+}
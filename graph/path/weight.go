@@ -43,6 +43,30 @@ func UniformCost(g traverse.Graph) Weighting {
 	}
 }
 
+// CostModel is a source of edge costs, such as a trained model, that may be
+// updated between searches.
+type CostModel interface {
+	// Cost returns the cost of traversing the
+	// edge e from the node from to the node to.
+	Cost(from, to graph.Node, e graph.Edge) float64
+}
+
+// ModelCost returns a Weighting that returns the edge costs of g given by model
+// for existing edges, zero for node identity and Inf for otherwise absent edges.
+// The model is consulted on each call, so changes to the model are reflected in
+// subsequent searches using the returned Weighting.
+func ModelCost(g traverse.Graph, model CostModel) Weighting {
+	return func(xid, yid int64) (w float64, ok bool) {
+		if xid == yid {
+			return 0, true
+		}
+		if e := g.Edge(xid, yid); e != nil {
+			return model.Cost(e.From(), e.To(), e), true
+		}
+		return math.Inf(1), false
+	}
+}
+
 // Heuristic returns an estimate of the cost of travelling between two nodes.
 type Heuristic func(x, y graph.Node) float64
 
@@ -51,3 +75,60 @@ type Heuristic func(x, y graph.Node) float64
 type HeuristicCoster interface {
 	HeuristicCost(x, y graph.Node) float64
 }
+
+// MemoizeHeuristic returns a Heuristic that caches the values of h for the given goal,
+// so that h is evaluated at most once for each node when searching for a path to goal.
+// Estimates to nodes other than goal are passed through to h without caching. The
+// cache is held by the returned Heuristic, so a new Heuristic should be obtained for
+// each goal and whenever the values of h may have changed. The returned Heuristic is
+// not safe for concurrent use.
+func MemoizeHeuristic(goal graph.Node, h Heuristic) Heuristic {
+	gid := goal.ID()
+	cache := make(map[int64]float64)
+	return func(x, y graph.Node) float64 {
+		if y.ID() != gid {
+			return h(x, y)
+		}
+		xid := x.ID()
+		if v, ok := cache[xid]; ok {
+			return v
+		}
+		v := h(x, y)
+		cache[xid] = v
+		return v
+	}
+}
+
+// weightFor returns w if it is not nil. Otherwise it returns the Weight method
+// of g if g implements Weighted, falling back to UniformCost.
+func weightFor(g traverse.Graph, w Weighting) Weighting {
+	if w != nil {
+		return w
+	}
+	if wg, ok := g.(Weighted); ok {
+		return wg.Weight
+	}
+	return UniformCost(g)
+}
+
+// heuristicFor returns h if it is not nil. Otherwise it returns the HeuristicCost
+// method of g if g implements HeuristicCoster, falling back to NullHeuristic.
+func heuristicFor(g graph.Graph, h Heuristic) Heuristic {
+	if h != nil {
+		return h
+	}
+	if hg, ok := g.(HeuristicCoster); ok {
+		return hg.HeuristicCost
+	}
+	return NullHeuristic
+}
+
+// weightOf returns the sum of the weights of the edges along path.
+func weightOf(path []graph.Node, weight Weighting) float64 {
+	var sum float64
+	for i := 1; i < len(path); i++ {
+		w, _ := weight(path[i-1].ID(), path[i].ID())
+		sum += w
+	}
+	return sum
+}
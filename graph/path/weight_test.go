@@ -0,0 +1,123 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// idCostModel is a CostModel that returns costs based on endpoint IDs.
+type idCostModel struct {
+	// penalised holds the ID of a node
+	// that is expensive to enter.
+	penalised int64
+	calls     int
+}
+
+func (m *idCostModel) Cost(from, to graph.Node, _ graph.Edge) float64 {
+	m.calls++
+	if to.ID() == m.penalised {
+		return 10
+	}
+	return float64(1 + from.ID()%2)
+}
+
+func TestModelCost(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 3}, {0, 2}, {2, 3}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	model := &idCostModel{penalised: 1}
+	weight := ModelCost(g, model)
+
+	if w, ok := weight(0, 0); w != 0 || !ok {
+		t.Errorf("unexpected self weight: got:(%v, %t) want:(0, true)", w, ok)
+	}
+	if w, ok := weight(3, 0); !math.IsInf(w, 1) || ok {
+		t.Errorf("unexpected absent edge weight: got:(%v, %t) want:(+Inf, false)", w, ok)
+	}
+	if w, ok := weight(1, 3); w != 2 || !ok {
+		t.Errorf("unexpected edge weight: got:(%v, %t) want:(2, true)", w, ok)
+	}
+
+	for _, test := range []struct {
+		penalised int64
+		wantPath  []int64
+		wantCost  float64
+	}{
+		{penalised: 1, wantPath: []int64{0, 2, 3}, wantCost: 2},
+		{penalised: 2, wantPath: []int64{0, 1, 3}, wantCost: 3},
+	} {
+		// Updating the model changes the
+		// costs seen by later searches.
+		model.penalised = test.penalised
+		model.calls = 0
+		p, cost, _, _ := AStarWithOptions(simple.Node(0), simple.Node(3), g, AStarOptions{Weight: weight})
+		if got := ids(p); !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("unexpected path with node %d penalised: got:%v want:%v", test.penalised, got, test.wantPath)
+		}
+		if cost != test.wantCost {
+			t.Errorf("unexpected cost with node %d penalised: got:%v want:%v", test.penalised, cost, test.wantCost)
+		}
+		if model.calls == 0 {
+			t.Errorf("model not consulted with node %d penalised", test.penalised)
+		}
+	}
+}
+
+func TestMemoizeHeuristic(t *testing.T) {
+	const n = 10
+	g := randomWeightedGrid(n, randomIntWeight(rand.NewSource(1)))
+	s, goal := simple.Node(0), simple.Node(n*n-1)
+
+	calls := make(map[int64]int)
+	manhattan := func(x, y graph.Node) float64 {
+		calls[x.ID()]++
+		xr, xc := x.ID()/n, x.ID()%n
+		yr, yc := y.ID()/n, y.ID()%n
+		return math.Abs(float64(xr-yr)) + math.Abs(float64(xc-yc))
+	}
+
+	pt, _ := AStar(s, goal, g, manhattan)
+	_, want := pt.To(goal.ID())
+	var repeated bool
+	for _, c := range calls {
+		if c > 1 {
+			repeated = true
+			break
+		}
+	}
+	if !repeated {
+		t.Fatal("test graph does not exercise repeated heuristic evaluations")
+	}
+
+	calls = make(map[int64]int)
+	pt, _ = AStar(s, goal, g, MemoizeHeuristic(goal, manhattan))
+	if _, got := pt.To(goal.ID()); got != want {
+		t.Errorf("unexpected cost with memoized heuristic: got:%v want:%v", got, want)
+	}
+	for id, c := range calls {
+		if c > 1 {
+			t.Errorf("heuristic evaluated %d times for node %d", c, id)
+		}
+	}
+
+	// Estimates to other nodes are not cached.
+	calls = make(map[int64]int)
+	h := MemoizeHeuristic(goal, manhattan)
+	for _, y := range []graph.Node{simple.Node(1), simple.Node(2), goal, goal} {
+		h(s, y)
+	}
+	if calls[s.ID()] != 3 {
+		t.Errorf("unexpected number of evaluations for distinct targets: got:%d want:3", calls[s.ID()])
+	}
+}
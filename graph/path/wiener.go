@@ -0,0 +1,46 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// WienerIndex returns the Wiener index of g, the sum of the shortest path distances
+// between all unordered pairs of nodes. If g is a graph.Directed, the distances
+// between all ordered pairs are summed. Pairs of nodes that are not connected by a
+// path are excluded from the sum; the second return value reports whether all pairs
+// were included.
+//
+// If weight is nil, the Weight method of g is used if g implements Weighted,
+// falling back to UniformCost otherwise. WienerIndex will panic if g has a
+// negative edge weight.
+//
+// The time complexity of WienerIndex is O(|V|.|E|.log|V|).
+func WienerIndex(g graph.Graph, weight Weighting) (index float64, connected bool) {
+	weight = weightFor(g, weight)
+	_, directed := g.(graph.Directed)
+
+	nodes := graph.NodesOf(g.Nodes())
+	connected = true
+	for i, u := range nodes {
+		path := newShortestFrom(u, nodes)
+		dijkstraFrom(u, g, weight, &path)
+		for j, v := range nodes {
+			if j == i || (!directed && j < i) {
+				continue
+			}
+			d := path.dist[path.indexOf[v.ID()]]
+			if math.IsInf(d, 1) {
+				connected = false
+				continue
+			}
+			index += d
+		}
+	}
+	return index, connected
+}
@@ -0,0 +1,53 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestWienerIndex(t *testing.T) {
+	// The Wiener index of the path graph on n
+	// nodes is (n+1)n(n-1)/6.
+	for n := 1; n <= 10; n++ {
+		g := simple.NewUndirectedGraph()
+		g.AddNode(simple.Node(0))
+		for i := 1; i < n; i++ {
+			g.SetEdge(simple.Edge{F: simple.Node(i - 1), T: simple.Node(i)})
+		}
+		got, connected := WienerIndex(g, nil)
+		want := float64((n + 1) * n * (n - 1) / 6)
+		if got != want || !connected {
+			t.Errorf("unexpected Wiener index for path of %d nodes: got:%v,%t want:%v,true", n, got, connected, want)
+		}
+	}
+
+	// Edge weights scale the distances.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for i := 1; i < 4; i++ {
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i - 1), T: simple.Node(i), W: 2})
+	}
+	if got, _ := WienerIndex(g, nil); got != 20 {
+		t.Errorf("unexpected weighted Wiener index: got:%v want:20", got)
+	}
+
+	// Disconnected pairs are excluded and reported.
+	g.AddNode(simple.Node(10))
+	if got, connected := WienerIndex(g, nil); got != 20 || connected {
+		t.Errorf("unexpected Wiener index for disconnected graph: got:%v,%t want:20,false", got, connected)
+	}
+
+	// Directed graphs sum over ordered pairs.
+	d := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 0}} {
+		d.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	if got, connected := WienerIndex(d, nil); got != 9 || !connected {
+		t.Errorf("unexpected Wiener index for directed cycle: got:%v,%t want:9,true", got, connected)
+	}
+}
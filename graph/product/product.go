@@ -0,0 +1,129 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package product
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// Kind specifies the kind of graph product to construct.
+type Kind int
+
+const (
+	// Cartesian is the Cartesian product, where (a₁, b₁) is adjacent
+	// to (a₂, b₂) if a₁ = a₂ and b₁ is adjacent to b₂, or b₁ = b₂ and
+	// a₁ is adjacent to a₂.
+	Cartesian Kind = iota
+
+	// Tensor is the tensor product, where (a₁, b₁) is adjacent to
+	// (a₂, b₂) if a₁ is adjacent to a₂ and b₁ is adjacent to b₂.
+	Tensor
+
+	// Strong is the strong product, the union of the Cartesian
+	// and tensor products.
+	Strong
+)
+
+// Node is a product of two graph nodes.
+type Node struct {
+	UID int64
+	A   graph.Node
+	B   graph.Node
+}
+
+// ID implements the graph.Node interface.
+func (n Node) ID() int64 { return n.UID }
+
+// Graph returns the product of a and b of the given kind, and a function that
+// returns the product node corresponding to a node in a and a node in b. The
+// composer returns nil if either node is not in its factor graph. The nodes of
+// the returned graph are Node values.
+//
+// If both a and b are graph.Undirected, the returned graph is a
+// *simple.UndirectedGraph, otherwise it is a *simple.DirectedGraph and
+// undirected edges of a factor are treated as directed edges in both directions.
+// Graph will panic if kind is not a valid Kind.
+func Graph(a, b graph.Graph, kind Kind) (graph.Graph, func(na, nb graph.Node) graph.Node) {
+	if kind < Cartesian || Strong < kind {
+		panic("product: invalid product kind")
+	}
+
+	aNodes := graph.NodesOf(a.Nodes())
+	sort.Sort(ordered.ByID(aNodes))
+	bNodes := graph.NodesOf(b.Nodes())
+	sort.Sort(ordered.ByID(bNodes))
+	aIndex := indexOf(aNodes)
+	bIndex := indexOf(bNodes)
+
+	nodes := make([]Node, len(aNodes)*len(bNodes))
+	for i, na := range aNodes {
+		for j, nb := range bNodes {
+			id := int64(i*len(bNodes) + j)
+			nodes[id] = Node{UID: id, A: na, B: nb}
+		}
+	}
+	compose := func(na, nb graph.Node) graph.Node {
+		i, ok := aIndex[na.ID()]
+		if !ok {
+			return nil
+		}
+		j, ok := bIndex[nb.ID()]
+		if !ok {
+			return nil
+		}
+		return nodes[i*len(bNodes)+j]
+	}
+
+	var dst interface {
+		graph.Graph
+		graph.Builder
+	}
+	_, aUndirected := a.(graph.Undirected)
+	_, bUndirected := b.(graph.Undirected)
+	if aUndirected && bUndirected {
+		dst = simple.NewUndirectedGraph()
+	} else {
+		dst = simple.NewDirectedGraph()
+	}
+	for _, n := range nodes {
+		dst.AddNode(n)
+	}
+
+	link := func(u, v graph.Node) {
+		if u.ID() != v.ID() {
+			dst.SetEdge(dst.NewEdge(u, v))
+		}
+	}
+	for _, u := range nodes {
+		if kind == Cartesian || kind == Strong {
+			for _, nb := range graph.NodesOf(b.From(u.B.ID())) {
+				link(u, compose(u.A, nb))
+			}
+			for _, na := range graph.NodesOf(a.From(u.A.ID())) {
+				link(u, compose(na, u.B))
+			}
+		}
+		if kind == Tensor || kind == Strong {
+			for _, na := range graph.NodesOf(a.From(u.A.ID())) {
+				for _, nb := range graph.NodesOf(b.From(u.B.ID())) {
+					link(u, compose(na, nb))
+				}
+			}
+		}
+	}
+	return dst, compose
+}
+
+func indexOf(nodes []graph.Node) map[int64]int {
+	idx := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		idx[n.ID()] = i
+	}
+	return idx
+}
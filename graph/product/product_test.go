@@ -0,0 +1,161 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package product
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// pathGraph returns an undirected path graph on n nodes.
+func pathGraph(n int) *simple.UndirectedGraph {
+	g := simple.NewUndirectedGraph()
+	g.AddNode(simple.Node(0))
+	for i := 1; i < n; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i - 1), T: simple.Node(i)})
+	}
+	return g
+}
+
+func edgeCount(g graph.Graph) int {
+	var n int
+	nodes := g.Nodes()
+	for nodes.Next() {
+		n += g.From(nodes.Node().ID()).Len()
+	}
+	if _, ok := g.(graph.Undirected); ok {
+		n /= 2
+	}
+	return n
+}
+
+var productTests = []struct {
+	name      string
+	a, b      graph.Graph
+	kind      Kind
+	wantNodes int
+	wantEdges int
+}{
+	{name: "P3□P2", a: pathGraph(3), b: pathGraph(2), kind: Cartesian, wantNodes: 6, wantEdges: 3*1 + 2*2},
+	{name: "P3×P2", a: pathGraph(3), b: pathGraph(2), kind: Tensor, wantNodes: 6, wantEdges: 2 * 2 * 1},
+	{name: "P3⊠P2", a: pathGraph(3), b: pathGraph(2), kind: Strong, wantNodes: 6, wantEdges: 3*1 + 2*2 + 2*2*1},
+	{name: "P4□P4", a: pathGraph(4), b: pathGraph(4), kind: Cartesian, wantNodes: 16, wantEdges: 4*3 + 4*3},
+	{name: "P4×P4", a: pathGraph(4), b: pathGraph(4), kind: Tensor, wantNodes: 16, wantEdges: 2 * 3 * 3},
+}
+
+func TestGraph(t *testing.T) {
+	for _, test := range productTests {
+		g, compose := Graph(test.a, test.b, test.kind)
+		if n := g.Nodes().Len(); n != test.wantNodes {
+			t.Errorf("%s: unexpected number of nodes: got:%d want:%d", test.name, n, test.wantNodes)
+		}
+		if n := edgeCount(g); n != test.wantEdges {
+			t.Errorf("%s: unexpected number of edges: got:%d want:%d", test.name, n, test.wantEdges)
+		}
+		if _, ok := g.(graph.Undirected); !ok {
+			t.Errorf("%s: expected undirected product of undirected graphs", test.name)
+		}
+		if compose(simple.Node(-1), simple.Node(0)) != nil {
+			t.Errorf("%s: unexpected product node for missing factor node", test.name)
+		}
+
+		// Every product edge must project to a valid move in each factor.
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			for _, v := range graph.NodesOf(g.From(u.ID())) {
+				pu, pv := u.(Node), v.(Node)
+				ca := checkMove(test.a, pu.A, pv.A)
+				cb := checkMove(test.b, pu.B, pv.B)
+				switch test.kind {
+				case Cartesian:
+					if !(ca == stay && cb == move || ca == move && cb == stay) {
+						t.Errorf("%s: invalid Cartesian product edge %v-%v", test.name, pu, pv)
+					}
+				case Tensor:
+					if ca != move || cb != move {
+						t.Errorf("%s: invalid tensor product edge %v-%v", test.name, pu, pv)
+					}
+				case Strong:
+					if ca == invalid || cb == invalid || ca == stay && cb == stay {
+						t.Errorf("%s: invalid strong product edge %v-%v", test.name, pu, pv)
+					}
+				}
+			}
+		}
+	}
+}
+
+type moveKind int
+
+const (
+	invalid moveKind = iota
+	stay
+	move
+)
+
+func checkMove(g graph.Graph, u, v graph.Node) moveKind {
+	switch {
+	case u.ID() == v.ID():
+		return stay
+	case g.HasEdgeBetween(u.ID(), v.ID()):
+		return move
+	default:
+		return invalid
+	}
+}
+
+func TestCartesianPathProjection(t *testing.T) {
+	a, b := pathGraph(4), pathGraph(3)
+	g, compose := Graph(a, b, Cartesian)
+
+	s, goal := compose(simple.Node(0), simple.Node(0)), compose(simple.Node(3), simple.Node(2))
+	pt, _ := path.AStar(s, goal, g, nil)
+	p, weight := pt.To(goal.ID())
+	if weight != 5 {
+		t.Errorf("unexpected product path length: got:%v want:5", weight)
+	}
+	var movesA, movesB int
+	for i := 1; i < len(p); i++ {
+		u, v := p[i-1].(Node), p[i].(Node)
+		ca, cb := checkMove(a, u.A, v.A), checkMove(b, u.B, v.B)
+		if ca == invalid || cb == invalid {
+			t.Fatalf("path step %v-%v does not project to factor paths", u, v)
+		}
+		if ca == move {
+			movesA++
+		}
+		if cb == move {
+			movesB++
+		}
+	}
+	if movesA != 3 || movesB != 2 {
+		t.Errorf("unexpected projected path lengths: got:%d,%d want:3,2", movesA, movesB)
+	}
+}
+
+func TestDirectedFactor(t *testing.T) {
+	a := simple.NewDirectedGraph()
+	a.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	b := pathGraph(2)
+
+	g, compose := Graph(a, b, Cartesian)
+	d, ok := g.(graph.Directed)
+	if !ok {
+		t.Fatal("expected directed product for directed factor")
+	}
+	u, v := compose(simple.Node(0), simple.Node(0)), compose(simple.Node(1), simple.Node(0))
+	if !d.HasEdgeFromTo(u.ID(), v.ID()) {
+		t.Error("missing product edge along directed factor edge")
+	}
+	if d.HasEdgeFromTo(v.ID(), u.ID()) {
+		t.Error("unexpected product edge against directed factor edge")
+	}
+	w := compose(simple.Node(0), simple.Node(1))
+	if !d.HasEdgeFromTo(u.ID(), w.ID()) || !d.HasEdgeFromTo(w.ID(), u.ID()) {
+		t.Error("missing product edges along undirected factor edge")
+	}
+}
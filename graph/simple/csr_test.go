@@ -0,0 +1,227 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/iterator"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/testgraph"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+func csrBuilder(nodes []graph.Node, edges []testgraph.WeightedLine, self, absent float64) (g graph.Graph, n []graph.Node, e []testgraph.Edge, s, a float64, ok bool) {
+	g, n, e, s, a, ok = weightedDirectedBuilder(nodes, edges, self, absent)
+	if !ok {
+		return nil, nil, nil, s, a, false
+	}
+	return simple.NewCSRGraph(g.(graph.Directed), self, absent), n, e, s, a, true
+}
+
+func TestCSRGraph(t *testing.T) {
+	t.Run("EdgeExistence", func(t *testing.T) {
+		testgraph.EdgeExistence(t, csrBuilder)
+	})
+	t.Run("NodeExistence", func(t *testing.T) {
+		testgraph.NodeExistence(t, csrBuilder)
+	})
+	t.Run("ReturnAdjacentNodes", func(t *testing.T) {
+		testgraph.ReturnAdjacentNodes(t, csrBuilder, true)
+	})
+	t.Run("ReturnAllEdges", func(t *testing.T) {
+		testgraph.ReturnAllEdges(t, csrBuilder, true)
+	})
+	t.Run("ReturnAllNodes", func(t *testing.T) {
+		testgraph.ReturnAllNodes(t, csrBuilder, true)
+	})
+	t.Run("ReturnAllWeightedEdges", func(t *testing.T) {
+		testgraph.ReturnAllWeightedEdges(t, csrBuilder, true)
+	})
+	t.Run("ReturnEdgeSlice", func(t *testing.T) {
+		testgraph.ReturnEdgeSlice(t, csrBuilder, true)
+	})
+	t.Run("ReturnWeightedEdgeSlice", func(t *testing.T) {
+		testgraph.ReturnWeightedEdgeSlice(t, csrBuilder, true)
+	})
+	t.Run("ReturnNodeSlice", func(t *testing.T) {
+		testgraph.ReturnNodeSlice(t, csrBuilder, true)
+	})
+	t.Run("Weight", func(t *testing.T) {
+		testgraph.Weight(t, csrBuilder)
+	})
+}
+
+func TestToCSR(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, 0)
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(10), T: simple.Node(30), W: 2},
+		{F: simple.Node(10), T: simple.Node(20), W: 1},
+		{F: simple.Node(30), T: simple.Node(10), W: 3},
+		{F: simple.Node(20), T: simple.Node(30), W: 4},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	g.AddNode(simple.Node(5))
+
+	rowPtr, colIdx, weights, index := simple.ToCSR(g)
+	wantIndex := map[int64]int{5: 0, 10: 1, 20: 2, 30: 3}
+	if !reflect.DeepEqual(index, wantIndex) {
+		t.Errorf("unexpected index: got:%v want:%v", index, wantIndex)
+	}
+	wantRowPtr := []int{0, 0, 2, 3, 4}
+	if !reflect.DeepEqual(rowPtr, wantRowPtr) {
+		t.Errorf("unexpected row pointers: got:%v want:%v", rowPtr, wantRowPtr)
+	}
+	wantColIdx := []int{2, 3, 3, 1}
+	if !reflect.DeepEqual(colIdx, wantColIdx) {
+		t.Errorf("unexpected column indices: got:%v want:%v", colIdx, wantColIdx)
+	}
+	wantWeights := []float64{1, 2, 4, 3}
+	if !reflect.DeepEqual(weights, wantWeights) {
+		t.Errorf("unexpected weights: got:%v want:%v", weights, wantWeights)
+	}
+
+	// Unweighted graphs have unit edge weights.
+	_, _, weights, _ = simple.ToCSR(csrTestGraph(50, 4, rand.NewSource(1)))
+	for i, w := range weights {
+		if w != 1 {
+			t.Fatalf("unexpected weight for unweighted edge %d: got:%v want:1", i, w)
+		}
+	}
+}
+
+func TestToTransposedCSR(t *testing.T) {
+	for seed := uint64(1); seed <= 5; seed++ {
+		g := simple.NewWeightedDirectedGraph(0, 0)
+		rnd := rand.New(rand.NewSource(seed))
+		for i := 0; i < 100; i++ {
+			g.AddNode(simple.Node(i * 3))
+		}
+		for i := 0; i < 300; i++ {
+			u, v := rnd.Intn(100), rnd.Intn(100)
+			if u == v {
+				continue
+			}
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u * 3), T: simple.Node(v * 3), W: float64(1 + rnd.Intn(10))})
+		}
+
+		rowPtr, colIdx, weights, index := simple.ToTransposedCSR(g)
+		_, _, _, wantIndex := simple.ToCSR(g)
+		if !reflect.DeepEqual(index, wantIndex) {
+			t.Fatalf("transposed index does not match ToCSR index for seed %d", seed)
+		}
+		nodes := make([]graph.Node, len(index))
+		for id, i := range index {
+			nodes[i] = g.Node(id)
+		}
+		for i, v := range nodes {
+			var got []int64
+			for k := rowPtr[i]; k < rowPtr[i+1]; k++ {
+				u := nodes[colIdx[k]]
+				got = append(got, u.ID())
+				if w := g.WeightedEdge(u.ID(), v.ID()).Weight(); weights[k] != w {
+					t.Errorf("unexpected weight for edge %d->%d for seed %d: got:%v want:%v", u.ID(), v.ID(), seed, weights[k], w)
+				}
+			}
+			var want []int64
+			for _, u := range graph.NodesOf(g.To(v.ID())) {
+				want = append(want, u.ID())
+			}
+			sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected predecessors of %d for seed %d: got:%v want:%v", v.ID(), seed, got, want)
+			}
+		}
+	}
+}
+
+func TestCSRBreadthFirst(t *testing.T) {
+	for seed := uint64(1); seed <= 5; seed++ {
+		g := csrTestGraph(200, 3, rand.NewSource(seed))
+		c := simple.NewCSRGraph(g, 0, 0)
+		want := breadthFirstOrder(g)
+		got := breadthFirstOrder(c)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected breadth first order for seed %d:\ngot: %v\nwant:%v", seed, got, want)
+		}
+	}
+}
+
+// csrTestGraph returns a random directed graph with n nodes
+// and up to degree out edges from each node.
+func csrTestGraph(n, degree int, src rand.Source) *simple.DirectedGraph {
+	rnd := rand.New(src)
+	g := simple.NewDirectedGraph()
+	for i := 0; i < n; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	for i := 0; i < n; i++ {
+		for d := 0; d < degree; d++ {
+			j := rnd.Intn(n)
+			if j == i {
+				continue
+			}
+			g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(j)})
+		}
+	}
+	return g
+}
+
+// breadthFirstOrder returns the IDs of nodes reachable from node 0 in
+// g in the order visited by a breadth first search. Neighbors are sorted
+// by ID so that the order does not depend on map iteration.
+func breadthFirstOrder(g graph.Directed) []int64 {
+	var order []int64
+	bf := traverse.BreadthFirst{
+		Traverse: func(e graph.Edge) bool { return true },
+	}
+	bf.Walk(sortedFrom{g}, simple.Node(0), func(n graph.Node, _ int) bool {
+		order = append(order, n.ID())
+		return false
+	})
+	return order
+}
+
+type sortedFrom struct {
+	graph.Directed
+}
+
+func (g sortedFrom) From(id int64) graph.Nodes {
+	nodes := graph.NodesOf(g.Directed.From(id))
+	sort.Sort(ordered.ByID(nodes))
+	return iterator.NewOrderedNodes(nodes)
+}
+
+var benchBreadthFirst int
+
+func BenchmarkCSRBreadthFirst(b *testing.B) {
+	g := csrTestGraph(10000, 8, rand.NewSource(1))
+	for _, bench := range []struct {
+		name string
+		g    graph.Graph
+	}{
+		{name: "map", g: g},
+		{name: "csr", g: simple.NewCSRGraph(g, 0, 0)},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				var n int
+				var bf traverse.BreadthFirst
+				bf.Walk(bench.g, simple.Node(0), func(graph.Node, int) bool {
+					n++
+					return false
+				})
+				benchBreadthFirst = n
+			}
+		})
+	}
+}
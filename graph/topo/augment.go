@@ -0,0 +1,232 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// AugmentTo2EdgeConnected returns a set of edges that, when added to the undirected
+// graph g, make g 2-edge-connected so that it has no bridges. If g is already
+// 2-edge-connected or has fewer than three nodes, AugmentTo2EdgeConnected returns
+// nil.
+//
+// The 2-edge-connected components of g are contracted to form its bridge tree, and
+// the leaves of the tree, taken in depth first order, are paired so that leaf i is
+// joined to leaf i+⌊l/2⌋ of the l leaves, with a final edge joining the last leaf
+// to the first if l is odd. For connected g, the ⌈l/2⌉ returned edges are the
+// minimum number required. If g is not connected, its components are first joined
+// in a chain by additional edges, and the result is not guaranteed to be minimal.
+// Each added edge joins the lowest ID node of a leaf component that is not an end
+// of its bridge, where such a node exists.
+func AugmentTo2EdgeConnected(g graph.Undirected) []simple.Edge {
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) < 3 {
+		return nil
+	}
+	sort.Sort(ordered.ByID(nodes))
+	adj := make(map[int64][]int64, len(nodes))
+	for _, u := range nodes {
+		uid := u.ID()
+		for _, v := range graph.NodesOf(g.From(uid)) {
+			if vid := v.ID(); vid != uid {
+				adj[uid] = append(adj[uid], vid)
+			}
+		}
+		sort.Sort(ordered.Int64s(adj[uid]))
+	}
+
+	var added []simple.Edge
+	join := func(uid, vid int64) {
+		adj[uid] = append(adj[uid], vid)
+		adj[vid] = append(adj[vid], uid)
+		added = append(added, simple.Edge{F: g.Node(uid), T: g.Node(vid)})
+	}
+
+	// Join the components of g in a chain, preferring
+	// leaf components of each bridge tree as the ends of
+	// the joining edges.
+	tree := newBridgeTree(nodes, adj)
+	if roots := tree.roots(); len(roots) > 1 {
+		for i := 1; i < len(roots); i++ {
+			prev := tree.leaves(roots[i-1])
+			next := tree.leaves(roots[i])
+			join(tree.rep[prev[len(prev)-1]], tree.rep[next[0]])
+		}
+		tree = newBridgeTree(nodes, adj)
+	}
+
+	leaves := tree.leaves(0)
+	if len(leaves) < 2 {
+		return added
+	}
+	half := len(leaves) / 2
+	for i := 0; i < half; i++ {
+		join(tree.rep[leaves[i]], tree.rep[leaves[i+half]])
+	}
+	if len(leaves)%2 != 0 {
+		join(tree.rep[leaves[len(leaves)-1]], tree.rep[leaves[0]])
+	}
+	return added
+}
+
+// bridgeTree is the forest formed by contracting the
+// 2-edge-connected components of a graph.
+type bridgeTree struct {
+	// adj holds the adjacency of components
+	// in order of their lowest ID node.
+	adj [][]int
+
+	// rep holds the representative node of
+	// each component used for joining edges.
+	rep []int64
+}
+
+// newBridgeTree returns the bridge tree of the graph with the given nodes, sorted
+// by ID, and adjacency.
+func newBridgeTree(nodes []graph.Node, adj map[int64][]int64) *bridgeTree {
+	isBridge := bridgesOf(nodes, adj)
+
+	// Label the components reachable
+	// without crossing a bridge.
+	comp := make(map[int64]int, len(nodes))
+	var t bridgeTree
+	for _, n := range nodes {
+		if _, ok := comp[n.ID()]; ok {
+			continue
+		}
+		c := len(t.adj)
+		t.adj = append(t.adj, nil)
+		t.rep = append(t.rep, n.ID())
+		comp[n.ID()] = c
+		for stack := []int64{n.ID()}; len(stack) != 0; {
+			uid := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, vid := range adj[uid] {
+				if _, ok := comp[vid]; ok || isBridge[[2]int64{uid, vid}] {
+					continue
+				}
+				comp[vid] = c
+				stack = append(stack, vid)
+			}
+		}
+	}
+
+	// ends holds the bridge ends in each component.
+	ends := make(map[int64]bool)
+	for _, n := range nodes {
+		uid := n.ID()
+		for _, vid := range adj[uid] {
+			if isBridge[[2]int64{uid, vid}] {
+				t.adj[comp[uid]] = append(t.adj[comp[uid]], comp[vid])
+				ends[uid] = true
+			}
+		}
+	}
+	// Nodes are visited in order of ID, so the first
+	// node found in each component that is not a bridge
+	// end is its lowest such node.
+	found := make([]bool, len(t.adj))
+	for _, n := range nodes {
+		c := comp[n.ID()]
+		if !found[c] && !ends[n.ID()] {
+			t.rep[c] = n.ID()
+			found[c] = true
+		}
+	}
+	return &t
+}
+
+// roots returns the lowest component of each tree of the forest.
+func (t *bridgeTree) roots() []int {
+	seen := make([]bool, len(t.adj))
+	var roots []int
+	for c := range t.adj {
+		if seen[c] {
+			continue
+		}
+		roots = append(roots, c)
+		seen[c] = true
+		for stack := []int{c}; len(stack) != 0; {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, v := range t.adj[u] {
+				if !seen[v] {
+					seen[v] = true
+					stack = append(stack, v)
+				}
+			}
+		}
+	}
+	return roots
+}
+
+// leaves returns the leaves of the tree containing root in depth first
+// order from root. A tree with a single component is its own leaf.
+func (t *bridgeTree) leaves(root int) []int {
+	if len(t.adj[root]) == 0 {
+		return []int{root}
+	}
+	var leaves []int
+	var visit func(u, parent int)
+	visit = func(u, parent int) {
+		if len(t.adj[u]) == 1 {
+			leaves = append(leaves, u)
+		}
+		for _, v := range t.adj[u] {
+			if v != parent {
+				visit(v, u)
+			}
+		}
+	}
+	visit(root, -1)
+	return leaves
+}
+
+// bridgesOf returns the bridges of the graph with the given nodes and adjacency,
+// keyed in both directions, using Tarjan's lowlink bridge-finding algorithm.
+func bridgesOf(nodes []graph.Node, adj map[int64][]int64) map[[2]int64]bool {
+	var (
+		index   = make(map[int64]int, len(nodes))
+		low     = make(map[int64]int, len(nodes))
+		bridges = make(map[[2]int64]bool)
+	)
+	// The root of each search has itself as parent
+	// since adj does not hold self edges.
+	var visit func(uid, parent int64)
+	visit = func(uid, parent int64) {
+		index[uid] = len(index)
+		low[uid] = index[uid]
+		for _, vid := range adj[uid] {
+			if vid == parent {
+				continue
+			}
+			if _, ok := index[vid]; ok {
+				if index[vid] < low[uid] {
+					low[uid] = index[vid]
+				}
+				continue
+			}
+			visit(vid, uid)
+			if low[vid] < low[uid] {
+				low[uid] = low[vid]
+			}
+			if low[vid] > index[uid] {
+				bridges[[2]int64{uid, vid}] = true
+				bridges[[2]int64{vid, uid}] = true
+			}
+		}
+	}
+	for _, n := range nodes {
+		if _, ok := index[n.ID()]; !ok {
+			visit(n.ID(), n.ID())
+		}
+	}
+	return bridges
+}
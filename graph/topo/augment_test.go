@@ -0,0 +1,167 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var augmentTo2EdgeConnectedTests = []struct {
+	name  string
+	g     []intset
+	nodes int
+	want  int
+}{
+	{
+		name: "cycle",
+		g: []intset{
+			0: linksTo(1),
+			1: linksTo(2),
+			2: linksTo(0),
+		},
+		want: 0,
+	},
+	{
+		name: "path",
+		g: []intset{
+			0: linksTo(1),
+			1: linksTo(2),
+			2: linksTo(3),
+		},
+		want: 1,
+	},
+	{
+		name: "star",
+		g: []intset{
+			0: linksTo(1, 2, 3, 4, 5),
+		},
+		want: 3,
+	},
+	{
+		// Two triangles joined by a bridge
+		// with a pendant path on each.
+		name: "dumbbell",
+		g: []intset{
+			0: linksTo(1, 2, 7),
+			1: linksTo(2),
+			2: linksTo(3),
+			3: linksTo(4, 5),
+			4: linksTo(5),
+			5: linksTo(6),
+		},
+		want: 1,
+	},
+	{
+		name:  "isolated nodes",
+		nodes: 4,
+		want:  4,
+	},
+	{
+		name: "two nodes",
+		g: []intset{
+			0: linksTo(1),
+		},
+		want: 0,
+	},
+}
+
+func TestAugmentTo2EdgeConnected(t *testing.T) {
+	for _, test := range augmentTo2EdgeConnectedTests {
+		g := simple.NewUndirectedGraph()
+		for i := 0; i < test.nodes; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for u, e := range test.g {
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		edges := AugmentTo2EdgeConnected(g)
+		if len(edges) != test.want {
+			t.Errorf("%s: unexpected number of added edges: got:%d want:%d", test.name, len(edges), test.want)
+		}
+		if g.Nodes().Len() >= 3 {
+			checkAugmentation(t, test.name, g, edges)
+		}
+	}
+}
+
+func TestAugmentTo2EdgeConnectedRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 100; trial++ {
+		n := 3 + rnd.Intn(20)
+		g := simple.NewUndirectedGraph()
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		// Build a random tree, so the graph is connected,
+		// with a few extra edges forming cycles.
+		for i := 1; i < n; i++ {
+			g.SetEdge(simple.Edge{F: simple.Node(rnd.Intn(i)), T: simple.Node(i)})
+		}
+		for i := 0; i < n/4; i++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u != v {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+
+		// The optimal number of edges is half
+		// the number of leaves of the bridge tree.
+		nodes := graph.NodesOf(g.Nodes())
+		adj := make(map[int64][]int64)
+		for _, u := range nodes {
+			for _, v := range graph.NodesOf(g.From(u.ID())) {
+				adj[u.ID()] = append(adj[u.ID()], v.ID())
+			}
+		}
+		leaves := newBridgeTree(nodes, adj).leaves(0)
+		want := int(math.Ceil(float64(len(leaves)) / 2))
+		if len(leaves) == 1 {
+			want = 0
+		}
+
+		edges := AugmentTo2EdgeConnected(g)
+		if len(edges) != want {
+			t.Errorf("trial %d: unexpected number of added edges: got:%d want:%d", trial, len(edges), want)
+		}
+		checkAugmentation(t, "random", g, edges)
+
+		// Also check a disconnected graph.
+		g.AddNode(simple.Node(n))
+		checkAugmentation(t, "disconnected", g, AugmentTo2EdgeConnected(g))
+	}
+}
+
+// checkAugmentation checks that adding edges to g leaves no bridges.
+// The edges are added to g.
+func checkAugmentation(t *testing.T, name string, g *simple.UndirectedGraph, edges []simple.Edge) {
+	t.Helper()
+	for _, e := range edges {
+		if e.F.ID() == e.T.ID() || g.HasEdgeBetween(e.F.ID(), e.T.ID()) {
+			t.Errorf("%s: invalid added edge %d-%d", name, e.F.ID(), e.T.ID())
+			continue
+		}
+		g.SetEdge(e)
+	}
+	nodes := graph.NodesOf(g.Nodes())
+	for _, u := range nodes {
+		for _, v := range nodes {
+			if u.ID() < v.ID() && !IsTwoEdgeConnected(u, v, g) {
+				t.Errorf("%s: nodes %d and %d are not 2-edge-connected after augmentation", name, u.ID(), v.ID())
+				return
+			}
+		}
+	}
+}
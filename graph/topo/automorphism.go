@@ -0,0 +1,291 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"fmt"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// NodeOrbits returns the orbits of the nodes of g under its automorphism group.
+// Nodes in the same orbit are structurally interchangeable. Each orbit is sorted
+// by node ID and the orbits are ordered by their lowest ID. If g is directed,
+// automorphisms must preserve edge direction.
+//
+// NodeOrbits finds automorphisms by backtracking search over node mappings,
+// pruned by color refinement. The worst case time complexity is exponential in
+// the number of nodes, so NodeOrbits is only suitable for small graphs of up to
+// a few tens of nodes, or larger graphs with little symmetry.
+func NodeOrbits(g graph.Graph) [][]graph.Node {
+	a := newAutomorphismSearch(g)
+
+	orbit := make(map[int64]int64, len(a.nodes))
+	var find func(id int64) int64
+	find = func(id int64) int64 {
+		if orbit[id] != id {
+			orbit[id] = find(orbit[id])
+		}
+		return orbit[id]
+	}
+	union := func(x, y int64) {
+		x, y = find(x), find(y)
+		if x < y {
+			orbit[y] = x
+		} else {
+			orbit[x] = y
+		}
+	}
+	for _, n := range a.nodes {
+		orbit[n.ID()] = n.ID()
+	}
+
+	for i, u := range a.nodes {
+		for _, v := range a.nodes[i+1:] {
+			uid, vid := u.ID(), v.ID()
+			if a.color[uid] != a.color[vid] || find(uid) == find(vid) {
+				continue
+			}
+			a.search(uid, vid, func(m map[int64]int64) bool {
+				for x, y := range m {
+					union(x, y)
+				}
+				return true
+			})
+		}
+	}
+
+	byRoot := make(map[int64][]graph.Node)
+	for _, n := range a.nodes {
+		r := find(n.ID())
+		byRoot[r] = append(byRoot[r], n)
+	}
+	orbits := make([][]graph.Node, 0, len(byRoot))
+	for _, o := range byRoot {
+		sort.Sort(ordered.ByID(o))
+		orbits = append(orbits, o)
+	}
+	sort.Sort(ordered.BySliceIDs(orbits))
+	return orbits
+}
+
+// FindInvolution returns a non-identity automorphism of g that is its own inverse,
+// mapping each node ID to the ID of its image, and true if one exists. If g has no
+// such involution, FindInvolution returns nil and false. If g is directed, the
+// automorphism must preserve edge direction. Every graph with a non-trivial
+// automorphism group of even order has an involution.
+//
+// FindInvolution uses the same backtracking search as NodeOrbits, restricted to
+// mappings that are their own inverse, and is similarly only suitable for small
+// graphs or larger graphs with little symmetry.
+func FindInvolution(g graph.Graph) (map[int64]int64, bool) {
+	a := newAutomorphismSearch(g)
+	a.involution = true
+	var sigma map[int64]int64
+	for i, u := range a.nodes {
+		for _, v := range a.nodes[i+1:] {
+			uid, vid := u.ID(), v.ID()
+			if a.color[uid] != a.color[vid] {
+				continue
+			}
+			found := a.search(uid, vid, func(m map[int64]int64) bool {
+				sigma = make(map[int64]int64, len(m))
+				for x, y := range m {
+					sigma[x] = y
+				}
+				return true
+			})
+			if found {
+				return sigma, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// automorphismSearch is a backtracking automorphism finder.
+type automorphismSearch struct {
+	g     graph.Graph
+	nodes []graph.Node
+	color map[int64]int
+	adj   func(uid, vid int64) bool
+
+	// involution restricts the search
+	// to automorphisms that are their
+	// own inverse.
+	involution bool
+}
+
+func newAutomorphismSearch(g graph.Graph) *automorphismSearch {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	adj := g.HasEdgeBetween
+	if d, ok := g.(graph.Directed); ok {
+		adj = d.HasEdgeFromTo
+	}
+	return &automorphismSearch{
+		g:     g,
+		nodes: nodes,
+		color: refineColors(g, nodes),
+		adj:   adj,
+	}
+}
+
+// search calls fn with each automorphism that maps the node with ID
+// uid to the node with ID vid until fn returns true. The mapping
+// passed to fn must not be retained. search returns whether fn
+// returned true.
+func (a *automorphismSearch) search(uid, vid int64, fn func(map[int64]int64) bool) bool {
+	order := a.order(uid)
+	m := map[int64]int64{uid: vid}
+	pre := map[int64]int64{vid: uid}
+	if !a.consistent(m, order[:1], uid, vid) {
+		return false
+	}
+	return a.extend(m, pre, order, 1, fn)
+}
+
+// order returns the IDs of the nodes of the graph in the order they are
+// mapped by a search starting from the node with ID uid.
+func (a *automorphismSearch) order(uid int64) []int64 {
+	// Order nodes breadth first from u so that each
+	// node is mapped after one of its neighbors where
+	// possible, maximising the pruning by adjacency.
+	order := []int64{uid}
+	seen := map[int64]bool{uid: true}
+	var head int
+	bfs := func() {
+		for ; head < len(order); head++ {
+			for _, to := range a.neighbors(order[head]) {
+				for to.Next() {
+					id := to.Node().ID()
+					if !seen[id] {
+						seen[id] = true
+						order = append(order, id)
+					}
+				}
+			}
+		}
+	}
+	bfs()
+	for _, n := range a.nodes {
+		if !seen[n.ID()] {
+			seen[n.ID()] = true
+			order = append(order, n.ID())
+			bfs()
+		}
+	}
+	return order
+}
+
+// neighbors returns iterators over the nodes adjacent to the node with
+// the given ID, in either direction for directed graphs.
+func (a *automorphismSearch) neighbors(id int64) []graph.Nodes {
+	if d, ok := a.g.(graph.Directed); ok {
+		return []graph.Nodes{d.From(id), d.To(id)}
+	}
+	return []graph.Nodes{a.g.From(id)}
+}
+
+// extend extends the partial mapping m, with preimages pre, to the nodes
+// in order from the ith, calling fn with each complete automorphism until
+// fn returns true.
+func (a *automorphismSearch) extend(m, pre map[int64]int64, order []int64, i int, fn func(map[int64]int64) bool) bool {
+	if i == len(order) {
+		return fn(m)
+	}
+	x := order[i]
+	for _, n := range a.nodes {
+		y := n.ID()
+		if _, used := pre[y]; used || a.color[x] != a.color[y] || !a.consistent(m, order[:i], x, y) {
+			continue
+		}
+		if a.involution && !involutive(m, pre, x, y) {
+			continue
+		}
+		m[x] = y
+		pre[y] = x
+		if a.extend(m, pre, order, i+1, fn) {
+			return true
+		}
+		delete(m, x)
+		delete(pre, y)
+	}
+	return false
+}
+
+// involutive returns whether mapping x to y is consistent with the partial
+// mapping m, with preimages pre, being its own inverse.
+func involutive(m, pre map[int64]int64, x, y int64) bool {
+	if z, ok := pre[x]; ok && z != y {
+		return false
+	}
+	if z, ok := m[y]; ok && z != x {
+		return false
+	}
+	return true
+}
+
+// consistent returns whether mapping x to y preserves adjacency
+// with the already mapped nodes in mapped.
+func (a *automorphismSearch) consistent(m map[int64]int64, mapped []int64, x, y int64) bool {
+	if a.adj(x, x) != a.adj(y, y) {
+		return false
+	}
+	for _, z := range mapped {
+		mz := m[z]
+		if a.adj(x, z) != a.adj(y, mz) || a.adj(z, x) != a.adj(mz, y) {
+			return false
+		}
+	}
+	return true
+}
+
+// refineColors returns a stable coloring of the nodes of g obtained by
+// iterated color refinement starting from a uniform coloring. Nodes that
+// are mapped to each other by an automorphism have the same color.
+func refineColors(g graph.Graph, nodes []graph.Node) map[int64]int {
+	d, isDirected := g.(graph.Directed)
+	color := make(map[int64]int, len(nodes))
+	for _, n := range nodes {
+		color[n.ID()] = 0
+	}
+	classes := 1
+	for {
+		next := make(map[int64]int, len(nodes))
+		index := make(map[string]int)
+		for _, n := range nodes {
+			sig := neighborSignature(color, g.From(n.ID()))
+			if isDirected {
+				sig += "|" + neighborSignature(color, d.To(n.ID()))
+			}
+			sig = fmt.Sprint(color[n.ID()], ":", sig)
+			c, ok := index[sig]
+			if !ok {
+				c = len(index)
+				index[sig] = c
+			}
+			next[n.ID()] = c
+		}
+		color = next
+		if len(index) == classes {
+			return color
+		}
+		classes = len(index)
+	}
+}
+
+// neighborSignature returns a canonical representation of the multiset
+// of colors of the nodes in it.
+func neighborSignature(color map[int64]int, it graph.Nodes) string {
+	var c []int
+	for it.Next() {
+		c = append(c, color[it.Node().ID()])
+	}
+	sort.Ints(c)
+	return fmt.Sprint(c)
+}
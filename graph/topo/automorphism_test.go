@@ -0,0 +1,218 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var nodeOrbitsTests = []struct {
+	name     string
+	g        []intset
+	directed bool
+	want     [][]int64
+}{
+	{
+		name: "cycle",
+		g: []intset{
+			0: linksTo(1),
+			1: linksTo(2),
+			2: linksTo(3),
+			3: linksTo(4),
+			4: linksTo(5),
+			5: linksTo(0),
+		},
+		want: [][]int64{{0, 1, 2, 3, 4, 5}},
+	},
+	{
+		name: "path",
+		g: []intset{
+			0: linksTo(1),
+			1: linksTo(2),
+			2: linksTo(3),
+			3: nil,
+		},
+		want: [][]int64{{0, 3}, {1, 2}},
+	},
+	{
+		name: "star",
+		g: []intset{
+			0: linksTo(1, 2, 3),
+			1: nil,
+			2: nil,
+			3: nil,
+		},
+		want: [][]int64{{0}, {1, 2, 3}},
+	},
+	{
+		name: "directed path",
+		g: []intset{
+			0: linksTo(1),
+			1: linksTo(2),
+			2: nil,
+		},
+		directed: true,
+		want:     [][]int64{{0}, {1}, {2}},
+	},
+	{
+		name: "directed cycle",
+		g: []intset{
+			0: linksTo(1),
+			1: linksTo(2),
+			2: linksTo(0),
+		},
+		directed: true,
+		want:     [][]int64{{0, 1, 2}},
+	},
+	{
+		// Each node of this graph has degree 3 but the
+		// graph has no nontrivial automorphism.
+		name: "asymmetric cubic",
+		g: []intset{
+			0:  linksTo(1, 6, 7),
+			1:  linksTo(2, 7),
+			2:  linksTo(3, 8),
+			3:  linksTo(4, 9),
+			4:  linksTo(5, 9),
+			5:  linksTo(6, 10),
+			6:  linksTo(10),
+			7:  linksTo(11),
+			8:  linksTo(9, 11),
+			9:  nil,
+			10: linksTo(11),
+			11: nil,
+		},
+		want: [][]int64{{0}, {1}, {2}, {3}, {4}, {5}, {6}, {7}, {8}, {9}, {10}, {11}},
+	},
+}
+
+func TestNodeOrbits(t *testing.T) {
+	for _, test := range nodeOrbitsTests {
+		var g interface {
+			graph.Graph
+			graph.NodeAdder
+			SetEdge(graph.Edge)
+		}
+		if test.directed {
+			g = simple.NewDirectedGraph()
+		} else {
+			g = simple.NewUndirectedGraph()
+		}
+		for u, e := range test.g {
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		var got [][]int64
+		for _, o := range NodeOrbits(g) {
+			var ids []int64
+			for _, n := range o {
+				ids = append(ids, n.ID())
+			}
+			got = append(got, ids)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: unexpected orbits: got:%v want:%v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestFindInvolution(t *testing.T) {
+	// The automorphism group of the directed cycle
+	// has odd order, so it has no involution.
+	want := map[string]bool{
+		"cycle":            true,
+		"path":             true,
+		"star":             true,
+		"directed path":    false,
+		"directed cycle":   false,
+		"asymmetric cubic": false,
+	}
+	for _, test := range nodeOrbitsTests {
+		var g interface {
+			graph.Graph
+			graph.NodeAdder
+			SetEdge(graph.Edge)
+		}
+		if test.directed {
+			g = simple.NewDirectedGraph()
+		} else {
+			g = simple.NewUndirectedGraph()
+		}
+		for u, e := range test.g {
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		sigma, ok := FindInvolution(g)
+		if ok != want[test.name] {
+			t.Errorf("%q: unexpected involution existence: got:%t want:%t", test.name, ok, want[test.name])
+		}
+		if ok {
+			checkInvolution(t, test.name, g, sigma)
+		}
+	}
+}
+
+func TestFindInvolutionPath(t *testing.T) {
+	for _, n := range []int{2, 5, 8} {
+		g := simple.NewUndirectedGraph()
+		for i := 0; i < n-1; i++ {
+			g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(i + 1)})
+		}
+		sigma, ok := FindInvolution(g)
+		if !ok {
+			t.Errorf("no involution found for path of %d nodes", n)
+			continue
+		}
+		for i := 0; i < n; i++ {
+			if got, want := sigma[int64(i)], int64(n-1-i); got != want {
+				t.Errorf("unexpected image of %d for path of %d nodes: got:%d want:%d", i, n, got, want)
+			}
+		}
+		checkInvolution(t, "path", g, sigma)
+	}
+}
+
+func checkInvolution(t *testing.T, name string, g graph.Graph, sigma map[int64]int64) {
+	t.Helper()
+	adj := g.HasEdgeBetween
+	if d, ok := g.(graph.Directed); ok {
+		adj = d.HasEdgeFromTo
+	}
+	nodes := graph.NodesOf(g.Nodes())
+	if len(sigma) != len(nodes) {
+		t.Errorf("%q: unexpected mapping size: got:%d want:%d", name, len(sigma), len(nodes))
+	}
+	identity := true
+	for _, u := range nodes {
+		uid := u.ID()
+		if sigma[sigma[uid]] != uid {
+			t.Errorf("%q: mapping is not an involution at %d", name, uid)
+		}
+		if sigma[uid] != uid {
+			identity = false
+		}
+		for _, v := range nodes {
+			vid := v.ID()
+			if adj(uid, vid) != adj(sigma[uid], sigma[vid]) {
+				t.Errorf("%q: mapping does not preserve adjacency of %d and %d", name, uid, vid)
+			}
+		}
+	}
+	if identity {
+		t.Errorf("%q: unexpected identity mapping", name)
+	}
+}
@@ -39,7 +39,18 @@ func KCore(k int, g graph.Undirected) []graph.Node {
 	return core
 }
 
-// degeneracyOrdering is the common code for DegeneracyOrdering and KCore. It
+// Degeneracy returns the degeneracy of the undirected graph g, the largest k
+// for which g has a non-empty k-core. Equivalently, it is the maximum degree of
+// a node at the time of its removal when nodes are repeatedly removed in order
+// of minimum remaining degree. In the degeneracy ordering returned by
+// DegeneracyOrdering, each node has at most Degeneracy(g) neighbors that
+// precede it.
+func Degeneracy(g graph.Undirected) int {
+	_, offsets := degeneracyOrdering(g)
+	return len(offsets) - 1
+}
+
+// degeneracyOrdering is the common code for DegeneracyOrdering, KCore and Degeneracy. It
 // returns l, the nodes of g in optimal ordering for coloring number and
 // s, a set of relative offsets into l for each k-core, where k is an index
 // into s.
@@ -126,6 +126,66 @@ func TestKCore(t *testing.T) {
 	}
 }
 
+func TestDegeneracy(t *testing.T) {
+	for i, test := range vOrderTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		if got := Degeneracy(g); got != test.wantK {
+			t.Errorf("unexpected degeneracy for test %d: got:%d want:%d", i, got, test.wantK)
+		}
+	}
+
+	// A triangulated grid is planar, so its degeneracy is at
+	// most 5; the interior nodes all have degree 6.
+	const n = 10
+	g := simple.NewUndirectedGraph()
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			id := int64(r*n + c)
+			if c+1 < n {
+				g.SetEdge(simple.Edge{F: simple.Node(id), T: simple.Node(id + 1)})
+			}
+			if r+1 < n {
+				g.SetEdge(simple.Edge{F: simple.Node(id), T: simple.Node(id + n)})
+			}
+			if c+1 < n && r+1 < n {
+				g.SetEdge(simple.Edge{F: simple.Node(id), T: simple.Node(id + n + 1)})
+			}
+		}
+	}
+	k := Degeneracy(g)
+	if k > 5 {
+		t.Errorf("unexpected degeneracy for planar graph: got:%d want:<=5", k)
+	}
+	order, _ := DegeneracyOrdering(g)
+	if len(order) != n*n {
+		t.Fatalf("unexpected ordering length: got:%d want:%d", len(order), n*n)
+	}
+	position := make(map[int64]int)
+	for i, u := range order {
+		position[u.ID()] = i
+	}
+	for i, u := range order {
+		var before int
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			if position[v.ID()] < i {
+				before++
+			}
+		}
+		if before > k {
+			t.Errorf("node %d has %d preceding neighbors in ordering, more than degeneracy %d", u.ID(), before, k)
+		}
+	}
+}
+
 var bronKerboschTests = []struct {
 	name string
 	g    []intset
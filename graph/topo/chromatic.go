@@ -0,0 +1,226 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ChromaticNumber returns the chromatic number of the undirected graph g, the
+// minimum number of colors needed to color the nodes of g so that no two adjacent
+// nodes share a color. The chromatic number of an empty graph is zero. Self loops
+// are ignored, as they are by KColoring and MinConflictColoring.
+//
+// ChromaticNumber attempts k-colorings by backtracking for increasing k until one
+// succeeds. The worst case running time is exponential in the number of nodes, so
+// ChromaticNumber is only suitable for small graphs, typically of no more than a
+// few tens of nodes.
+func ChromaticNumber(g graph.Undirected) int {
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) == 0 {
+		return 0
+	}
+
+	// Color high degree nodes first so that conflicts
+	// are found close to the root of the search.
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	adj := make([][]int, len(nodes))
+	for i, u := range nodes {
+		to := g.From(u.ID())
+		for to.Next() {
+			j := indexOf[to.Node().ID()]
+			if j != i {
+				adj[i] = append(adj[i], j)
+			}
+		}
+	}
+	order := make([]int, len(nodes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return len(adj[order[a]]) > len(adj[order[b]])
+	})
+
+	color := make([]int, len(nodes))
+	for k := 1; ; k++ {
+		for i := range color {
+			color[i] = -1
+		}
+		if colorable(0, k, order, adj, color) {
+			return k
+		}
+	}
+}
+
+// colorable returns whether the nodes in order[pos:] can be colored with k colors
+// consistently with the colors already assigned in color.
+func colorable(pos, k int, order []int, adj [][]int, color []int) bool {
+	if pos == len(order) {
+		return true
+	}
+	u := order[pos]
+	// Colors are interchangeable, so no node needs a
+	// color more than one greater than those used so far.
+	limit := 0
+	for _, v := range order[:pos] {
+		if color[v] >= limit {
+			limit = color[v] + 1
+		}
+	}
+	if limit >= k {
+		limit = k - 1
+	}
+	for c := 0; c <= limit; c++ {
+		ok := true
+		for _, v := range adj[u] {
+			if color[v] == c {
+				ok = false
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		color[u] = c
+		if colorable(pos+1, k, order, adj, color) {
+			return true
+		}
+		color[u] = -1
+	}
+	return false
+}
+
+// KColoring returns a proper coloring of the undirected graph g using at most k
+// colors if one exists. The returned map holds the color in [0, k) of each node of g,
+// keyed by node ID, and the returned boolean is true. If g is not k-colorable, the
+// returned map is nil and the boolean is false. Self loops are ignored, as they
+// are by ChromaticNumber, so g is k-colorable for k at least its chromatic number.
+//
+// KColoring uses backtracking with forward checking, coloring the node with the
+// fewest remaining available colors first. The worst case running time is
+// exponential in the number of nodes.
+func KColoring(g graph.Undirected, k int) (colors map[int64]int, ok bool) {
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) == 0 {
+		return make(map[int64]int), true
+	}
+	if k < 1 {
+		return nil, false
+	}
+
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	adj := make([][]int, len(nodes))
+	for i, u := range nodes {
+		to := g.From(u.ID())
+		for to.Next() {
+			j := indexOf[to.Node().ID()]
+			if j != i {
+				adj[i] = append(adj[i], j)
+			}
+		}
+	}
+
+	c := kColorer{
+		k:         k,
+		adj:       adj,
+		color:     make([]int, len(nodes)),
+		forbidden: make([][]int, len(nodes)),
+		available: make([]int, len(nodes)),
+	}
+	for i := range nodes {
+		c.color[i] = -1
+		c.forbidden[i] = make([]int, k)
+		c.available[i] = k
+	}
+	if !c.search(len(nodes)) {
+		return nil, false
+	}
+	colors = make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		colors[n.ID()] = c.color[i]
+	}
+	return colors, true
+}
+
+// kColorer holds the state of a k-coloring search.
+type kColorer struct {
+	k     int
+	adj   [][]int
+	color []int
+
+	// forbidden holds the number of neighbors
+	// of each node that have been given each
+	// color, and available holds the number
+	// of colors not forbidden for each node.
+	forbidden [][]int
+	available []int
+}
+
+// search colors the remaining uncolored nodes, returning whether it succeeded.
+func (c *kColorer) search(uncolored int) bool {
+	if uncolored == 0 {
+		return true
+	}
+
+	// Choose the most constrained uncolored node,
+	// breaking ties by the highest degree.
+	u := -1
+	for i, col := range c.color {
+		if col >= 0 {
+			continue
+		}
+		if u < 0 || c.available[i] < c.available[u] || (c.available[i] == c.available[u] && len(c.adj[i]) > len(c.adj[u])) {
+			u = i
+		}
+	}
+
+	for col := 0; col < c.k; col++ {
+		if c.forbidden[u][col] != 0 {
+			continue
+		}
+		if c.assign(u, col) && c.search(uncolored-1) {
+			return true
+		}
+		c.unassign(u, col)
+	}
+	return false
+}
+
+// assign colors u with col and updates the available colors of its neighbors,
+// returning false if an uncolored neighbor is left with no available color.
+func (c *kColorer) assign(u, col int) bool {
+	c.color[u] = col
+	ok := true
+	for _, v := range c.adj[u] {
+		c.forbidden[v][col]++
+		if c.forbidden[v][col] == 1 {
+			c.available[v]--
+			if c.available[v] == 0 && c.color[v] < 0 {
+				ok = false
+			}
+		}
+	}
+	return ok
+}
+
+// unassign reverses assign.
+func (c *kColorer) unassign(u, col int) {
+	c.color[u] = -1
+	for _, v := range c.adj[u] {
+		c.forbidden[v][col]--
+		if c.forbidden[v][col] == 0 {
+			c.available[v]++
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph/multi"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var chromaticNumberTests = []struct {
+	name string
+	g    []intset
+	want int
+}{
+	{
+		name: "empty",
+		g:    nil,
+		want: 0,
+	},
+	{
+		name: "isolated nodes",
+		g:    []intset{0: nil, 1: nil, 2: nil},
+		want: 1,
+	},
+	{
+		name: "triangle",
+		g: []intset{
+			0: linksTo(1, 2),
+			1: linksTo(2),
+			2: nil,
+		},
+		want: 3,
+	},
+	{
+		name: "C6",
+		g: []intset{
+			0: linksTo(1, 5),
+			1: linksTo(2),
+			2: linksTo(3),
+			3: linksTo(4),
+			4: linksTo(5),
+			5: nil,
+		},
+		want: 2,
+	},
+	{
+		name: "C5",
+		g: []intset{
+			0: linksTo(1, 4),
+			1: linksTo(2),
+			2: linksTo(3),
+			3: linksTo(4),
+			4: nil,
+		},
+		want: 3,
+	},
+	{
+		name: "K4",
+		g: []intset{
+			0: linksTo(1, 2, 3),
+			1: linksTo(2, 3),
+			2: linksTo(3),
+			3: nil,
+		},
+		want: 4,
+	},
+	{
+		name: "petersen",
+		g: []intset{
+			0: linksTo(1, 4, 5),
+			1: linksTo(2, 6),
+			2: linksTo(3, 7),
+			3: linksTo(4, 8),
+			4: linksTo(9),
+			5: linksTo(7, 8),
+			6: linksTo(8, 9),
+			7: linksTo(9),
+			8: nil,
+			9: nil,
+		},
+		want: 3,
+	},
+	{
+		name: "batagelj-zaversnik",
+		g:    batageljZaversnikGraph,
+		want: 4,
+	},
+}
+
+func TestChromaticNumber(t *testing.T) {
+	for _, test := range chromaticNumberTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		got := ChromaticNumber(g)
+		if got != test.want {
+			t.Errorf("%q: unexpected chromatic number: got:%d want:%d", test.name, got, test.want)
+		}
+	}
+}
+
+func TestKColoring(t *testing.T) {
+	for _, test := range chromaticNumberTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		for k := 0; k <= test.want+1; k++ {
+			colors, ok := KColoring(g, k)
+			if want := k >= test.want; ok != want {
+				t.Errorf("%q: unexpected %d-colorability: got:%t want:%t", test.name, k, ok, want)
+				continue
+			}
+			if !ok {
+				if colors != nil {
+					t.Errorf("%q: unexpected coloring for infeasible k=%d: %v", test.name, k, colors)
+				}
+				continue
+			}
+			if len(colors) != g.Nodes().Len() {
+				t.Errorf("%q: unexpected number of colored nodes for k=%d: got:%d want:%d", test.name, k, len(colors), g.Nodes().Len())
+			}
+			for uid, c := range colors {
+				if c < 0 || k <= c {
+					t.Errorf("%q: color out of range for node %d with k=%d: %d", test.name, uid, k, c)
+				}
+				to := g.From(uid)
+				for to.Next() {
+					if vid := to.Node().ID(); colors[vid] == c {
+						t.Errorf("%q: adjacent nodes %d and %d share color %d with k=%d", test.name, uid, vid, c, k)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestChromaticSelfLoop(t *testing.T) {
+	// A triangle with a self loop on node 0.
+	g := multi.NewUndirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 0}, {0, 0}} {
+		g.SetLine(g.NewLine(multi.Node(e[0]), multi.Node(e[1])))
+	}
+
+	if got := ChromaticNumber(g); got != 3 {
+		t.Errorf("unexpected chromatic number with self loop: got:%d want:3", got)
+	}
+	if _, ok := KColoring(g, 2); ok {
+		t.Error("unexpected 2-coloring of triangle with self loop")
+	}
+	colors, ok := KColoring(g, 3)
+	if !ok {
+		t.Fatal("no 3-coloring of triangle with self loop")
+	}
+	if colors[0] == colors[1] || colors[1] == colors[2] || colors[2] == colors[0] {
+		t.Errorf("invalid coloring of triangle with self loop: %v", colors)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// bandwidth returns the maximum difference in position in order
+// between the ends of any edge in g.
+func bandwidth(g graph.Undirected, order []graph.Node) int {
+	pos := make(map[int64]int, len(order))
+	for i, n := range order {
+		pos[n.ID()] = i
+	}
+	var max int
+	for _, u := range order {
+		to := g.From(u.ID())
+		for to.Next() {
+			d := pos[u.ID()] - pos[to.Node().ID()]
+			if d > max {
+				max = d
+			}
+		}
+	}
+	return max
+}
+
+func TestCuthillMcKee(t *testing.T) {
+	const (
+		n    = 50
+		band = 2
+	)
+	for seed := uint64(1); seed <= 5; seed++ {
+		// Construct a banded graph with its nodes randomly relabelled.
+		perm := rand.New(rand.NewSource(seed)).Perm(n)
+		g := simple.NewUndirectedGraph()
+		for i := 0; i < n; i++ {
+			for j := i + 1; j <= i+band && j < n; j++ {
+				g.SetEdge(simple.Edge{F: simple.Node(perm[i]), T: simple.Node(perm[j])})
+			}
+		}
+		// Add a second component.
+		g.SetEdge(simple.Edge{F: simple.Node(n), T: simple.Node(n + 1)})
+
+		original := graph.NodesOf(g.Nodes())
+		sort.Sort(ordered.ByID(original))
+		order := CuthillMcKee(g)
+
+		if len(order) != len(original) {
+			t.Fatalf("seed %d: unexpected ordering length: got:%d want:%d", seed, len(order), len(original))
+		}
+		seen := make(map[int64]bool)
+		for _, u := range order {
+			if seen[u.ID()] {
+				t.Errorf("seed %d: node %d repeated in ordering", seed, u.ID())
+			}
+			seen[u.ID()] = true
+		}
+
+		before := bandwidth(g, original)
+		after := bandwidth(g, order)
+		if after >= before {
+			t.Errorf("seed %d: ordering did not reduce bandwidth: before:%d after:%d", seed, before, after)
+		}
+		if after != band {
+			t.Errorf("seed %d: unexpected bandwidth: got:%d want:%d", seed, after, band)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// ClassifyEdges performs a depth first search of the directed graph g and returns
+// its edges classified by the search. Tree edges are the edges followed to discover
+// nodes, back edges lead to an ancestor in the search tree, forward edges lead to a
+// descendant that has already been discovered, and cross edges lead to a node in a
+// finished subtree that is not a descendant. Self edges are back edges. The graph
+// has a cycle if and only if there is at least one back edge.
+//
+// The search is started from nodes in order of ascending ID and neighbors are
+// visited in order of ascending ID, so the classification is deterministic.
+func ClassifyEdges(g graph.Directed) (tree, back, forward, cross []graph.Edge) {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+
+	var (
+		time     int
+		discover = make(map[int64]int)
+		finish   = make(map[int64]int)
+	)
+	var visit func(u graph.Node)
+	visit = func(u graph.Node) {
+		uid := u.ID()
+		time++
+		discover[uid] = time
+		to := graph.NodesOf(g.From(uid))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			vid := v.ID()
+			e := g.Edge(uid, vid)
+			switch {
+			case discover[vid] == 0:
+				tree = append(tree, e)
+				visit(v)
+			case finish[vid] == 0:
+				back = append(back, e)
+			case discover[uid] < discover[vid]:
+				forward = append(forward, e)
+			default:
+				cross = append(cross, e)
+			}
+		}
+		time++
+		finish[uid] = time
+	}
+	for _, n := range nodes {
+		if discover[n.ID()] == 0 {
+			visit(n)
+		}
+	}
+	return tree, back, forward, cross
+}
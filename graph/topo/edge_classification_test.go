@@ -0,0 +1,92 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var classifyEdgesTests = []struct {
+	name  string
+	g     []intset
+	tree  [][2]int64
+	back  [][2]int64
+	fwd   [][2]int64
+	cross [][2]int64
+}{
+	{
+		// 0 -> 1 -> 2 -> 0 is a cycle, 0 -> 2 skips
+		// forward over 1 and 3 -> 2 crosses into the
+		// finished tree rooted at 0.
+		name: "all kinds",
+		g: []intset{
+			0: linksTo(1, 2),
+			1: linksTo(2),
+			2: linksTo(0),
+			3: linksTo(2),
+		},
+		tree:  [][2]int64{{0, 1}, {1, 2}},
+		back:  [][2]int64{{2, 0}},
+		fwd:   [][2]int64{{0, 2}},
+		cross: [][2]int64{{3, 2}},
+	},
+	{
+		name: "dag",
+		g: []intset{
+			0: linksTo(1, 2),
+			1: linksTo(3),
+			2: linksTo(3),
+			3: nil,
+		},
+		tree:  [][2]int64{{0, 1}, {1, 3}, {0, 2}},
+		cross: [][2]int64{{2, 3}},
+	},
+}
+
+func TestClassifyEdges(t *testing.T) {
+	for _, test := range classifyEdgesTests {
+		g := simple.NewDirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		tree, back, fwd, cross := ClassifyEdges(g)
+		for _, c := range []struct {
+			kind string
+			got  []graph.Edge
+			want [][2]int64
+		}{
+			{kind: "tree", got: tree, want: test.tree},
+			{kind: "back", got: back, want: test.back},
+			{kind: "forward", got: fwd, want: test.fwd},
+			{kind: "cross", got: cross, want: test.cross},
+		} {
+			var got [][2]int64
+			for _, e := range c.got {
+				got = append(got, [2]int64{e.From().ID(), e.To().ID()})
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("%q: unexpected %s edges: got:%v want:%v", test.name, c.kind, got, c.want)
+			}
+		}
+		if hasBack, cyclic := len(back) != 0, !isDAG(g); hasBack != cyclic {
+			t.Errorf("%q: back edge presence does not match cyclicity: back edges:%t cyclic:%t", test.name, hasBack, cyclic)
+		}
+	}
+}
+
+func isDAG(g graph.Directed) bool {
+	_, err := Sort(g)
+	return err == nil
+}
@@ -0,0 +1,82 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+)
+
+// IsTwoEdgeConnected returns whether there are two edge-disjoint paths from a to
+// b in g, so that a and b remain connected after the removal of any single edge.
+// For undirected graphs this holds exactly when no bridge separates a and b. A
+// node is 2-edge-connected to itself. Parallel edges between a pair of nodes are
+// treated as a single edge.
+//
+// IsTwoEdgeConnected finds up to two augmenting paths in g with unit edge
+// capacities using breadth-first search.
+func IsTwoEdgeConnected(a, b graph.Node, g graph.Graph) bool {
+	aid, bid := a.ID(), b.ID()
+	if g.Node(aid) == nil || g.Node(bid) == nil {
+		return false
+	}
+	if aid == bid {
+		return true
+	}
+
+	// flow holds the unit flows along edges and
+	// into holds, for each node, the nodes with
+	// flow into it, which may be cancelled.
+	flow := make(map[[2]int64]bool)
+	into := make(map[int64]set.Int64s)
+	residual := func(uid, vid int64) bool {
+		return flow[[2]int64{vid, uid}] || (!flow[[2]int64{uid, vid}] && g.Edge(uid, vid) != nil)
+	}
+	push := func(uid, vid int64) {
+		if e := [2]int64{vid, uid}; flow[e] {
+			delete(flow, e)
+			into[uid].Remove(vid)
+			return
+		}
+		flow[[2]int64{uid, vid}] = true
+		if into[vid] == nil {
+			into[vid] = make(set.Int64s)
+		}
+		into[vid].Add(uid)
+	}
+
+	for i := 0; i < 2; i++ {
+		prev := map[int64]int64{aid: aid}
+		queue := []int64{aid}
+		for len(queue) != 0 {
+			uid := queue[0]
+			queue = queue[1:]
+			if uid == bid {
+				break
+			}
+			visit := func(vid int64) {
+				if _, seen := prev[vid]; seen || !residual(uid, vid) {
+					return
+				}
+				prev[vid] = uid
+				queue = append(queue, vid)
+			}
+			to := g.From(uid)
+			for to.Next() {
+				visit(to.Node().ID())
+			}
+			for vid := range into[uid] {
+				visit(vid)
+			}
+		}
+		if _, found := prev[bid]; !found {
+			return false
+		}
+		for vid := bid; vid != aid; vid = prev[vid] {
+			push(prev[vid], vid)
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestIsTwoEdgeConnected(t *testing.T) {
+	// Two cycles 0-1-2 and 3-4-5 joined by the bridge 2-3,
+	// with a pendant node 6 and an isolated node 7, and
+	// two cycles 9-10-11 and 11-12-13 sharing node 11.
+	g := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{
+		{0, 1}, {1, 2}, {2, 0},
+		{2, 3},
+		{3, 4}, {4, 5}, {5, 3},
+		{5, 6},
+		{9, 10}, {10, 11}, {11, 9}, {11, 12}, {12, 13}, {13, 11},
+	} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	g.AddNode(simple.Node(7))
+
+	for _, test := range []struct {
+		a, b int64
+		want bool
+	}{
+		{a: 0, b: 1, want: true},
+		{a: 0, b: 2, want: true},
+		{a: 3, b: 5, want: true},
+		{a: 0, b: 3, want: false},
+		{a: 2, b: 3, want: false},
+		{a: 5, b: 6, want: false},
+		{a: 0, b: 7, want: false},
+		{a: 7, b: 7, want: true},
+		// Two cycles sharing a cut vertex are
+		// 2-edge-connected but not biconnected.
+		{a: 9, b: 12, want: true},
+	} {
+		for _, pair := range [][2]int64{{test.a, test.b}, {test.b, test.a}} {
+			got := IsTwoEdgeConnected(simple.Node(pair[0]), simple.Node(pair[1]), g)
+			if got != test.want {
+				t.Errorf("unexpected result for %d and %d: got:%t want:%t", pair[0], pair[1], got, test.want)
+			}
+		}
+	}
+
+	// Adding a second connection removes the bridge.
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(4)})
+	if !IsTwoEdgeConnected(simple.Node(0), simple.Node(3), g) {
+		t.Error("expected 0 and 3 to be 2-edge-connected after removing bridge")
+	}
+}
+
+func TestIsTwoEdgeConnectedDirected(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{
+		// Two paths 0->1->3 and 0->2->3.
+		{0, 1}, {1, 3}, {0, 2}, {2, 3},
+		// A single path 3->4 and a path back 4->0.
+		{3, 4}, {4, 0},
+	} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	for _, test := range []struct {
+		a, b int64
+		want bool
+	}{
+		{a: 0, b: 3, want: true},
+		{a: 3, b: 0, want: false},
+		{a: 0, b: 4, want: false},
+	} {
+		got := IsTwoEdgeConnected(simple.Node(test.a), simple.Node(test.b), g)
+		if got != test.want {
+			t.Errorf("unexpected result for %d to %d: got:%t want:%t", test.a, test.b, got, test.want)
+		}
+	}
+
+	// The augmenting search must be able to cancel flow:
+	// the shortest path 0->1->2->3 uses edges of both of
+	// the disjoint paths 0->1->4->5->3 and 0->6->7->2->3.
+	h := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 3}, {1, 4}, {4, 5}, {5, 3}, {0, 6}, {6, 7}, {7, 2}} {
+		h.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	if !IsTwoEdgeConnected(simple.Node(0), simple.Node(3), h) {
+		t.Error("expected 0 and 3 to be 2-edge-connected")
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/iterator"
+)
+
+// ApproxFeedbackVertexSet returns a set of nodes of the directed graph g whose
+// removal leaves g acyclic. The set is found greedily: while the remaining graph
+// has a cycle, the node with the highest total degree in the remaining graph
+// among the nodes that lie on a cycle is removed, with ties broken by lowest ID.
+// Nodes lie on a cycle when they are in a strongly connected component with more
+// than one node or have a self loop. The returned set is not guaranteed to be a
+// minimum feedback vertex set. The returned nodes are in order of removal.
+func ApproxFeedbackVertexSet(g graph.Directed) []graph.Node {
+	view := withoutNodes{Directed: g, removed: make(set.Int64s)}
+	var removed []graph.Node
+	for {
+		var (
+			best       graph.Node
+			bestDegree int
+		)
+		for _, c := range TarjanSCC(view) {
+			if len(c) == 1 {
+				if id := c[0].ID(); !view.HasEdgeFromTo(id, id) {
+					continue
+				}
+			}
+			for _, n := range c {
+				d := view.From(n.ID()).Len() + view.To(n.ID()).Len()
+				if best == nil || d > bestDegree || (d == bestDegree && n.ID() < best.ID()) {
+					best = n
+					bestDegree = d
+				}
+			}
+		}
+		if best == nil {
+			return removed
+		}
+		view.removed.Add(best.ID())
+		removed = append(removed, best)
+	}
+}
+
+// withoutNodes is a view of a directed graph that hides a set of nodes
+// and their edges.
+type withoutNodes struct {
+	graph.Directed
+	removed set.Int64s
+}
+
+func (g withoutNodes) Node(id int64) graph.Node {
+	if g.removed.Has(id) {
+		return nil
+	}
+	return g.Directed.Node(id)
+}
+
+func (g withoutNodes) Nodes() graph.Nodes {
+	return g.filter(g.Directed.Nodes())
+}
+
+func (g withoutNodes) From(id int64) graph.Nodes {
+	if g.removed.Has(id) {
+		return graph.Empty
+	}
+	return g.filter(g.Directed.From(id))
+}
+
+func (g withoutNodes) To(id int64) graph.Nodes {
+	if g.removed.Has(id) {
+		return graph.Empty
+	}
+	return g.filter(g.Directed.To(id))
+}
+
+func (g withoutNodes) HasEdgeBetween(xid, yid int64) bool {
+	return g.HasEdgeFromTo(xid, yid) || g.HasEdgeFromTo(yid, xid)
+}
+
+func (g withoutNodes) Edge(uid, vid int64) graph.Edge {
+	if g.removed.Has(uid) || g.removed.Has(vid) {
+		return nil
+	}
+	return g.Directed.Edge(uid, vid)
+}
+
+func (g withoutNodes) HasEdgeFromTo(uid, vid int64) bool {
+	return !g.removed.Has(uid) && !g.removed.Has(vid) && g.Directed.HasEdgeFromTo(uid, vid)
+}
+
+// filter returns the nodes of it that have not been removed, sorted by ID.
+func (g withoutNodes) filter(it graph.Nodes) graph.Nodes {
+	var nodes []graph.Node
+	for it.Next() {
+		n := it.Node()
+		if !g.removed.Has(n.ID()) {
+			nodes = append(nodes, n)
+		}
+	}
+	if len(nodes) == 0 {
+		return graph.Empty
+	}
+	sort.Sort(ordered.ByID(nodes))
+	return iterator.NewOrderedNodes(nodes)
+}
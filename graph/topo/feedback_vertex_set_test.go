@@ -0,0 +1,155 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/multi"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestApproxFeedbackVertexSet(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		g       []intset
+		wantLen int
+	}{
+		{
+			name: "dag",
+			g: []intset{
+				0: linksTo(1, 2),
+				1: linksTo(2),
+				2: nil,
+			},
+			wantLen: 0,
+		},
+		{
+			name: "cycle",
+			g: []intset{
+				0: linksTo(1),
+				1: linksTo(2),
+				2: linksTo(0),
+			},
+			wantLen: 1,
+		},
+		{
+			// Node 0 lies on both cycles.
+			name: "shared",
+			g: []intset{
+				0: linksTo(1, 3),
+				1: linksTo(2),
+				2: linksTo(0),
+				3: linksTo(4),
+				4: linksTo(0),
+			},
+			wantLen: 1,
+		},
+		{
+			name: "disjoint",
+			g: []intset{
+				0: linksTo(1),
+				1: linksTo(0),
+				2: linksTo(3),
+				3: linksTo(2),
+			},
+			wantLen: 2,
+		},
+	} {
+		g := simple.NewDirectedGraph()
+		for u, e := range test.g {
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		fvs := ApproxFeedbackVertexSet(g)
+		if len(fvs) != test.wantLen {
+			t.Errorf("unexpected feedback vertex set size for %s: got:%d want:%d", test.name, len(fvs), test.wantLen)
+		}
+		checkFeedbackVertexSet(t, test.name, g, fvs)
+	}
+}
+
+func TestApproxFeedbackVertexSetNearDAG(t *testing.T) {
+	const (
+		n     = 100
+		back  = 5
+		pEdge = 0.05
+	)
+	for seed := uint64(1); seed <= 5; seed++ {
+		rnd := rand.New(rand.NewSource(seed))
+		g := simple.NewDirectedGraph()
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				if rnd.Float64() < pEdge {
+					g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(j)})
+				}
+			}
+		}
+		for added := 0; added < back; {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u <= v || g.HasEdgeBetween(int64(u), int64(v)) {
+				continue
+			}
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			added++
+		}
+
+		fvs := ApproxFeedbackVertexSet(g)
+		// Removing the tail of each back edge breaks
+		// all cycles, so a minimum set is no larger.
+		if len(fvs) > back {
+			t.Errorf("unexpectedly large feedback vertex set for seed %d: got:%d want:<=%d", seed, len(fvs), back)
+		}
+		checkFeedbackVertexSet(t, "near DAG", g, fvs)
+	}
+}
+
+func TestApproxFeedbackVertexSetSelfLoop(t *testing.T) {
+	g := multi.NewDirectedGraph()
+	g.SetLine(g.NewLine(multi.Node(0), multi.Node(1)))
+	g.SetLine(g.NewLine(multi.Node(1), multi.Node(1)))
+	fvs := ApproxFeedbackVertexSet(g)
+	if len(fvs) != 1 || fvs[0].ID() != 1 {
+		t.Errorf("unexpected feedback vertex set for self loop: got:%v want:[1]", fvs)
+	}
+}
+
+func checkFeedbackVertexSet(t *testing.T, name string, g graph.Directed, fvs []graph.Node) {
+	t.Helper()
+	dst := simple.NewDirectedGraph()
+	removed := make(map[int64]bool)
+	for _, n := range fvs {
+		removed[n.ID()] = true
+	}
+	nodes := g.Nodes()
+	for nodes.Next() {
+		u := nodes.Node()
+		if removed[u.ID()] {
+			continue
+		}
+		if dst.Node(u.ID()) == nil {
+			dst.AddNode(u)
+		}
+		to := g.From(u.ID())
+		for to.Next() {
+			if v := to.Node(); !removed[v.ID()] {
+				dst.SetEdge(simple.Edge{F: u, T: v})
+			}
+		}
+	}
+	if _, err := Sort(dst); err != nil {
+		t.Errorf("graph not acyclic after removing feedback vertex set for %s: %v", name, err)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import "gonum.org/v1/gonum/graph"
+
+// Girth returns the number of edges in the shortest cycle in g. If g is a
+// graph.Directed, the shortest directed cycle is found, otherwise g is treated
+// as undirected. Girth returns -1 if g has no cycle. Edge weights are ignored.
+//
+// The girth is found by a breadth first search from each node in g, so Girth
+// takes O(|V|.(|V|+|E|)) time.
+func Girth(g graph.Graph) int {
+	nodes := graph.NodesOf(g.Nodes())
+	_, directed := g.(graph.Directed)
+	girth := -1
+	for _, s := range nodes {
+		var n int
+		if directed {
+			n = shortestDirectedCycleThrough(g, s.ID(), girth)
+		} else {
+			n = shortestUndirectedCycleFrom(g, s.ID(), girth)
+		}
+		if n > 0 && (girth < 0 || n < girth) {
+			girth = n
+		}
+	}
+	return girth
+}
+
+// shortestDirectedCycleThrough returns the length of the shortest directed
+// cycle in g through s, or -1 if there is none shorter than limit. A negative
+// limit is ignored.
+func shortestDirectedCycleThrough(g graph.Graph, s int64, limit int) int {
+	depth := map[int64]int{s: 0}
+	queue := []int64{s}
+	for len(queue) != 0 {
+		u := queue[0]
+		queue = queue[1:]
+		d := depth[u] + 1
+		if limit >= 0 && d >= limit {
+			return -1
+		}
+		to := g.From(u)
+		for to.Next() {
+			v := to.Node().ID()
+			if v == s {
+				return d
+			}
+			if _, ok := depth[v]; !ok {
+				depth[v] = d
+				queue = append(queue, v)
+			}
+		}
+	}
+	return -1
+}
+
+// shortestUndirectedCycleFrom returns the length of the shortest cycle
+// closed by a non-tree edge of a breadth first search of g from s, or -1
+// if there is none shorter than limit. A negative limit is ignored. The
+// minimum over all s is the girth of g.
+func shortestUndirectedCycleFrom(g graph.Graph, s int64, limit int) int {
+	depth := map[int64]int{s: 0}
+	parent := map[int64]int64{s: s}
+	queue := []int64{s}
+	best := -1
+	for len(queue) != 0 {
+		u := queue[0]
+		queue = queue[1:]
+		if best >= 0 && 2*depth[u]+1 >= best {
+			// No shorter cycle can be found.
+			break
+		}
+		if limit >= 0 && 2*depth[u]+1 >= limit {
+			break
+		}
+		to := g.From(u)
+		for to.Next() {
+			v := to.Node().ID()
+			if v == u {
+				return 1
+			}
+			dv, ok := depth[v]
+			if !ok {
+				depth[v] = depth[u] + 1
+				parent[v] = u
+				queue = append(queue, v)
+				continue
+			}
+			if parent[u] == v {
+				continue
+			}
+			if n := depth[u] + dv + 1; best < 0 || n < best {
+				best = n
+			}
+		}
+	}
+	return best
+}
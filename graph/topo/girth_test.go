@@ -0,0 +1,62 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var girthTests = []struct {
+	name     string
+	directed bool
+	edges    [][2]int64
+	want     int
+}{
+	{name: "empty", want: -1},
+	{name: "triangle", edges: [][2]int64{{0, 1}, {1, 2}, {2, 0}}, want: 3},
+	{name: "tree", edges: [][2]int64{{0, 1}, {0, 2}, {1, 3}, {1, 4}, {2, 5}}, want: -1},
+	{name: "square with tail", edges: [][2]int64{{0, 1}, {1, 2}, {2, 3}, {3, 0}, {3, 4}, {4, 5}}, want: 4},
+	{
+		// The Petersen graph has girth 5.
+		name: "petersen",
+		edges: [][2]int64{
+			{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0},
+			{0, 5}, {1, 6}, {2, 7}, {3, 8}, {4, 9},
+			{5, 7}, {7, 9}, {9, 6}, {6, 8}, {8, 5},
+		},
+		want: 5,
+	},
+	{name: "pentagon and hexagon", edges: [][2]int64{
+		{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0},
+		{10, 11}, {11, 12}, {12, 13}, {13, 14}, {14, 15}, {15, 10},
+	}, want: 5},
+	{name: "directed dag", directed: true, edges: [][2]int64{{0, 1}, {1, 2}, {0, 2}}, want: -1},
+	{name: "directed triangle", directed: true, edges: [][2]int64{{0, 1}, {1, 2}, {2, 0}}, want: 3},
+	{name: "directed two cycle", directed: true, edges: [][2]int64{{0, 1}, {1, 0}, {1, 2}, {2, 3}, {3, 1}}, want: 2},
+	{name: "directed square", directed: true, edges: [][2]int64{{0, 1}, {1, 2}, {2, 3}, {3, 0}, {0, 2}}, want: 3},
+}
+
+func TestGirth(t *testing.T) {
+	for _, test := range girthTests {
+		var g interface {
+			graph.Graph
+			SetEdge(graph.Edge)
+		}
+		if test.directed {
+			g = simple.NewDirectedGraph()
+		} else {
+			g = simple.NewUndirectedGraph()
+		}
+		for _, e := range test.edges {
+			g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+		}
+		if got := Girth(g); got != test.want {
+			t.Errorf("%s: unexpected girth: got:%d want:%d", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import "gonum.org/v1/gonum/graph"
+
+// IncrementalComponents maintains the number of connected components of an
+// undirected graph over a fixed node set as edges are added. It is a streaming
+// complement to ConnectedComponents.
+type IncrementalComponents struct {
+	indexOf map[int64]int
+	forest  unionFind
+	count   int
+}
+
+// NewIncrementalComponents returns an IncrementalComponents holding the given
+// nodes with no edges, so each node is initially in its own component.
+// Duplicate nodes are ignored.
+func NewIncrementalComponents(nodes []graph.Node) *IncrementalComponents {
+	indexOf := make(map[int64]int, len(nodes))
+	for _, n := range nodes {
+		if _, ok := indexOf[n.ID()]; !ok {
+			indexOf[n.ID()] = len(indexOf)
+		}
+	}
+	return &IncrementalComponents{
+		indexOf: indexOf,
+		forest:  newUnionFind(len(indexOf)),
+		count:   len(indexOf),
+	}
+}
+
+// Union adds an edge between a and b, merging their components.
+// Union will panic if a or b is not in the node set of c.
+func (c *IncrementalComponents) Union(a, b graph.Node) {
+	i, ok := c.indexOf[a.ID()]
+	if !ok {
+		panic("topo: node not in component set")
+	}
+	j, ok := c.indexOf[b.ID()]
+	if !ok {
+		panic("topo: node not in component set")
+	}
+	i, j = c.forest.find(i), c.forest.find(j)
+	if i == j {
+		return
+	}
+	c.forest.union(i, j)
+	c.count--
+}
+
+// Count returns the current number of connected components.
+func (c *IncrementalComponents) Count() int {
+	return c.count
+}
@@ -0,0 +1,69 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestIncrementalComponents(t *testing.T) {
+	nodes := []graph.Node{simple.Node(0), simple.Node(1), simple.Node(2), simple.Node(3), simple.Node(4), simple.Node(4)}
+	c := NewIncrementalComponents(nodes)
+	if got := c.Count(); got != 5 {
+		t.Fatalf("unexpected initial count: got:%d want:5", got)
+	}
+	for _, test := range []struct {
+		a, b int64
+		want int
+	}{
+		{a: 0, b: 1, want: 4},
+		{a: 1, b: 0, want: 4},
+		{a: 2, b: 3, want: 3},
+		{a: 3, b: 3, want: 3},
+		{a: 1, b: 3, want: 2},
+		{a: 0, b: 2, want: 2},
+		{a: 4, b: 2, want: 1},
+	} {
+		c.Union(simple.Node(test.a), simple.Node(test.b))
+		if got := c.Count(); got != test.want {
+			t.Errorf("unexpected count after union of %d and %d: got:%d want:%d", test.a, test.b, got, test.want)
+		}
+	}
+
+	panicked := func() (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		c.Union(simple.Node(0), simple.Node(10))
+		return false
+	}()
+	if !panicked {
+		t.Error("expected panic for union with absent node")
+	}
+}
+
+func TestIncrementalComponentsMatchesConnectedComponents(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	const n = 100
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < n; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	c := NewIncrementalComponents(graph.NodesOf(g.Nodes()))
+	for i := 0; i < 120; i++ {
+		u, v := rnd.Intn(n), rnd.Intn(n)
+		if u == v {
+			continue
+		}
+		g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		c.Union(simple.Node(u), simple.Node(v))
+		if got, want := c.Count(), len(ConnectedComponents(g)); got != want {
+			t.Fatalf("unexpected count after %d edges: got:%d want:%d", i+1, got, want)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// KargerStein returns the weight of a minimum cut of the undirected graph g and the
+// nodes on one side of the cut, sorted by ID, using the recursive contraction
+// algorithm of Karger and Stein. The weight of a cut is the sum of the weights of
+// the edges crossing it. If g has fewer than two nodes, the cut weight is +Inf and
+// the returned side is nil.
+//
+// Each run of the algorithm contracts randomly chosen edges, with probability
+// proportional to their weight, until ⌈1 + n/√2⌉ of the n nodes remain, and then
+// recurses twice independently on the contracted graph, keeping the lighter of the
+// two cuts found. Graphs with at most six nodes are solved exhaustively. A single
+// run takes O(n² log n) time and finds a particular minimum cut with probability
+// Ω(1/log n), so KargerStein performs ⌈log₂ n⌉² runs, finding a minimum cut with
+// probability 1 - O(1/n). The returned cut is not guaranteed to be minimum.
+//
+// Random numbers are drawn from src, or the global rand source if src is nil;
+// runs with equivalent sources are reproducible. If weight is nil, the Weight
+// method of g is used if g implements graph.Weighted, falling back to UniformCost
+// otherwise. Self edges are ignored. KargerStein will panic if g has a negative
+// or infinite edge weight.
+func KargerStein(g graph.Undirected, weight Weighting, src rand.Source) (cut float64, side []graph.Node) {
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) < 2 {
+		return math.Inf(1), nil
+	}
+	sort.Sort(ordered.ByID(nodes))
+	if weight == nil {
+		if wg, ok := g.(graph.Weighted); ok {
+			weight = wg.Weight
+		} else {
+			weight = UniformCost(g)
+		}
+	}
+	rnd := rand.Float64
+	if src != nil {
+		rnd = rand.New(src).Float64
+	}
+
+	index := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		index[n.ID()] = i
+	}
+	c := cutGraph{
+		w:       make([][]float64, len(nodes)),
+		degree:  make([]float64, len(nodes)),
+		members: make([][]graph.Node, len(nodes)),
+	}
+	for i, u := range nodes {
+		c.w[i] = make([]float64, len(nodes))
+		c.members[i] = []graph.Node{u}
+	}
+	for i, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if vid == uid {
+				continue
+			}
+			w, ok := weight(uid, vid)
+			if !ok {
+				panic("topo: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("topo: negative edge weight")
+			}
+			if math.IsInf(w, 1) {
+				panic("topo: infinite edge weight")
+			}
+			c.w[i][index[vid]] = w
+			c.degree[i] += w
+		}
+	}
+
+	runs := int(math.Ceil(math.Log2(float64(len(nodes)))))
+	runs *= runs
+	cut = math.Inf(1)
+	for i := 0; i < runs; i++ {
+		h := c.clone()
+		w, s := h.minCut(rnd)
+		if w < cut {
+			cut, side = w, s
+		}
+		if cut == 0 {
+			break
+		}
+	}
+	side = append([]graph.Node(nil), side...)
+	sort.Sort(ordered.ByID(side))
+	return cut, side
+}
+
+// cutGraph is a dense weighted graph of contracted nodes.
+type cutGraph struct {
+	// w holds the weights between
+	// contracted nodes and degree
+	// their weighted degrees.
+	w      [][]float64
+	degree []float64
+
+	// members holds the nodes of the
+	// original graph contracted into
+	// each node.
+	members [][]graph.Node
+}
+
+// clone returns a deep copy of c.
+func (c cutGraph) clone() cutGraph {
+	n := cutGraph{
+		w:       make([][]float64, len(c.w)),
+		degree:  append([]float64(nil), c.degree...),
+		members: append([][]graph.Node(nil), c.members...),
+	}
+	for i, row := range c.w {
+		n.w[i] = append([]float64(nil), row...)
+	}
+	return n
+}
+
+// minCut returns the weight of a cut of c and the members
+// of one side found by recursive contraction, modifying c.
+func (c *cutGraph) minCut(rnd func() float64) (float64, []graph.Node) {
+	n := len(c.w)
+	if n <= 6 {
+		return c.exhaustive()
+	}
+	t := int(math.Ceil(1 + float64(n)/math.Sqrt2))
+	cut := math.Inf(1)
+	var side []graph.Node
+	for i := 0; i < 2; i++ {
+		h := c.clone()
+		if !h.contract(t, rnd) {
+			// A contracted node with no
+			// edges forms a cut of weight zero.
+			return 0, h.isolated()
+		}
+		w, s := h.minCut(rnd)
+		if w < cut {
+			cut, side = w, s
+		}
+	}
+	return cut, side
+}
+
+// contract contracts randomly chosen edges of c, with probability
+// proportional to their weight, until t nodes remain. It returns
+// false if contraction stops early because no edges remain.
+func (c *cutGraph) contract(t int, rnd func() float64) bool {
+	for len(c.w) > t {
+		var total float64
+		for _, d := range c.degree {
+			total += d
+		}
+		if total == 0 {
+			return false
+		}
+		i := choose(c.degree, total*rnd())
+		if i < 0 {
+			return false
+		}
+		j := choose(c.w[i], c.degree[i]*rnd())
+		c.merge(i, j)
+	}
+	return true
+}
+
+// choose returns the index of the element of weights at which
+// the cumulative sum of weights exceeds r, falling back to the
+// last positive weight to guard against rounding error.
+func choose(weights []float64, r float64) int {
+	last := -1
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		if r < w {
+			return i
+		}
+		r -= w
+		last = i
+	}
+	return last
+}
+
+// merge contracts node j of c into node i.
+func (c *cutGraph) merge(i, j int) {
+	c.w[i][j] = 0
+	c.w[j][i] = 0
+	c.degree[i] = 0
+	for k := range c.w {
+		c.w[i][k] += c.w[j][k]
+		c.w[k][i] = c.w[i][k]
+		c.degree[i] += c.w[i][k]
+	}
+	c.w[i][i] = 0
+	members := c.members[i]
+	c.members[i] = append(members[:len(members):len(members)], c.members[j]...)
+
+	// Move the last node into the place of j.
+	last := len(c.w) - 1
+	c.w[j] = c.w[last]
+	for k := range c.w {
+		c.w[k][j] = c.w[k][last]
+	}
+	c.degree[j] = c.degree[last]
+	c.members[j] = c.members[last]
+	c.w = c.w[:last]
+	for k := range c.w {
+		c.w[k] = c.w[k][:last]
+	}
+	c.degree = c.degree[:last]
+	c.members = c.members[:last]
+}
+
+// exhaustive returns the weight of a minimum cut of c and the
+// members of one side by enumerating all bipartitions.
+func (c *cutGraph) exhaustive() (float64, []graph.Node) {
+	n := len(c.w)
+	cut := math.Inf(1)
+	var best uint
+	// Node zero is always on the side given by the
+	// set bits of mask, and at least one node is not.
+	for mask := uint(1); mask < 1<<uint(n)-1; mask += 2 {
+		var w float64
+		for i := 0; i < n; i++ {
+			if mask&(1<<uint(i)) == 0 {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if mask&(1<<uint(j)) == 0 {
+					w += c.w[i][j]
+				}
+			}
+		}
+		if w < cut {
+			cut, best = w, mask
+		}
+	}
+	var side []graph.Node
+	for i := 0; i < n; i++ {
+		if best&(1<<uint(i)) != 0 {
+			side = append(side, c.members[i]...)
+		}
+	}
+	return cut, side
+}
+
+// isolated returns the members of a node of c with no edges.
+func (c *cutGraph) isolated() []graph.Node {
+	for i, d := range c.degree {
+		if d == 0 {
+			return c.members[i]
+		}
+	}
+	return nil
+}
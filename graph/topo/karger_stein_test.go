@@ -0,0 +1,135 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestKargerStein(t *testing.T) {
+	// Two 5-cliques joined by edges of weight 1 and 2.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, offset := range []int{0, 5} {
+		for i := 0; i < 5; i++ {
+			for j := i + 1; j < 5; j++ {
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(offset + i), T: simple.Node(offset + j), W: 3})
+			}
+		}
+	}
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(5), W: 1})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(4), T: simple.Node(9), W: 2})
+
+	cut, side := KargerStein(g, nil, rand.NewSource(1))
+	if cut != 3 {
+		t.Errorf("unexpected cut weight: got:%v want:3", cut)
+	}
+	if got := cutIDs(side); !(reflect.DeepEqual(got, []int64{0, 1, 2, 3, 4}) || reflect.DeepEqual(got, []int64{5, 6, 7, 8, 9})) {
+		t.Errorf("unexpected cut side: %v", got)
+	}
+
+	// A disconnected graph has a cut of weight zero.
+	g.RemoveEdge(0, 5)
+	g.RemoveEdge(4, 9)
+	cut, side = KargerStein(g, nil, rand.NewSource(1))
+	if cut != 0 || cutWeight(g, side) != 0 {
+		t.Errorf("unexpected cut for disconnected graph: weight:%v side:%v", cut, cutIDs(side))
+	}
+
+	// Unweighted graphs have unit edge weights.
+	u := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 0}, {2, 3}, {3, 4}, {4, 5}, {5, 3}} {
+		u.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	cut, side = KargerStein(u, nil, rand.NewSource(1))
+	if got := cutIDs(side); cut != 1 || !(reflect.DeepEqual(got, []int64{0, 1, 2}) || reflect.DeepEqual(got, []int64{3, 4, 5})) {
+		t.Errorf("unexpected cut for unweighted graph: weight:%v side:%v", cut, got)
+	}
+
+	single := simple.NewUndirectedGraph()
+	single.AddNode(simple.Node(0))
+	cut, side = KargerStein(single, nil, nil)
+	if !math.IsInf(cut, 1) || side != nil {
+		t.Errorf("unexpected cut for single node graph: weight:%v side:%v", cut, cutIDs(side))
+	}
+}
+
+func TestKargerSteinRandom(t *testing.T) {
+	src := rand.NewSource(1)
+	rnd := rand.New(src)
+	for trial := 0; trial < 50; trial++ {
+		n := 2 + rnd.Intn(10)
+		g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				if rnd.Float64() < 0.5 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i), T: simple.Node(j), W: float64(1 + rnd.Intn(5))})
+				}
+			}
+		}
+		want := bruteForceMinCut(g)
+		cut, side := KargerStein(g, nil, src)
+		if cut != want {
+			t.Errorf("trial %d: unexpected cut weight for %d node graph: got:%v want:%v", trial, n, cut, want)
+		}
+		if len(side) == 0 || len(side) == n {
+			t.Errorf("trial %d: cut side is not a proper subset: %v", trial, cutIDs(side))
+		}
+		if w := cutWeight(g, side); w != cut {
+			t.Errorf("trial %d: cut side does not match weight: side weight:%v cut:%v", trial, w, cut)
+		}
+	}
+}
+
+// cutWeight returns the weight of edges in g crossing
+// between side and the remaining nodes.
+func cutWeight(g *simple.WeightedUndirectedGraph, side []graph.Node) float64 {
+	in := make(map[int64]bool)
+	for _, n := range side {
+		in[n.ID()] = true
+	}
+	var w float64
+	for _, e := range graph.WeightedEdgesOf(g.WeightedEdges()) {
+		if in[e.From().ID()] != in[e.To().ID()] {
+			w += e.Weight()
+		}
+	}
+	return w
+}
+
+// bruteForceMinCut returns the weight of a minimum cut of g
+// with nodes 0 to n-1 by enumerating all bipartitions.
+func bruteForceMinCut(g *simple.WeightedUndirectedGraph) float64 {
+	n := g.Nodes().Len()
+	best := math.Inf(1)
+	for mask := 1; mask < 1<<uint(n)-1; mask += 2 {
+		var side []graph.Node
+		for i := 0; i < n; i++ {
+			if mask&(1<<uint(i)) != 0 {
+				side = append(side, simple.Node(i))
+			}
+		}
+		best = math.Min(best, cutWeight(g, side))
+	}
+	return best
+}
+
+// cutIDs returns the IDs of the nodes in side.
+func cutIDs(side []graph.Node) []int64 {
+	var ids []int64
+	for _, n := range side {
+		ids = append(ids, n.ID())
+	}
+	return ids
+}
@@ -0,0 +1,93 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"container/heap"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// LexicographicTopologicalSort performs a topological sort of the directed graph g
+// returning the lexicographically smallest 'from' to 'to' sort order by node ID.
+// This is the unique order in which, at each position, the node with the lowest ID
+// of those with all their predecessors already placed is chosen. Self loops are
+// ignored.
+//
+// If a topological ordering is not possible, the nodes that could be ordered are
+// returned with an Unorderable error listing the cyclic components of the nodes
+// that could not be ordered, with each component's members sorted by ID.
+func LexicographicTopologicalSort(g graph.Directed) ([]graph.Node, error) {
+	nodes := graph.NodesOf(g.Nodes())
+	indegree := make(map[int64]int, len(nodes))
+	var ready byIDHeap
+	for _, n := range nodes {
+		id := n.ID()
+		to := g.To(id)
+		for to.Next() {
+			if to.Node().ID() != id {
+				indegree[id]++
+			}
+		}
+		if indegree[id] == 0 {
+			ready = append(ready, n)
+		}
+	}
+	heap.Init(&ready)
+
+	sorted := make([]graph.Node, 0, len(nodes))
+	for ready.Len() != 0 {
+		u := heap.Pop(&ready).(graph.Node)
+		sorted = append(sorted, u)
+		uid := u.ID()
+		from := g.From(uid)
+		for from.Next() {
+			v := from.Node()
+			if v.ID() == uid {
+				continue
+			}
+			indegree[v.ID()]--
+			if indegree[v.ID()] == 0 {
+				heap.Push(&ready, v)
+			}
+		}
+	}
+	if len(sorted) == len(nodes) {
+		return sorted, nil
+	}
+
+	// Report the cyclic components among the
+	// nodes that could not be placed.
+	placed := make(map[int64]bool, len(sorted))
+	for _, n := range sorted {
+		placed[n.ID()] = true
+	}
+	var sc Unorderable
+	for _, c := range TarjanSCC(g) {
+		if len(c) < 2 || placed[c[0].ID()] {
+			continue
+		}
+		sort.Sort(ordered.ByID(c))
+		sc = append(sc, c)
+	}
+	sort.Sort(ordered.BySliceIDs(sc))
+	return sorted, sc
+}
+
+// byIDHeap is a min-heap of nodes ordered by ID.
+type byIDHeap []graph.Node
+
+func (h byIDHeap) Len() int            { return len(h) }
+func (h byIDHeap) Less(i, j int) bool  { return h[i].ID() < h[j].ID() }
+func (h byIDHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *byIDHeap) Push(n interface{}) { *h = append(*h, n.(graph.Node)) }
+func (h *byIDHeap) Pop() interface{} {
+	t := *h
+	var n interface{}
+	n, *h = t[len(t)-1], t[:len(t)-1]
+	return n
+}
@@ -0,0 +1,107 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var lexicographicSortTests = []struct {
+	name      string
+	g         []intset
+	want      []int64
+	wantCycle [][]int64
+}{
+	{
+		name: "independent",
+		g: []intset{
+			0: nil,
+			1: nil,
+			2: nil,
+		},
+		want: []int64{0, 1, 2},
+	},
+	{
+		// Valid orders include 3 0 2 1 4 and 0 3 1 2 4.
+		name: "diamond and chain",
+		g: []intset{
+			0: linksTo(1, 2),
+			1: linksTo(4),
+			2: linksTo(4),
+			3: linksTo(4),
+			4: nil,
+		},
+		want: []int64{0, 1, 2, 3, 4},
+	},
+	{
+		name: "high ID first",
+		g: []intset{
+			0: nil,
+			1: linksTo(0),
+			2: nil,
+			3: linksTo(1),
+		},
+		want: []int64{2, 3, 1, 0},
+	},
+	{
+		name: "cycle",
+		g: []intset{
+			0: linksTo(1),
+			1: linksTo(2),
+			2: linksTo(1, 3),
+			3: nil,
+			4: nil,
+		},
+		want:      []int64{0, 4},
+		wantCycle: [][]int64{{1, 2}},
+	},
+}
+
+func TestLexicographicTopologicalSort(t *testing.T) {
+	for _, test := range lexicographicSortTests {
+		g := simple.NewDirectedGraph()
+		for u, e := range test.g {
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		got, err := LexicographicTopologicalSort(g)
+		var gotIDs []int64
+		for _, n := range got {
+			gotIDs = append(gotIDs, n.ID())
+		}
+		if !reflect.DeepEqual(gotIDs, test.want) {
+			t.Errorf("%q: unexpected order: got:%v want:%v", test.name, gotIDs, test.want)
+		}
+		if test.wantCycle == nil {
+			if err != nil {
+				t.Errorf("%q: unexpected error: %v", test.name, err)
+			}
+			continue
+		}
+		uo, ok := err.(Unorderable)
+		if !ok {
+			t.Errorf("%q: expected Unorderable error, got:%v", test.name, err)
+			continue
+		}
+		var gotCycles [][]int64
+		for _, c := range uo {
+			var ids []int64
+			for _, n := range c {
+				ids = append(ids, n.ID())
+			}
+			gotCycles = append(gotCycles, ids)
+		}
+		if !reflect.DeepEqual(gotCycles, test.wantCycle) {
+			t.Errorf("%q: unexpected cycles: got:%v want:%v", test.name, gotCycles, test.wantCycle)
+		}
+	}
+}
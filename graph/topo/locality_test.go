@@ -0,0 +1,96 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// meanEdgeSpan returns the mean difference in position in order
+// between the ends of the edges of g.
+func meanEdgeSpan(g graph.Graph, order []graph.Node) float64 {
+	pos := make(map[int64]int, len(order))
+	for i, n := range order {
+		pos[n.ID()] = i
+	}
+	var sum, n int
+	for _, u := range order {
+		to := g.From(u.ID())
+		for to.Next() {
+			d := pos[u.ID()] - pos[to.Node().ID()]
+			if d < 0 {
+				d = -d
+			}
+			sum += d
+			n++
+		}
+	}
+	return float64(sum) / float64(n)
+}
+
+func TestLocalityOrdering(t *testing.T) {
+	const side = 10
+	for seed := uint64(1); seed <= 5; seed++ {
+		for _, directed := range []bool{false, true} {
+			// Construct a grid with its nodes randomly relabelled.
+			perm := rand.New(rand.NewSource(seed)).Perm(side * side)
+			var g interface {
+				graph.Graph
+				SetEdge(graph.Edge)
+			}
+			if directed {
+				g = simple.NewDirectedGraph()
+			} else {
+				g = simple.NewUndirectedGraph()
+			}
+			for r := 0; r < side; r++ {
+				for c := 0; c < side; c++ {
+					u := simple.Node(perm[r*side+c])
+					if c+1 < side {
+						g.SetEdge(simple.Edge{F: u, T: simple.Node(perm[r*side+c+1])})
+					}
+					if r+1 < side {
+						g.SetEdge(simple.Edge{F: u, T: simple.Node(perm[(r+1)*side+c])})
+					}
+				}
+			}
+			// Add a second component.
+			g.SetEdge(simple.Edge{F: simple.Node(side * side), T: simple.Node(side*side + 1)})
+
+			original := graph.NodesOf(g.Nodes())
+			sort.Sort(ordered.ByID(original))
+			order := LocalityOrdering(g)
+
+			if len(order) != len(original) {
+				t.Fatalf("seed %d directed=%t: unexpected ordering length: got:%d want:%d",
+					seed, directed, len(order), len(original))
+			}
+			seen := make(map[int64]bool)
+			for _, u := range order {
+				if g.Node(u.ID()) == nil {
+					t.Errorf("seed %d directed=%t: node %d not in graph", seed, directed, u.ID())
+				}
+				if seen[u.ID()] {
+					t.Errorf("seed %d directed=%t: node %d repeated in ordering", seed, directed, u.ID())
+				}
+				seen[u.ID()] = true
+			}
+
+			before := meanEdgeSpan(g, original)
+			after := meanEdgeSpan(g, order)
+			if after >= before {
+				t.Errorf("seed %d directed=%t: ordering did not reduce mean edge span: before:%v after:%v",
+					seed, directed, before, after)
+			}
+		}
+	}
+}
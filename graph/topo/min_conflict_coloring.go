@@ -0,0 +1,177 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// MinConflictColoring returns a coloring of the undirected graph g with k colors
+// that minimizes the number of conflicts, edges joining two nodes of the same color,
+// and the number of conflicts of the coloring. The returned map holds the color in
+// [0, k) of each node of g, keyed by node ID. Self loops are ignored.
+//
+// MinConflictColoring uses the min-conflicts local search heuristic. Starting from a
+// random coloring, at each of up to maxSteps steps a node with a conflict is chosen
+// at random and recolored with the color that gives it the fewest conflicts, with
+// ties broken randomly. The best coloring found is returned; the search stops early
+// if a coloring without conflicts is found. The result is not guaranteed to be
+// optimal. If src is nil, rand.Intn is used as the random generator.
+// MinConflictColoring will panic if k is less than one.
+func MinConflictColoring(g graph.Undirected, k, maxSteps int, src rand.Source) (colors map[int64]int, conflicts int) {
+	if k < 1 {
+		panic("topo: invalid number of colors")
+	}
+	rnd := rand.Intn
+	if src != nil {
+		rnd = rand.New(src).Intn
+	}
+
+	// Sort the nodes so that the search is
+	// reproducible for a given source.
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	adj := make([][]int, len(nodes))
+	for i, u := range nodes {
+		to := g.From(u.ID())
+		for to.Next() {
+			if j := indexOf[to.Node().ID()]; j != i {
+				adj[i] = append(adj[i], j)
+			}
+		}
+		sort.Ints(adj[i])
+	}
+
+	c := conflictColorer{
+		adj:   adj,
+		color: make([]int, len(nodes)),
+		same:  make([]int, len(nodes)),
+		pos:   make([]int, len(nodes)),
+	}
+	for i := range nodes {
+		c.color[i] = rnd(k)
+		c.pos[i] = -1
+	}
+	for i := range nodes {
+		for _, j := range adj[i] {
+			if c.color[j] == c.color[i] {
+				c.same[i]++
+			}
+		}
+		if c.same[i] > 0 {
+			c.mark(i)
+		}
+		c.conflicts += c.same[i]
+	}
+	// Each conflict has been counted from both ends.
+	c.conflicts /= 2
+
+	best := append([]int(nil), c.color...)
+	bestConflicts := c.conflicts
+	count := make([]int, k)
+	var ties []int
+	for step := 0; step < maxSteps && c.conflicts != 0; step++ {
+		u := c.conflicted[rnd(len(c.conflicted))]
+		for i := range count {
+			count[i] = 0
+		}
+		for _, v := range adj[u] {
+			count[c.color[v]]++
+		}
+		ties = ties[:0]
+		for col, n := range count {
+			switch {
+			case len(ties) == 0 || n < count[ties[0]]:
+				ties = append(ties[:0], col)
+			case n == count[ties[0]]:
+				ties = append(ties, col)
+			}
+		}
+		c.recolor(u, ties[rnd(len(ties))])
+		if c.conflicts < bestConflicts {
+			copy(best, c.color)
+			bestConflicts = c.conflicts
+		}
+	}
+
+	colors = make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		colors[n.ID()] = best[i]
+	}
+	return colors, bestConflicts
+}
+
+// conflictColorer holds the state of a min-conflicts coloring search.
+type conflictColorer struct {
+	adj   [][]int
+	color []int
+
+	// same holds the number of neighbors of
+	// each node that share its color, and
+	// conflicts holds the number of edges
+	// joining nodes of the same color.
+	same      []int
+	conflicts int
+
+	// conflicted holds the nodes with
+	// a conflict and pos holds the index
+	// of each node in conflicted, or -1.
+	conflicted []int
+	pos        []int
+}
+
+// recolor changes the color of u to col, updating the conflict state.
+func (c *conflictColorer) recolor(u, col int) {
+	old := c.color[u]
+	if old == col {
+		return
+	}
+	c.color[u] = col
+	for _, v := range c.adj[u] {
+		switch c.color[v] {
+		case old:
+			c.same[u]--
+			c.same[v]--
+			c.conflicts--
+		case col:
+			c.same[u]++
+			c.same[v]++
+			c.conflicts++
+		default:
+			continue
+		}
+		c.update(v)
+	}
+	c.update(u)
+}
+
+// update adds u to or removes u from the conflicted nodes as needed.
+func (c *conflictColorer) update(u int) {
+	switch {
+	case c.same[u] > 0 && c.pos[u] < 0:
+		c.mark(u)
+	case c.same[u] == 0 && c.pos[u] >= 0:
+		last := c.conflicted[len(c.conflicted)-1]
+		c.conflicted[c.pos[u]] = last
+		c.pos[last] = c.pos[u]
+		c.conflicted = c.conflicted[:len(c.conflicted)-1]
+		c.pos[u] = -1
+	}
+}
+
+// mark adds u to the conflicted nodes.
+func (c *conflictColorer) mark(u int) {
+	c.pos[u] = len(c.conflicted)
+	c.conflicted = append(c.conflicted, u)
+}
@@ -0,0 +1,115 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestMinConflictColoring(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		g             *simple.UndirectedGraph
+		k             int
+		wantConflicts int
+	}{
+		{name: "empty", g: simple.NewUndirectedGraph(), k: 2, wantConflicts: 0},
+		{name: "even cycle", g: cycleGraph(10), k: 2, wantConflicts: 0},
+		{name: "odd cycle", g: cycleGraph(11), k: 2, wantConflicts: 1},
+		{name: "odd cycle 3 colors", g: cycleGraph(11), k: 3, wantConflicts: 0},
+		{name: "K5 with 4 colors", g: completeGraph(5), k: 4, wantConflicts: 1},
+		{name: "K5 with 1 color", g: completeGraph(5), k: 1, wantConflicts: 10},
+		{name: "grid", g: gridGraph(8, 8), k: 2, wantConflicts: 0},
+		{name: "planted 4-partite", g: plantedColoring(60, 4, 0.3, rand.NewSource(1)), k: 4, wantConflicts: 0},
+	} {
+		colors, conflicts := MinConflictColoring(test.g, test.k, 10000, rand.NewSource(1))
+		if conflicts != test.wantConflicts {
+			t.Errorf("%s: unexpected number of conflicts: got:%d want:%d", test.name, conflicts, test.wantConflicts)
+		}
+		if n := test.g.Nodes().Len(); len(colors) != n {
+			t.Errorf("%s: unexpected number of colored nodes: got:%d want:%d", test.name, len(colors), n)
+		}
+		var got int
+		for _, e := range graph.EdgesOf(test.g.Edges()) {
+			if colors[e.From().ID()] == colors[e.To().ID()] {
+				got++
+			}
+		}
+		if got != conflicts {
+			t.Errorf("%s: reported conflicts do not match coloring: got:%d want:%d", test.name, conflicts, got)
+		}
+		for id, c := range colors {
+			if c < 0 || c >= test.k {
+				t.Errorf("%s: invalid color for node %d: %d", test.name, id, c)
+			}
+		}
+	}
+
+	// The search is reproducible for a given source.
+	g := plantedColoring(40, 3, 0.3, rand.NewSource(2))
+	a, _ := MinConflictColoring(g, 3, 100, rand.NewSource(3))
+	b, _ := MinConflictColoring(g, 3, 100, rand.NewSource(3))
+	if !reflect.DeepEqual(a, b) {
+		t.Error("unexpected difference between colorings with the same source")
+	}
+}
+
+func cycleGraph(n int) *simple.UndirectedGraph {
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < n; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node((i + 1) % n)})
+	}
+	return g
+}
+
+func completeGraph(n int) *simple.UndirectedGraph {
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(j)})
+		}
+	}
+	return g
+}
+
+func gridGraph(r, c int) *simple.UndirectedGraph {
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			u := simple.Node(i*c + j)
+			if j+1 < c {
+				g.SetEdge(simple.Edge{F: u, T: simple.Node(i*c + j + 1)})
+			}
+			if i+1 < r {
+				g.SetEdge(simple.Edge{F: u, T: simple.Node((i+1)*c + j)})
+			}
+		}
+	}
+	return g
+}
+
+// plantedColoring returns a random k-partite graph on n nodes, with each
+// edge between nodes in different parts present with probability p.
+func plantedColoring(n, k int, p float64, src rand.Source) *simple.UndirectedGraph {
+	rnd := rand.New(src)
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < n; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if i%k != j%k && rnd.Float64() < p {
+				g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(j)})
+			}
+		}
+	}
+	return g
+}
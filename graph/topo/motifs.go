@@ -0,0 +1,95 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import "gonum.org/v1/gonum/graph"
+
+// Pattern is a small subgraph pattern counted by CountPattern.
+type Pattern int
+
+const (
+	// PathOf3 is the path on three nodes.
+	PathOf3 Pattern = iota
+	// Triangle is the cycle on three nodes.
+	Triangle
+	// StarOf4 is the star on four nodes, a
+	// center joined to three leaves.
+	StarOf4
+	// Cycle4 is the cycle on four nodes.
+	Cycle4
+)
+
+// CountPattern returns the number of non-induced occurrences of pattern in the
+// undirected graph g, the number of subgraphs of g, not necessarily induced, that
+// are isomorphic to the pattern. So, for example, each triangle of g contains
+// three occurrences of PathOf3 and the complete graph on four nodes contains three
+// occurrences of Cycle4. Self loops are ignored. CountPattern will panic if pattern
+// is not a known Pattern.
+func CountPattern(g graph.Undirected, pattern Pattern) int {
+	switch pattern {
+	case PathOf3:
+		// Each pair of edges at a node forms a path.
+		return sumOverDegrees(g, func(d int) int { return d * (d - 1) / 2 })
+	case Triangle:
+		return CountTriangles(g)
+	case StarOf4:
+		// Each triple of edges at a node forms a star.
+		return sumOverDegrees(g, func(d int) int { return d * (d - 1) * (d - 2) / 6 })
+	case Cycle4:
+		return countCycle4(g)
+	default:
+		panic("topo: unknown pattern")
+	}
+}
+
+// sumOverDegrees returns the sum of fn applied to the degree of each node
+// of g, ignoring self loops.
+func sumOverDegrees(g graph.Undirected, fn func(degree int) int) int {
+	var n int
+	nodes := g.Nodes()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		d := g.From(uid).Len()
+		if g.HasEdgeBetween(uid, uid) {
+			d--
+		}
+		n += fn(d)
+	}
+	return n
+}
+
+// countCycle4 returns the number of 4-cycles in g. Each pair of
+// common neighbors of two distinct nodes closes a 4-cycle with
+// those nodes as a diagonal, so each 4-cycle is counted once for
+// each end of each of its two diagonals.
+func countCycle4(g graph.Undirected) int {
+	var n int
+	common := make(map[int64]int)
+	nodes := g.Nodes()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		for k := range common {
+			delete(common, k)
+		}
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if vid == uid {
+				continue
+			}
+			next := g.From(vid)
+			for next.Next() {
+				wid := next.Node().ID()
+				if wid != uid && wid != vid {
+					common[wid]++
+				}
+			}
+		}
+		for _, c := range common {
+			n += c * (c - 1) / 2
+		}
+	}
+	return n / 4
+}
@@ -0,0 +1,152 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var countPatternTests = []struct {
+	name string
+	g    []intset
+	want map[Pattern]int
+}{
+	{
+		name: "K4",
+		g: []intset{
+			0: linksTo(1, 2, 3),
+			1: linksTo(2, 3),
+			2: linksTo(3),
+			3: nil,
+		},
+		want: map[Pattern]int{PathOf3: 12, Triangle: 4, StarOf4: 4, Cycle4: 3},
+	},
+	{
+		name: "path",
+		g: []intset{
+			0: linksTo(1),
+			1: linksTo(2),
+			2: linksTo(3),
+			3: nil,
+		},
+		want: map[Pattern]int{PathOf3: 2, Triangle: 0, StarOf4: 0, Cycle4: 0},
+	},
+	{
+		name: "square with a pendant",
+		g: []intset{
+			0: linksTo(1, 3),
+			1: linksTo(2),
+			2: linksTo(3),
+			3: linksTo(4),
+			4: nil,
+		},
+		want: map[Pattern]int{PathOf3: 6, Triangle: 0, StarOf4: 1, Cycle4: 1},
+	},
+	{
+		name: "K3,3",
+		g: []intset{
+			0: linksTo(3, 4, 5),
+			1: linksTo(3, 4, 5),
+			2: linksTo(3, 4, 5),
+			3: nil,
+			4: nil,
+			5: nil,
+		},
+		want: map[Pattern]int{PathOf3: 18, Triangle: 0, StarOf4: 6, Cycle4: 9},
+	},
+}
+
+func TestCountPattern(t *testing.T) {
+	for _, test := range countPatternTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		for pattern, want := range test.want {
+			got := CountPattern(g, pattern)
+			if got != want {
+				t.Errorf("%q: unexpected count of pattern %d: got:%d want:%d", test.name, pattern, got, want)
+			}
+		}
+	}
+}
+
+func TestCountPatternRandom(t *testing.T) {
+	const n = 12
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		g := simple.NewUndirectedGraph()
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+		}
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.4 {
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				}
+			}
+		}
+		if got, want := CountPattern(g, Triangle), CountTriangles(g); got != want {
+			t.Errorf("unexpected triangle count: got:%d want:%d", got, want)
+		}
+		if got, want := CountPattern(g, PathOf3), bruteForcePathOf3(g); got != want {
+			t.Errorf("unexpected 3-path count: got:%d want:%d", got, want)
+		}
+		if got, want := CountPattern(g, Cycle4), bruteForceCycle4(g); got != want {
+			t.Errorf("unexpected 4-cycle count: got:%d want:%d", got, want)
+		}
+	}
+}
+
+// bruteForcePathOf3 counts paths u-v-w by their middle node.
+func bruteForcePathOf3(g graph.Undirected) int {
+	var n int
+	nodes := graph.NodesOf(g.Nodes())
+	for _, v := range nodes {
+		for i, u := range nodes {
+			for _, w := range nodes[i+1:] {
+				if u.ID() != v.ID() && w.ID() != v.ID() &&
+					g.HasEdgeBetween(u.ID(), v.ID()) && g.HasEdgeBetween(v.ID(), w.ID()) {
+					n++
+				}
+			}
+		}
+	}
+	return n
+}
+
+// bruteForceCycle4 counts the 4-cycles on each set of four nodes.
+func bruteForceCycle4(g graph.Undirected) int {
+	var n int
+	nodes := graph.NodesOf(g.Nodes())
+	e := func(a, b graph.Node) bool { return g.HasEdgeBetween(a.ID(), b.ID()) }
+	for i := range nodes {
+		for j := i + 1; j < len(nodes); j++ {
+			for k := j + 1; k < len(nodes); k++ {
+				for l := k + 1; l < len(nodes); l++ {
+					a, b, c, d := nodes[i], nodes[j], nodes[k], nodes[l]
+					// The three distinct cyclic orderings of four nodes.
+					for _, o := range [3][4]graph.Node{{a, b, c, d}, {a, b, d, c}, {a, c, b, d}} {
+						if e(o[0], o[1]) && e(o[1], o[2]) && e(o[2], o[3]) && e(o[3], o[0]) {
+							n++
+						}
+					}
+				}
+			}
+		}
+	}
+	return n
+}
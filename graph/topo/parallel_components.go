@@ -0,0 +1,155 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ParallelConnectedComponents returns the connected components of the undirected
+// graph g using the given number of concurrent workers. The nodes of g are split
+// between the workers, which add the edges of their nodes to a single union-find
+// forest shared by all the workers and updated with atomic operations, so memory
+// use does not grow with the number of workers. The components are the same as
+// those returned by ConnectedComponents, although they may be in a different order.
+// Components are ordered by their first node in the iteration order of g.Nodes().
+//
+// The methods of g must be safe for concurrent use. If workers is less than one,
+// a single worker is used.
+func ParallelConnectedComponents(g graph.Undirected, workers int) [][]graph.Node {
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) == 0 {
+		return nil
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(nodes) {
+		workers = len(nodes)
+	}
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	forest := newConcurrentUnionFind(len(nodes))
+	var wg sync.WaitGroup
+	chunk := (len(nodes) + workers - 1) / workers
+	for lo := 0; lo < len(nodes); lo += chunk {
+		hi := lo + chunk
+		if hi > len(nodes) {
+			hi = len(nodes)
+		}
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			for i := lo; i < hi; i++ {
+				to := g.From(nodes[i].ID())
+				for to.Next() {
+					// Each edge is seen from both
+					// ends, so add it only once.
+					if j := indexOf[to.Node().ID()]; j < i {
+						forest.union(i, j)
+					}
+				}
+			}
+		}(lo, hi)
+	}
+	wg.Wait()
+
+	var cc [][]graph.Node
+	component := make(map[int]int)
+	for i, n := range nodes {
+		r := forest.find(i)
+		c, ok := component[r]
+		if !ok {
+			c = len(cc)
+			component[r] = c
+			cc = append(cc, nil)
+		}
+		cc[c] = append(cc[c], n)
+	}
+	return cc
+}
+
+// concurrentUnionFind is a disjoint set forest over dense indices that
+// is safe for concurrent use. Roots are always linked below roots with
+// a lower index, so the forest cannot form a cycle when concurrently
+// updated.
+type concurrentUnionFind []int64
+
+func newConcurrentUnionFind(n int) concurrentUnionFind {
+	f := make(concurrentUnionFind, n)
+	for i := range f {
+		f[i] = int64(i)
+	}
+	return f
+}
+
+// find returns the root of the set holding i, halving the path as it goes.
+func (f concurrentUnionFind) find(i int) int {
+	for {
+		p := atomic.LoadInt64(&f[i])
+		if p == int64(i) {
+			return i
+		}
+		gp := atomic.LoadInt64(&f[p])
+		atomic.CompareAndSwapInt64(&f[i], p, gp)
+		i = int(gp)
+	}
+}
+
+// union merges the sets holding i and j.
+func (f concurrentUnionFind) union(i, j int) {
+	for {
+		i, j = f.find(i), f.find(j)
+		if i == j {
+			return
+		}
+		if j < i {
+			i, j = j, i
+		}
+		// The link fails if another worker has
+		// linked j since it was found as a root.
+		if atomic.CompareAndSwapInt64(&f[j], int64(j), int64(i)) {
+			return
+		}
+	}
+}
+
+// unionFind is a disjoint set forest over dense indices.
+type unionFind []int
+
+func newUnionFind(n int) unionFind {
+	f := make(unionFind, n)
+	for i := range f {
+		f[i] = i
+	}
+	return f
+}
+
+// find returns the root of the set holding i, halving the path as it goes.
+func (f unionFind) find(i int) int {
+	for f[i] != i {
+		f[i] = f[f[i]]
+		i = f[i]
+	}
+	return i
+}
+
+// union merges the sets holding i and j.
+func (f unionFind) union(i, j int) {
+	i, j = f.find(i), f.find(j)
+	if i == j {
+		return
+	}
+	if j < i {
+		i, j = j, i
+	}
+	f[j] = i
+}
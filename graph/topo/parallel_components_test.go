@@ -0,0 +1,114 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"reflect"
+	"runtime"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestParallelConnectedComponents(t *testing.T) {
+	for i, test := range connectedComponentTests {
+		g := simple.NewUndirectedGraph()
+
+		for u, e := range test.g {
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				if g.Node(int64(v)) == nil {
+					g.AddNode(simple.Node(v))
+				}
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		for _, workers := range []int{0, 1, 2, 3, 8, 100} {
+			got := componentIDs(ParallelConnectedComponents(g, workers))
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("unexpected connected components for test %d with %d workers:\ngot: %v\nwant:%v", i, workers, got, test.want)
+			}
+		}
+	}
+
+	for _, p := range []float64{0.001, 0.002, 0.005} {
+		g := simple.NewUndirectedGraph()
+		gen.Gnp(g, 1000, p, rand.NewSource(1))
+		want := componentIDs(ConnectedComponents(g))
+		for _, workers := range []int{1, 4, 7} {
+			got := componentIDs(ParallelConnectedComponents(g, workers))
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected connected components for G(1000, %v) with %d workers", p, workers)
+			}
+		}
+	}
+}
+
+func componentIDs(cc [][]graph.Node) [][]int64 {
+	got := make([][]int64, len(cc))
+	for j, c := range cc {
+		ids := make([]int64, len(c))
+		for k, n := range c {
+			ids[k] = n.ID()
+		}
+		sort.Sort(ordered.Int64s(ids))
+		got[j] = ids
+	}
+	sort.Sort(ordered.BySliceValues(got))
+	return got
+}
+
+var gnpUndirected_10000_sparse = gnpUndirected(10000, 0.0002)
+
+func gnpUndirected(n int, p float64) graph.Undirected {
+	g := simple.NewUndirectedGraph()
+	gen.Gnp(g, n, p, nil)
+	return g
+}
+
+func BenchmarkConnectedComponentsGnp_10000_sparse(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ConnectedComponents(gnpUndirected_10000_sparse)
+	}
+}
+
+func benchmarkParallelConnectedComponents(b *testing.B, g graph.Undirected, workers int) {
+	for i := 0; i < b.N; i++ {
+		ParallelConnectedComponents(g, workers)
+	}
+}
+
+func BenchmarkParallelConnectedComponentsGnp_10000_sparse_1(b *testing.B) {
+	benchmarkParallelConnectedComponents(b, gnpUndirected_10000_sparse, 1)
+}
+func BenchmarkParallelConnectedComponentsGnp_10000_sparse_4(b *testing.B) {
+	benchmarkParallelConnectedComponents(b, gnpUndirected_10000_sparse, 4)
+}
+func BenchmarkParallelConnectedComponentsGnp_10000_sparse_8(b *testing.B) {
+	benchmarkParallelConnectedComponents(b, gnpUndirected_10000_sparse, 8)
+}
+
+var gnpUndirected_100000_sparse = gnpUndirected(100000, 0.00002)
+
+func BenchmarkConnectedComponentsGnp_100000_sparse(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ConnectedComponents(gnpUndirected_100000_sparse)
+	}
+}
+
+func BenchmarkParallelConnectedComponentsGnp_100000_sparse_1(b *testing.B) {
+	benchmarkParallelConnectedComponents(b, gnpUndirected_100000_sparse, 1)
+}
+func BenchmarkParallelConnectedComponentsGnp_100000_sparse_GOMAXPROCS(b *testing.B) {
+	benchmarkParallelConnectedComponents(b, gnpUndirected_100000_sparse, runtime.GOMAXPROCS(0))
+}
@@ -0,0 +1,344 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"gonum.org/v1/gonum/graph"
+)
+
+// IsPlanar returns whether the undirected graph g is planar, that is, whether it
+// can be drawn in the plane without edge crossings.
+//
+// Graphs with more than 3V-6 edges are rejected immediately. Otherwise each
+// biconnected component of g is tested with the Demoucron, Malgrange and Pertuiset
+// algorithm, which embeds a cycle of the component and then repeatedly embeds a
+// path through a face until either all edges are embedded, or a fragment of the
+// remaining graph has no face it can be embedded in. The fragments that cannot be
+// embedded correspond to Kuratowski subgraphs, subdivisions of K5 or K3,3. The
+// running time is O(V²E), so IsPlanar is suitable for small to moderate sized
+// graphs of up to a few thousand nodes.
+//
+// See Demoucron, Malgrange and Pertuiset, "Graphes planaires: reconnaissance et
+// construction de représentations planaires topologiques." Rev. Française
+// Recherche Opérationnelle 8 (1964) pp 33-47, and chapter 9 of Gibbons,
+// "Algorithmic Graph Theory", Cambridge University Press (1985).
+func IsPlanar(g graph.Undirected) bool {
+	nodes := graph.NodesOf(g.Nodes())
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	adj := make([][]int, len(nodes))
+	var edges int
+	for i, u := range nodes {
+		to := g.From(u.ID())
+		for to.Next() {
+			j := indexOf[to.Node().ID()]
+			if j == i {
+				continue
+			}
+			adj[i] = append(adj[i], j)
+			if i < j {
+				edges++
+			}
+		}
+	}
+	if len(nodes) >= 3 && edges > 3*len(nodes)-6 {
+		return false
+	}
+
+	for _, block := range biconnectedBlocks(adj) {
+		if !isPlanarBlock(adj, block) {
+			return false
+		}
+	}
+	return true
+}
+
+// biconnectedBlocks returns the edge sets of the biconnected components
+// of the graph described by adj, using the Hopcroft and Tarjan algorithm.
+func biconnectedBlocks(adj [][]int) [][][2]int {
+	var (
+		index  = make([]int, len(adj))
+		low    = make([]int, len(adj))
+		stack  [][2]int
+		blocks [][][2]int
+		next   = 1
+	)
+	var visit func(u, parent int)
+	visit = func(u, parent int) {
+		index[u] = next
+		low[u] = next
+		next++
+		for _, v := range adj[u] {
+			if v == parent {
+				continue
+			}
+			switch {
+			case index[v] == 0:
+				stack = append(stack, [2]int{u, v})
+				visit(v, u)
+				if low[v] < low[u] {
+					low[u] = low[v]
+				}
+				if low[v] >= index[u] {
+					var block [][2]int
+					for {
+						e := stack[len(stack)-1]
+						stack = stack[:len(stack)-1]
+						block = append(block, e)
+						if e == [2]int{u, v} {
+							break
+						}
+					}
+					blocks = append(blocks, block)
+				}
+			case index[v] < index[u]:
+				stack = append(stack, [2]int{u, v})
+				if index[v] < low[u] {
+					low[u] = index[v]
+				}
+			}
+		}
+	}
+	for u := range adj {
+		if index[u] == 0 {
+			visit(u, -1)
+		}
+	}
+	return blocks
+}
+
+// isPlanarBlock returns whether the biconnected component with the given
+// edges is planar using the Demoucron, Malgrange and Pertuiset algorithm.
+func isPlanarBlock(all [][]int, block [][2]int) bool {
+	if len(block) < 3 {
+		// A single edge.
+		return true
+	}
+
+	// Restrict the adjacency to the block.
+	adj := make(map[int][]int)
+	for _, e := range block {
+		adj[e[0]] = append(adj[e[0]], e[1])
+		adj[e[1]] = append(adj[e[1]], e[0])
+	}
+	if len(block) > 3*len(adj)-6 {
+		return false
+	}
+
+	embedded := make(map[[2]int]bool)
+	inH := make(map[int]bool)
+	embed := func(p []int) {
+		for i, u := range p {
+			inH[u] = true
+			if i > 0 {
+				embedded[edgeKey(p[i-1], u)] = true
+			}
+		}
+	}
+
+	// Embed an initial cycle through the first edge.
+	u, v := block[0][0], block[0][1]
+	p := blockPath(adj, u, func(w int) bool { return w == v }, func(a, b int) bool {
+		return edgeKey(a, b) == edgeKey(u, v)
+	})
+	embed(p)
+	embedded[edgeKey(u, v)] = true
+	faces := [][]int{p, reverse(append([]int(nil), p...))}
+
+	for len(embedded) < len(block) {
+		frags := fragments(adj, inH, embedded)
+
+		// Find the fragment with the fewest admissible faces.
+		best, bestFace, bestCount := -1, -1, 0
+		for i, f := range frags {
+			var count, face int
+			for j, fc := range faces {
+				if containsAll(fc, f.attachments) {
+					if count == 0 {
+						face = j
+					}
+					count++
+				}
+			}
+			if count == 0 {
+				return false
+			}
+			if best < 0 || count < bestCount {
+				best, bestFace, bestCount = i, face, count
+			}
+			if count == 1 {
+				break
+			}
+		}
+
+		// Embed a path of the fragment between two
+		// attachments, splitting the chosen face.
+		f := frags[best]
+		p := f.attachments
+		if f.nodes != nil {
+			a := f.attachments[0]
+			p = blockPath(adj, a, func(w int) bool { return w != a && inH[w] }, func(x, y int) bool {
+				// Only traverse the unembedded nodes of the fragment
+				// from a to reach another of its attachments.
+				if inH[y] {
+					return x == a || !f.attachment(y)
+				}
+				return !f.nodes[y]
+			})
+		}
+		face := faces[bestFace]
+		i, j := indexIn(face, p[0]), indexIn(face, p[len(p)-1])
+		interior := p[1 : len(p)-1]
+		var f1, f2 []int
+		for k := i; ; k = (k + 1) % len(face) {
+			f1 = append(f1, face[k])
+			if k == j {
+				break
+			}
+		}
+		for k := len(interior) - 1; k >= 0; k-- {
+			f1 = append(f1, interior[k])
+		}
+		for k := j; ; k = (k + 1) % len(face) {
+			f2 = append(f2, face[k])
+			if k == i {
+				break
+			}
+		}
+		f2 = append(f2, interior...)
+		faces[bestFace] = f1
+		faces = append(faces, f2)
+		embed(p)
+	}
+	return true
+}
+
+// fragment is a bridge of a partially embedded graph.
+type fragment struct {
+	// nodes is the set of unembedded nodes
+	// of the fragment, empty for a chord.
+	nodes map[int]bool
+
+	// attachments are the embedded nodes
+	// of the fragment.
+	attachments []int
+}
+
+func (f fragment) attachment(u int) bool {
+	for _, a := range f.attachments {
+		if a == u {
+			return true
+		}
+	}
+	return false
+}
+
+// fragments returns the fragments of the graph described by adj relative
+// to the embedded subgraph with nodes inH and edges embedded.
+func fragments(adj map[int][]int, inH map[int]bool, embedded map[[2]int]bool) []fragment {
+	var frags []fragment
+	seen := make(map[int]bool)
+	for u, nbrs := range adj {
+		if inH[u] {
+			// Chords between embedded nodes.
+			for _, v := range nbrs {
+				if u < v && inH[v] && !embedded[edgeKey(u, v)] {
+					frags = append(frags, fragment{attachments: []int{u, v}})
+				}
+			}
+			continue
+		}
+		if seen[u] {
+			continue
+		}
+
+		// Components of the unembedded nodes together
+		// with their edges to the embedded nodes.
+		f := fragment{nodes: map[int]bool{u: true}}
+		attached := make(map[int]bool)
+		seen[u] = true
+		queue := []int{u}
+		for len(queue) != 0 {
+			w := queue[0]
+			queue = queue[1:]
+			for _, x := range adj[w] {
+				switch {
+				case inH[x]:
+					if !attached[x] {
+						attached[x] = true
+						f.attachments = append(f.attachments, x)
+					}
+				case !seen[x]:
+					seen[x] = true
+					f.nodes[x] = true
+					queue = append(queue, x)
+				}
+			}
+		}
+		frags = append(frags, f)
+	}
+	return frags
+}
+
+// blockPath returns a shortest path in adj from u to a node satisfying isEnd,
+// not traversing edges for which skip returns true.
+func blockPath(adj map[int][]int, u int, isEnd func(int) bool, skip func(a, b int) bool) []int {
+	prev := map[int]int{u: u}
+	queue := []int{u}
+	for len(queue) != 0 {
+		w := queue[0]
+		queue = queue[1:]
+		for _, x := range adj[w] {
+			if _, ok := prev[x]; ok || skip(w, x) {
+				continue
+			}
+			prev[x] = w
+			if isEnd(x) {
+				p := []int{x}
+				for x != u {
+					x = prev[x]
+					p = append(p, x)
+				}
+				return reverse(p)
+			}
+			queue = append(queue, x)
+		}
+	}
+	panic("topo: no path in biconnected component")
+}
+
+func edgeKey(u, v int) [2]int {
+	if v < u {
+		u, v = v, u
+	}
+	return [2]int{u, v}
+}
+
+func containsAll(face, nodes []int) bool {
+	for _, n := range nodes {
+		if indexIn(face, n) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func indexIn(s []int, v int) int {
+	for i, e := range s {
+		if e == v {
+			return i
+		}
+	}
+	return -1
+}
+
+func reverse(s []int) []int {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+	return s
+}
@@ -0,0 +1,220 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var isPlanarTests = []struct {
+	name string
+	g    []intset
+	want bool
+}{
+	{
+		name: "empty",
+		g:    nil,
+		want: true,
+	},
+	{
+		name: "tree",
+		g: []intset{
+			0: linksTo(1, 2, 3),
+			1: linksTo(4, 5),
+			2: nil,
+			3: linksTo(6),
+			4: nil,
+			5: nil,
+			6: nil,
+		},
+		want: true,
+	},
+	{
+		name: "K4",
+		g: []intset{
+			0: linksTo(1, 2, 3),
+			1: linksTo(2, 3),
+			2: linksTo(3),
+			3: nil,
+		},
+		want: true,
+	},
+	{
+		name: "K5",
+		g: []intset{
+			0: linksTo(1, 2, 3, 4),
+			1: linksTo(2, 3, 4),
+			2: linksTo(3, 4),
+			3: linksTo(4),
+			4: nil,
+		},
+		want: false,
+	},
+	{
+		name: "K5 minus an edge",
+		g: []intset{
+			0: linksTo(1, 2, 3, 4),
+			1: linksTo(2, 3, 4),
+			2: linksTo(3, 4),
+			3: nil,
+			4: nil,
+		},
+		want: true,
+	},
+	{
+		name: "K3,3",
+		g: []intset{
+			0: linksTo(3, 4, 5),
+			1: linksTo(3, 4, 5),
+			2: linksTo(3, 4, 5),
+			3: nil,
+			4: nil,
+			5: nil,
+		},
+		want: false,
+	},
+	{
+		// K3,3 with the edges 0-3 and 1-4 subdivided
+		// passes the edge count test.
+		name: "subdivided K3,3",
+		g: []intset{
+			0: linksTo(6, 4, 5),
+			1: linksTo(3, 7, 5),
+			2: linksTo(3, 4, 5),
+			3: linksTo(6),
+			4: linksTo(7),
+			5: nil,
+			6: nil,
+			7: nil,
+		},
+		want: false,
+	},
+	{
+		name: "subdivided K5",
+		g: []intset{
+			0: linksTo(5, 2, 3, 4),
+			1: linksTo(2, 6, 4),
+			2: linksTo(3, 7),
+			3: linksTo(4, 6),
+			4: nil,
+			5: linksTo(1),
+			6: nil,
+			7: linksTo(4),
+		},
+		want: false,
+	},
+	{
+		name: "petersen",
+		g: []intset{
+			0: linksTo(1, 4, 5),
+			1: linksTo(2, 6),
+			2: linksTo(3, 7),
+			3: linksTo(4, 8),
+			4: linksTo(9),
+			5: linksTo(7, 8),
+			6: linksTo(8, 9),
+			7: linksTo(9),
+			8: nil,
+			9: nil,
+		},
+		want: false,
+	},
+	{
+		name: "cube",
+		g: []intset{
+			0: linksTo(1, 2, 4),
+			1: linksTo(3, 5),
+			2: linksTo(3, 6),
+			3: linksTo(7),
+			4: linksTo(5, 6),
+			5: linksTo(7),
+			6: linksTo(7),
+			7: nil,
+		},
+		want: true,
+	},
+	{
+		name: "octahedron",
+		g: []intset{
+			0: linksTo(2, 3, 4, 5),
+			1: linksTo(2, 3, 4, 5),
+			2: linksTo(4, 5),
+			3: linksTo(4, 5),
+			4: nil,
+			5: nil,
+		},
+		want: true,
+	},
+	{
+		name: "wheel",
+		g: []intset{
+			0: linksTo(1, 2, 3, 4, 5, 6),
+			1: linksTo(2, 6),
+			2: linksTo(3),
+			3: linksTo(4),
+			4: linksTo(5),
+			5: linksTo(6),
+			6: nil,
+		},
+		want: true,
+	},
+	{
+		name: "K4 blocks joined at a cut node",
+		g: []intset{
+			0: linksTo(1, 2, 3),
+			1: linksTo(2, 3),
+			2: linksTo(3),
+			3: linksTo(4, 5, 6),
+			4: linksTo(5, 6),
+			5: linksTo(6),
+			6: nil,
+		},
+		want: true,
+	},
+	{
+		name: "K5 component with a planar component",
+		g: []intset{
+			0:  linksTo(1, 2, 3, 4),
+			1:  linksTo(2, 3, 4),
+			2:  linksTo(3, 4),
+			3:  linksTo(4),
+			4:  nil,
+			5:  linksTo(6, 7, 8, 9, 10, 11),
+			6:  linksTo(7, 8, 9, 10, 11),
+			7:  linksTo(8),
+			8:  linksTo(9),
+			9:  linksTo(10),
+			10: linksTo(11),
+			11: nil,
+		},
+		want: false,
+	},
+	{
+		name: "batagelj-zaversnik",
+		g:    batageljZaversnikGraph,
+		want: true,
+	},
+}
+
+func TestIsPlanar(t *testing.T) {
+	for _, test := range isPlanarTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		got := IsPlanar(g)
+		if got != test.want {
+			t.Errorf("%q: unexpected planarity: got:%t want:%t", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// SimplePathsUpTo returns all the simple paths from s to t in g with at most maxLen
+// edges. The paths are found by a backtracking depth first search from s that visits
+// the neighbors of each node in order of node ID, and are returned in the order they
+// are found. If s and t are the same node in g, the single path of length zero holding
+// s is returned.
+//
+// The number of simple paths may grow exponentially with maxLen, so SimplePathsUpTo
+// should only be used with small bounds or on small graphs.
+func SimplePathsUpTo(s, t graph.Node, maxLen int, g graph.Directed) [][]graph.Node {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil || maxLen < 0 {
+		return nil
+	}
+	tid := t.ID()
+
+	var paths [][]graph.Node
+	path := []graph.Node{s}
+	onPath := map[int64]bool{s.ID(): true}
+	var walk func(u graph.Node)
+	walk = func(u graph.Node) {
+		if u.ID() == tid {
+			paths = append(paths, append([]graph.Node(nil), path...))
+			return
+		}
+		if len(path) > maxLen {
+			return
+		}
+		to := graph.NodesOf(g.From(u.ID()))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			if onPath[v.ID()] {
+				continue
+			}
+			onPath[v.ID()] = true
+			path = append(path, v)
+			walk(v)
+			path = path[:len(path)-1]
+			onPath[v.ID()] = false
+		}
+	}
+	walk(s)
+	return paths
+}
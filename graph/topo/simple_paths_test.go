@@ -0,0 +1,56 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var simplePathsUpToTests = []struct {
+	s, t   int64
+	maxLen int
+	want   [][]int64
+}{
+	{s: 0, t: 4, maxLen: 0, want: nil},
+	{s: 0, t: 4, maxLen: 1, want: [][]int64{{0, 4}}},
+	{s: 0, t: 4, maxLen: 2, want: [][]int64{{0, 1, 4}, {0, 4}}},
+	{s: 0, t: 4, maxLen: 3, want: [][]int64{{0, 1, 2, 4}, {0, 1, 4}, {0, 3, 1, 4}, {0, 3, 2, 4}, {0, 4}}},
+	{s: 0, t: 4, maxLen: 10, want: [][]int64{{0, 1, 2, 4}, {0, 1, 4}, {0, 3, 1, 2, 4}, {0, 3, 1, 4}, {0, 3, 2, 4}, {0, 4}}},
+	{s: 2, t: 2, maxLen: 3, want: [][]int64{{2}}},
+	{s: 4, t: 0, maxLen: 10, want: nil},
+	{s: 0, t: 5, maxLen: 10, want: nil},
+}
+
+func TestSimplePathsUpTo(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{
+		{0, 1}, {0, 3}, {0, 4},
+		{1, 2}, {1, 4},
+		{2, 4},
+		{3, 1}, {3, 2},
+		{2, 3}, // Creates cycles that simple paths must avoid.
+	} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+
+	for _, test := range simplePathsUpToTests {
+		paths := SimplePathsUpTo(simple.Node(test.s), simple.Node(test.t), test.maxLen, g)
+		var got [][]int64
+		for _, p := range paths {
+			var ids []int64
+			for _, n := range p {
+				ids = append(ids, n.ID())
+			}
+			got = append(got, ids)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected paths from %d to %d with at most %d edges:\ngot: %v\nwant:%v",
+				test.s, test.t, test.maxLen, got, test.want)
+		}
+	}
+}
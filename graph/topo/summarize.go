@@ -0,0 +1,188 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// SummarizeGraph returns a lossy summary of g with about targetNodes nodes and the
+// mapping from the node IDs of g to the IDs of the summary nodes. Each summary node,
+// a supernode, stands for a group of nodes of g. Groups are formed by greedily merging
+// the pair of supernodes whose neighborhoods are the most similar, measured by the
+// Jaccard index of their closed neighborhoods, the sets of neighboring supernodes
+// including the supernodes themselves, until targetNodes supernodes remain. Only
+// pairs of supernodes that are adjacent or share a neighbor are merged, so the
+// summary may have more than targetNodes nodes if no such pair remains. Ties are
+// broken in favor of the pair with the fewest nodes in total, and then the pair
+// with the lowest IDs.
+//
+// Supernodes are given IDs from zero in order of the lowest node ID in their group.
+// The weight of the edge from one supernode to another is the total weight of the
+// edges of g from nodes in the first group to nodes in the second, using the edge
+// weights of g if it is a graph.Weighted and unit weights otherwise. Edges within a
+// group are not represented. If g is undirected, summary edges are held in both
+// directions. Since only edges within groups are lost, nodes connected in g are in
+// supernodes connected in the summary. Edges in both directions are considered when
+// comparing the neighborhoods of nodes of a directed graph.
+//
+// SummarizeGraph will panic if targetNodes is less than one.
+func SummarizeGraph(g graph.Graph, targetNodes int) (summary *simple.WeightedDirectedGraph, supernode map[int64]int64) {
+	if targetNodes < 1 {
+		panic("topo: invalid target node count")
+	}
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	// adj holds the number of edges in either direction
+	// between each active group and its neighbors, and
+	// parent holds the group each node was merged into.
+	adj := make([]map[int]int, len(nodes))
+	parent := make([]int, len(nodes))
+	size := make([]int, len(nodes))
+	for i := range nodes {
+		parent[i] = i
+		adj[i] = make(map[int]int)
+		size[i] = 1
+	}
+	for i, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			j := indexOf[to.Node().ID()]
+			if i == j {
+				continue
+			}
+			adj[i][j]++
+			adj[j][i]++
+		}
+	}
+
+	active := len(nodes)
+	for active > targetNodes {
+		a, b := -1, -1
+		best := math.Inf(-1)
+		consider := func(x, y int) {
+			if y < x {
+				x, y = y, x
+			}
+			s := groupSimilarity(adj[x], adj[y], x, y)
+			switch {
+			case s < best:
+				return
+			case s == best:
+				if n := size[x] + size[y]; n > size[a]+size[b] || (n == size[a]+size[b] && (x > a || (x == a && y > b))) {
+					return
+				}
+			}
+			a, b, best = x, y, s
+		}
+		for x := range nodes {
+			if parent[x] != x {
+				continue
+			}
+			for y := range adj[x] {
+				consider(x, y)
+				for z := range adj[y] {
+					if z != x {
+						consider(x, z)
+					}
+				}
+			}
+		}
+		if a < 0 {
+			break
+		}
+
+		// Merge group b into group a.
+		for x, c := range adj[b] {
+			delete(adj[x], b)
+			if x == a {
+				continue
+			}
+			adj[a][x] += c
+			adj[x][a] += c
+		}
+		adj[b] = nil
+		parent[b] = a
+		size[a] += size[b]
+		active--
+	}
+
+	find := func(i int) int {
+		for parent[i] != i {
+			i = parent[i]
+		}
+		return i
+	}
+	summary = simple.NewWeightedDirectedGraph(0, 0)
+	supernode = make(map[int64]int64, len(nodes))
+	label := make(map[int]int64)
+	for i, n := range nodes {
+		r := find(i)
+		id, ok := label[r]
+		if !ok {
+			id = int64(len(label))
+			label[r] = id
+			summary.AddNode(simple.Node(id))
+		}
+		supernode[n.ID()] = id
+	}
+
+	wg, isWeighted := g.(graph.Weighted)
+	weight := make(map[[2]int64]float64)
+	for _, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			x, y := supernode[uid], supernode[vid]
+			if x == y {
+				continue
+			}
+			w := 1.0
+			if isWeighted {
+				w, _ = wg.Weight(uid, vid)
+			}
+			weight[[2]int64{x, y}] += w
+		}
+	}
+	for e, w := range weight {
+		summary.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(e[0]), T: simple.Node(e[1]), W: w})
+	}
+	return summary, supernode
+}
+
+// groupSimilarity returns the Jaccard index of the closed neighborhoods of
+// the groups x and y, with neighbors a and b. The closed neighborhood of a
+// group holds its neighbors and the group itself.
+func groupSimilarity(a, b map[int]int, x, y int) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+		x, y = y, x
+	}
+	// The closed neighborhoods share x if y
+	// neighbors x, and y if x neighbors y.
+	var common int
+	if _, ok := b[x]; ok {
+		common += 2
+	}
+	for z := range a {
+		if _, ok := b[z]; ok {
+			common++
+		}
+	}
+	union := len(a) + len(b) + 2 - common
+	return float64(common) / float64(union)
+}
@@ -0,0 +1,118 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestSummarizeGraphCliques(t *testing.T) {
+	// Three K5 joined in a ring by single edges.
+	const k = 5
+	g := simple.NewUndirectedGraph()
+	for c := 0; c < 3; c++ {
+		for i := 0; i < k; i++ {
+			for j := i + 1; j < k; j++ {
+				g.SetEdge(simple.Edge{F: simple.Node(c*k + i), T: simple.Node(c*k + j)})
+			}
+		}
+		g.SetEdge(simple.Edge{F: simple.Node(c*k + k - 1), T: simple.Node(((c + 1) % 3) * k)})
+	}
+
+	summary, supernode := SummarizeGraph(g, 3)
+	if n := summary.Nodes().Len(); n != 3 {
+		t.Fatalf("unexpected number of supernodes: got:%d want:3", n)
+	}
+	for id, s := range supernode {
+		if want := id / k; s != want {
+			t.Errorf("unexpected supernode for node %d: got:%d want:%d", id, s, want)
+		}
+	}
+	for x := int64(0); x < 3; x++ {
+		for y := int64(0); y < 3; y++ {
+			if x == y {
+				continue
+			}
+			if w, ok := summary.Weight(x, y); !ok || w != 1 {
+				t.Errorf("unexpected weight for edge %d->%d: got:%v,%t want:1,true", x, y, w, ok)
+			}
+		}
+	}
+
+	summary, supernode = SummarizeGraph(g, 20)
+	if n := summary.Nodes().Len(); n != g.Nodes().Len() {
+		t.Errorf("unexpected number of supernodes without merging: got:%d want:%d", n, g.Nodes().Len())
+	}
+	if len(supernode) != g.Nodes().Len() {
+		t.Errorf("unexpected supernode mapping size: got:%d want:%d", len(supernode), g.Nodes().Len())
+	}
+}
+
+func TestSummarizeGraphRandom(t *testing.T) {
+	const n = 60
+	rnd := rand.New(rand.NewSource(1))
+	for _, target := range []int{1, 5, 10, 30} {
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		// A random tree with extra edges is connected.
+		for i := 1; i < n; i++ {
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(rnd.Intn(i)), T: simple.Node(i), W: 2})
+		}
+		for k := 0; k < n; k++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u != v {
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: 2})
+			}
+		}
+
+		summary, supernode := SummarizeGraph(g, target)
+		if got := summary.Nodes().Len(); got != target {
+			t.Errorf("unexpected number of supernodes for target %d: got:%d", target, got)
+		}
+		if cc := len(ConnectedComponents(undirectedOf(summary))); cc != 1 {
+			t.Errorf("summary for target %d not connected: %d components", target, cc)
+		}
+
+		// The summary edge weights account for every
+		// edge of g that joins distinct supernodes.
+		var want, got float64
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			for _, v := range graph.NodesOf(g.From(u.ID())) {
+				if supernode[u.ID()] != supernode[v.ID()] {
+					w, _ := g.Weight(u.ID(), v.ID())
+					want += w
+				}
+			}
+		}
+		edges := summary.WeightedEdges()
+		for edges.Next() {
+			got += edges.WeightedEdge().Weight()
+		}
+		if got != want {
+			t.Errorf("unexpected total summary edge weight for target %d: got:%v want:%v", target, got, want)
+		}
+	}
+}
+
+// undirectedOf returns an undirected copy of g.
+func undirectedOf(g graph.Directed) graph.Undirected {
+	u := simple.NewUndirectedGraph()
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		u.AddNode(n)
+	}
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		for _, v := range graph.NodesOf(g.From(n.ID())) {
+			u.SetEdge(simple.Edge{F: n, T: v})
+		}
+	}
+	return u
+}
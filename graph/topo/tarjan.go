@@ -93,6 +93,15 @@ func TarjanSCC(g graph.Directed) [][]graph.Node {
 	return tarjanSCCstabilized(g, nil)
 }
 
+// ReachabilitySummary returns whether the directed graph g is strongly connected,
+// so that every node can reach every other node, and the number of strongly connected
+// components of g. A graph with no nodes has no components and is not strongly
+// connected.
+func ReachabilitySummary(g graph.Directed) (stronglyConnected bool, components int) {
+	components = len(TarjanSCC(g))
+	return components == 1, components
+}
+
 func tarjanSCCstabilized(g graph.Directed, order func([]graph.Node)) [][]graph.Node {
 	nodes := graph.NodesOf(g.Nodes())
 	var succ func(id int64) []graph.Node
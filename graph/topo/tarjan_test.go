@@ -191,6 +191,35 @@ func TestTarjanSCC(t *testing.T) {
 	}
 }
 
+func TestReachabilitySummary(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		g         []intset
+		connected bool
+		n         int
+	}{
+		{name: "empty", g: nil, connected: false, n: 0},
+		{name: "single node", g: []intset{nil}, connected: true, n: 1},
+		{name: "cycle", g: []intset{0: linksTo(1), 1: linksTo(2), 2: linksTo(3), 3: linksTo(0)}, connected: true, n: 1},
+		{name: "dag", g: []intset{0: linksTo(1, 2), 1: linksTo(3), 2: linksTo(3), 3: nil}, connected: false, n: 4},
+		{name: "two cycles", g: []intset{0: linksTo(1), 1: linksTo(0, 2), 2: linksTo(3), 3: linksTo(2)}, connected: false, n: 2},
+	} {
+		g := simple.NewDirectedGraph()
+		for u, e := range test.g {
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		connected, n := ReachabilitySummary(g)
+		if connected != test.connected || n != test.n {
+			t.Errorf("%s: unexpected result: got:(%t, %d) want:(%t, %d)", test.name, connected, n, test.connected, test.n)
+		}
+	}
+}
+
 var stabilizedSortTests = []struct {
 	g []intset
 
@@ -0,0 +1,73 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// TransitiveReduction builds the transitive reduction of the directed acyclic
+// graph g in dst. The transitive reduction holds all the nodes of g and the
+// minimal subset of the edges of g that preserves the reachability relation of
+// g; an edge from u to v is removed when v can be reached from u by another path.
+// The dst graph is not cleared.
+//
+// The transitive reduction is only unique for acyclic graphs. If g has a cycle,
+// an Unorderable error listing the cyclic components of g is returned and dst
+// is not altered. A self loop is treated as a cycle.
+func TransitiveReduction(dst Builder, g graph.Directed) error {
+	sorted, err := Sort(g)
+	if err != nil {
+		return err
+	}
+	for _, n := range sorted {
+		if id := n.ID(); g.HasEdgeFromTo(id, id) {
+			return Unorderable{{n}}
+		}
+	}
+
+	pos := make(map[int64]int, len(sorted))
+	for i, n := range sorted {
+		pos[n.ID()] = i
+	}
+
+	// reach holds the set of positions reachable
+	// from the node at each position as a bit set.
+	words := (len(sorted) + 63) / 64
+	reach := make([][]uint64, len(sorted))
+	keep := make([][]graph.Node, len(sorted))
+	for i := len(sorted) - 1; i >= 0; i-- {
+		reach[i] = make([]uint64, words)
+		children := graph.NodesOf(g.From(sorted[i].ID()))
+		sort.Slice(children, func(a, b int) bool { return pos[children[a].ID()] < pos[children[b].ID()] })
+
+		// Visiting children in topological order ensures
+		// that any alternative path to a child passes
+		// through a child that has already been visited.
+		for _, v := range children {
+			j := pos[v.ID()]
+			if reach[i][j/64]&(1<<uint(j%64)) != 0 {
+				continue
+			}
+			keep[i] = append(keep[i], v)
+			reach[i][j/64] |= 1 << uint(j%64)
+			for w, bits := range reach[j] {
+				reach[i][w] |= bits
+			}
+		}
+	}
+
+	for _, n := range sorted {
+		dst.AddNode(n)
+	}
+	for i, u := range sorted {
+		for _, v := range keep[i] {
+			dst.SetEdge(g.Edge(u.ID(), v.ID()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var transitiveReductionTests = []struct {
+	name  string
+	edges [][2]int64
+	want  [][2]int64
+	cycle bool
+}{
+	{
+		name: "complete dag",
+		edges: func() [][2]int64 {
+			var e [][2]int64
+			for u := int64(0); u < 6; u++ {
+				for v := u + 1; v < 6; v++ {
+					e = append(e, [2]int64{u, v})
+				}
+			}
+			return e
+		}(),
+		want: [][2]int64{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}},
+	},
+	{
+		name:  "diamond",
+		edges: [][2]int64{{0, 1}, {0, 2}, {1, 3}, {2, 3}, {0, 3}},
+		want:  [][2]int64{{0, 1}, {0, 2}, {1, 3}, {2, 3}},
+	},
+	{
+		name:  "already reduced",
+		edges: [][2]int64{{0, 1}, {2, 1}, {1, 3}, {4, 5}},
+		want:  [][2]int64{{0, 1}, {1, 3}, {2, 1}, {4, 5}},
+	},
+	{
+		name:  "cycle",
+		edges: [][2]int64{{0, 1}, {1, 2}, {2, 0}, {2, 3}},
+		cycle: true,
+	},
+	{
+		name:  "self loop",
+		edges: [][2]int64{{0, 1}, {1, 1}},
+		cycle: true,
+	},
+}
+
+func TestTransitiveReduction(t *testing.T) {
+	for _, test := range transitiveReductionTests {
+		g := simple.NewDirectedGraph()
+		for _, e := range test.edges {
+			if e[0] == e[1] {
+				if g.Node(e[0]) == nil {
+					g.AddNode(simple.Node(e[0]))
+				}
+				continue
+			}
+			g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+		}
+		var sg graph.Directed = g
+		if test.name == "self loop" {
+			sg = selfLoop{DirectedGraph: g, id: 1}
+		}
+
+		dst := simple.NewDirectedGraph()
+		err := TransitiveReduction(dst, sg)
+		if test.cycle {
+			if _, ok := err.(Unorderable); !ok {
+				t.Errorf("%s: expected Unorderable error, got:%v", test.name, err)
+			}
+			if dst.Nodes().Len() != 0 {
+				t.Errorf("%s: dst altered for cyclic graph", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if dst.Nodes().Len() != g.Nodes().Len() {
+			t.Errorf("%s: unexpected number of nodes: got:%d want:%d", test.name, dst.Nodes().Len(), g.Nodes().Len())
+		}
+		var got [][2]int64
+		edges := dst.Edges()
+		for edges.Next() {
+			e := edges.Edge()
+			got = append(got, [2]int64{e.From().ID(), e.To().ID()})
+		}
+		sort.Slice(got, func(i, j int) bool {
+			return got[i][0] < got[j][0] || (got[i][0] == got[j][0] && got[i][1] < got[j][1])
+		})
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: unexpected reduction: got:%v want:%v", test.name, got, test.want)
+		}
+	}
+}
+
+// selfLoop is a directed graph with a self loop on the node id.
+type selfLoop struct {
+	*simple.DirectedGraph
+	id int64
+}
+
+func (g selfLoop) HasEdgeFromTo(uid, vid int64) bool {
+	if uid == g.id && vid == g.id {
+		return true
+	}
+	return g.DirectedGraph.HasEdgeFromTo(uid, vid)
+}
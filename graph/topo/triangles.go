@@ -0,0 +1,68 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// CountTriangles returns the number of triangles in the undirected graph g.
+//
+// CountTriangles uses the node-iterator algorithm with nodes ranked by degree so
+// that each triangle is counted once from its lowest ranked node, and only pairs
+// of higher ranked neighbors are examined. Self loops are ignored.
+//
+// The time complexity of CountTriangles is O(|E|^(3/2)).
+func CountTriangles(g graph.Undirected) int {
+	nodes := graph.NodesOf(g.Nodes())
+	rank := make(map[int64]int, len(nodes))
+	degree := make(map[int64]int, len(nodes))
+	for _, u := range nodes {
+		degree[u.ID()] = g.From(u.ID()).Len()
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		di, dj := degree[nodes[i].ID()], degree[nodes[j].ID()]
+		return di < dj || (di == dj && nodes[i].ID() < nodes[j].ID())
+	})
+	for i, u := range nodes {
+		rank[u.ID()] = i
+	}
+
+	// higher holds the neighbors of each node that
+	// have a higher rank than the node.
+	higher := make(map[int64][]int64, len(nodes))
+	for _, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if rank[vid] > rank[uid] {
+				higher[uid] = append(higher[uid], vid)
+			}
+		}
+	}
+
+	var n int
+	mark := make(map[int64]bool)
+	for _, u := range nodes {
+		uid := u.ID()
+		for _, vid := range higher[uid] {
+			mark[vid] = true
+		}
+		for _, vid := range higher[uid] {
+			for _, wid := range higher[vid] {
+				if mark[wid] {
+					n++
+				}
+			}
+		}
+		for _, vid := range higher[uid] {
+			delete(mark, vid)
+		}
+	}
+	return n
+}
@@ -0,0 +1,75 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var countTrianglesTests = []struct {
+	name string
+	g    []intset
+	want int
+}{
+	{
+		name: "K4",
+		g: []intset{
+			0: linksTo(1, 2, 3),
+			1: linksTo(2, 3),
+			2: linksTo(3),
+			3: nil,
+		},
+		want: 4,
+	},
+	{
+		name: "K3,3",
+		g: []intset{
+			0: linksTo(3, 4, 5),
+			1: linksTo(3, 4, 5),
+			2: linksTo(3, 4, 5),
+			3: nil,
+			4: nil,
+			5: nil,
+		},
+		want: 0,
+	},
+	{
+		name: "two triangles sharing an edge with a tail",
+		g: []intset{
+			0: linksTo(1, 2),
+			1: linksTo(2, 3),
+			2: linksTo(3),
+			3: linksTo(4),
+			4: nil,
+		},
+		want: 2,
+	},
+	{
+		name: "batagelj-zaversnik",
+		g:    batageljZaversnikGraph,
+		want: 11,
+	},
+}
+
+func TestCountTriangles(t *testing.T) {
+	for _, test := range countTrianglesTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		got := CountTriangles(g)
+		if got != test.want {
+			t.Errorf("%q: unexpected number of triangles: got:%d want:%d", test.name, got, test.want)
+		}
+	}
+}
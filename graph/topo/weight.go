@@ -0,0 +1,29 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+// Weighting is a mapping between a pair of nodes and a weight. It follows the
+// semantics of the Weighter interface and of path.Weighting.
+type Weighting func(xid, yid int64) (w float64, ok bool)
+
+// UniformCost returns a Weighting that returns an edge cost of 1 for existing
+// edges, zero for node identity and Inf for otherwise absent edges.
+func UniformCost(g traverse.Graph) Weighting {
+	return func(xid, yid int64) (w float64, ok bool) {
+		if xid == yid {
+			return 0, true
+		}
+		if e := g.Edge(xid, yid); e != nil {
+			return 1, true
+		}
+		return math.Inf(1), false
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// WLSignature returns the Weisfeiler-Lehman color refinement signature of g after
+// the given number of refinement iterations. Every node starts with the same label
+// and in each iteration the label of a node is replaced by a hash of its current
+// label and the sorted labels of its neighbors. If g is directed, the labels of
+// nodes reachable from and reaching to a node are considered separately. The
+// returned map holds the number of nodes with each final label.
+//
+// Labels depend only on the structure of g, so signatures of different graphs may
+// be compared. Isomorphic graphs have identical signatures and graphs with
+// different signatures are not isomorphic, up to the unlikely event of a hash
+// collision. The converse does not hold; for example, all regular graphs with the
+// same number of nodes and degree have identical signatures.
+//
+// WLSignature will panic if iterations is negative.
+func WLSignature(g graph.Graph, iterations int) map[string]int {
+	if iterations < 0 {
+		panic("topo: negative iterations")
+	}
+	d, isDirected := g.(graph.Directed)
+	nodes := graph.NodesOf(g.Nodes())
+	label := make(map[int64]string, len(nodes))
+	for _, n := range nodes {
+		label[n.ID()] = "0"
+	}
+	for i := 0; i < iterations; i++ {
+		next := make(map[int64]string, len(nodes))
+		for _, n := range nodes {
+			id := n.ID()
+			sig := label[id] + ":" + neighborLabels(label, g.From(id))
+			if isDirected {
+				sig += "|" + neighborLabels(label, d.To(id))
+			}
+			h := fnv.New64a()
+			h.Write([]byte(sig))
+			next[id] = fmt.Sprintf("%016x", h.Sum64())
+		}
+		label = next
+	}
+
+	hist := make(map[string]int)
+	for _, l := range label {
+		hist[l]++
+	}
+	return hist
+}
+
+// neighborLabels returns a canonical representation of the multiset
+// of labels of the nodes in it.
+func neighborLabels(label map[int64]string, it graph.Nodes) string {
+	var l []string
+	for it.Next() {
+		l = append(l, label[it.Node().ID()])
+	}
+	sort.Strings(l)
+	return strings.Join(l, ",")
+}
@@ -0,0 +1,110 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// relabel adds the nodes and edges of g to dst with node IDs permuted by perm.
+func relabel(g graph.Graph, dst graph.Builder, perm []int) {
+	nodes := graph.NodesOf(g.Nodes())
+	for _, n := range nodes {
+		dst.AddNode(simple.Node(perm[n.ID()]))
+	}
+	for _, n := range nodes {
+		uid := n.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			dst.SetEdge(simple.Edge{F: simple.Node(perm[uid]), T: simple.Node(perm[vid])})
+		}
+	}
+}
+
+func TestWLSignatureIsomorphic(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	grid := gridGraph(3, 4)
+	relabelled := simple.NewUndirectedGraph()
+	relabel(grid, relabelled, rnd.Perm(12))
+
+	directed := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 0}, {2, 3}, {3, 4}, {4, 3}, {1, 5}} {
+		directed.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	relabelledDirected := simple.NewDirectedGraph()
+	relabel(directed, relabelledDirected, rnd.Perm(6))
+
+	for _, test := range []struct {
+		name string
+		a, b graph.Graph
+	}{
+		{name: "grid", a: grid, b: relabelled},
+		{name: "directed", a: directed, b: relabelledDirected},
+	} {
+		for iter := 0; iter <= 4; iter++ {
+			a := WLSignature(test.a, iter)
+			b := WLSignature(test.b, iter)
+			if !reflect.DeepEqual(a, b) {
+				t.Errorf("unexpected signature difference for %s after %d iterations:\n%v\n%v", test.name, iter, a, b)
+			}
+		}
+	}
+}
+
+func TestWLSignatureDifferent(t *testing.T) {
+	path := simple.NewUndirectedGraph()
+	star := simple.NewUndirectedGraph()
+	for i := 1; i < 5; i++ {
+		path.SetEdge(simple.Edge{F: simple.Node(i - 1), T: simple.Node(i)})
+		star.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(i)})
+	}
+
+	// The directed 3-cycle and the transitive
+	// tournament on three nodes have the same
+	// underlying undirected graph.
+	cycle := simple.NewDirectedGraph()
+	tournament := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 0}} {
+		cycle.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {0, 2}} {
+		tournament.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+
+	for _, test := range []struct {
+		name string
+		a, b graph.Graph
+	}{
+		{name: "path and star", a: path, b: star},
+		{name: "grids", a: gridGraph(3, 4), b: gridGraph(2, 6)},
+		{name: "directed", a: cycle, b: tournament},
+	} {
+		// All nodes share the initial label.
+		if a, b := WLSignature(test.a, 0), WLSignature(test.b, 0); !reflect.DeepEqual(a, b) {
+			t.Errorf("unexpected initial signature difference for %s:\n%v\n%v", test.name, a, b)
+		}
+		if a, b := WLSignature(test.a, 3), WLSignature(test.b, 3); reflect.DeepEqual(a, b) {
+			t.Errorf("unexpected signature equality for %s: %v", test.name, a)
+		}
+	}
+
+	// Color refinement does not distinguish regular
+	// graphs of the same order and degree.
+	triangles := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 0}, {3, 4}, {4, 5}, {5, 3}} {
+		triangles.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	if a, b := WLSignature(cycleGraph(6), 3), WLSignature(triangles, 3); !reflect.DeepEqual(a, b) {
+		t.Errorf("unexpected signature difference for regular graphs:\n%v\n%v", a, b)
+	}
+}